@@ -5,17 +5,51 @@ import (
 	"fmt"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"log"
+	_ "modernc.org/sqlite"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/config"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/sqlite"
 )
 
-func ConnectToDB(ConnStr string) (*sql.DB, error) {
-	db, err := sql.Open("pgx", ConnStr)
+func ConnectToDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	driver, dsn := "pgx", cfg.URL
+	if cfg.Driver == config.DriverSQLite {
+		driver, dsn = "sqlite", sqliteDSN(cfg.URL)
+	}
+
+	db, err := sql.Open(driver, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to Connect Database; %w", err)
 	}
+	if cfg.Driver == config.DriverSQLite {
+		// SQLite allows one writer; a single connection queues writes in
+		// the pool instead of failing them with SQLITE_BUSY.
+		db.SetMaxOpenConns(1)
+	} else {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping Database : %w", err)
 	}
 
-	log.Println("Database connected successfully")
+	log.Printf("Database connected successfully (%s)", cfg.Driver)
 	return db, nil
 }
+
+// sqliteDSN opens path with foreign keys enforced, which SQLite leaves off
+// by default, and WAL so readers do not block the writer.
+func sqliteDSN(path string) string {
+	return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+}
+
+// NewStore builds the repository for the configured driver.
+func NewStore(db *sql.DB, cfg *config.Config) repository.Store {
+	if cfg.Database.Driver == config.DriverSQLite {
+		return sqlite.NewSQLiteRepo(db, cfg.Institution.Calendar)
+	}
+	return repository.NewPostgresRepo(db, cfg.Institution.Calendar)
+}
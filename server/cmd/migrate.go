@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository"
+)
+
+const migrateUsage = "usage: migrate [up | down [steps] | status]"
+
+// RunMigrate implements the `migrate` subcommand:
+//
+//	migrate up           apply every pending migration
+//	migrate down [n]     revert the last n migrations (default 1)
+//	migrate status       list migrations and when they were applied
+func RunMigrate(repo repository.Migrator, args []string) error {
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "up":
+		applied, err := repo.MigrateUp()
+		for _, v := range applied {
+			fmt.Printf("applied %04d\n", v)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid step count %q; %s", args[1], migrateUsage)
+			}
+			steps = n
+		}
+		reverted, err := repo.MigrateDown(steps)
+		for _, v := range reverted {
+			fmt.Printf("reverted %04d\n", v)
+		}
+		return err
+	case "status":
+		states, err := repo.MigrationStatus()
+		if err != nil {
+			return err
+		}
+		for _, st := range states {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = "applied " + st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-40s %s\n", st.Version, st.Name, applied)
+		}
+	default:
+		return fmt.Errorf("unknown migrate action %q; %s", action, migrateUsage)
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/openapi"
+	notification_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/notification"
+)
+
+// Security schemes; each names the JWT middleware guarding the route.
+const (
+	studentAuth  = "studentAuth"
+	facultyAuth  = "facultyAuth"
+	adminAuth    = "adminAuth"
+	guardianAuth = "guardianAuth"
+)
+
+// pageParams are read by params.Page on every paginated list.
+var pageParams = []openapi.Param{
+	{Name: "limit", Type: "integer", Description: "Page size, default 50, capped at 200"},
+	{Name: "offset", Type: "integer"},
+	{Name: "sort", Description: "Column to sort by; each list documents its own keys"},
+	{Name: "order", Enum: []string{"asc", "desc"}},
+}
+
+// filterParams are read by params.AttendanceFilter.
+var filterParams = []openapi.Param{
+	{Name: "from", Format: "date"},
+	{Name: "to", Format: "date"},
+	{Name: "term", Description: "Term name; its dates replace from and to"},
+	{Name: "status", Description: "Present or Absent"},
+}
+
+func joinParams(groups ...[]openapi.Param) []openapi.Param {
+	var all []openapi.Param
+	for _, g := range groups {
+		all = append(all, g...)
+	}
+	return all
+}
+
+func queryParam(name string, required bool) []openapi.Param {
+	return []openapi.Param{{Name: name, Required: required}}
+}
+
+var (
+	subjectCodeParam = queryParam("subjectCode", true)
+	classDateParam   = []openapi.Param{{Name: "date", Format: "date", Required: true}}
+	idPath           = []openapi.Param{{Name: "id", In: "path", Type: "integer"}}
+	exportParams     = joinParams(subjectCodeParam, []openapi.Param{
+		{Name: "format", Enum: []string{"csv", "xlsx", "pdf"}, Description: "Defaults to csv"},
+	}, filterParams)
+	defaulterParams = joinParams([]openapi.Param{
+		{Name: "department"},
+		{Name: "sem", Type: "integer"},
+		{Name: "subjectCode"},
+		{Name: "threshold", Type: "number", Description: "Percentage, defaults to ATTENDANCE_THRESHOLD"},
+	}, filterParams)
+	// rangeParams skip status for views that count both.
+	rangeParams     = filterParams[:3:3]
+	analyticsParams = append(rangeParams, openapi.Param{
+		Name: "limit", Type: "integer", Description: "Top absentees to list, default 10, capped at 100",
+	})
+	teachingLogParams = joinParams(queryParam("subjectCode", false), rangeParams)
+	exportFormats     = []string{
+		"text/csv",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/pdf",
+	}
+	flagParams = joinParams([]openapi.Param{
+		{Name: "review", Enum: []string{"open", "accepted", "rejected"}, Description: "Defaults to every review"},
+	}, rangeParams)
+)
+
+// Response data that handlers build from maps.
+type (
+	tokenData struct {
+		Token string `json:"token"`
+	}
+	studentIDData struct {
+		StudentID int64 `json:"student_id"`
+	}
+	subjectIDData struct {
+		SubjectID int64 `json:"subject_id"`
+	}
+	facultyIDData struct {
+		FacultyID int64 `json:"faculty_id"`
+	}
+	insertedData struct {
+		InsertedCount int `json:"inserted_count"`
+		HeldCount     int `json:"held_count"`
+	}
+	assignedData struct {
+		UpdatedCount int64 `json:"updatedCount"`
+		Skipped      int64 `json:"skipped"`
+	}
+	adminIDData struct {
+		AdminID int64 `json:"admin_id"`
+	}
+	termIDData struct {
+		TermID int64 `json:"term_id"`
+	}
+	departmentCodeData struct {
+		Code string `json:"code"`
+	}
+	guardianIDData struct {
+		GuardianID int64 `json:"guardian_id"`
+	}
+	substitutionIDData struct {
+		SubstitutionID int64 `json:"substitution_id"`
+	}
+	swapIDData struct {
+		SwapID int64 `json:"swap_id"`
+	}
+	sessionIDData struct {
+		SessionID int64 `json:"session_id"`
+	}
+	webhookCreatedData struct {
+		WebhookID int64  `json:"webhook_id"`
+		Secret    string `json:"secret"`
+	}
+)
+
+// routes documents every endpoint registered in SetupRoutes; TestRoutesDocumented
+// fails when the two drift apart.
+var routes = []openapi.Route{
+	// Student
+	{Method: http.MethodPost, Path: "/students/register", Tag: "students", Summary: "Register a student",
+		Body: domain.StudentRegisterPayload{}, Data: studentIDData{}},
+	{Method: http.MethodPost, Path: "/students/login", Tag: "students", Summary: "Log in as a student",
+		Body: domain.StudentLoginPayload{}, Data: tokenData{}},
+	{Method: http.MethodGet, Path: "/students/subjects", Tag: "students", Summary: "Subjects of the logged in student",
+		Auth: studentAuth, Data: []domain.SubjectPayload{}},
+	{Method: http.MethodGet, Path: "/students/notifications", Tag: "notifications", Summary: "Notification preferences of the student",
+		Auth: studentAuth, Data: []domain.NotificationPreference{}},
+	{Method: http.MethodPut, Path: "/students/notifications", Tag: "notifications", Summary: "Opt in or out of a notification kind",
+		Auth: studentAuth, Body: domain.NotificationPreferencePayload{}},
+
+	// Subject
+	{Method: http.MethodPost, Path: "/subjects", Tag: "subjects", Summary: "Add a subject",
+		Body: domain.SubjectPayload{}, Data: subjectIDData{}},
+	{Method: http.MethodGet, Path: "/subjects", Tag: "subjects", Summary: "Subjects of a department and sem",
+		Params: joinParams([]openapi.Param{{Name: "department", Required: true}, {Name: "sem", Type: "integer", Required: true}}, pageParams),
+		Data:   []domain.Subject{}, Paged: true},
+	{Method: http.MethodGet, Path: "/subjects/faculty", Tag: "subjects", Summary: "Subjects taught by the logged in faculty",
+		Auth: facultyAuth, Data: []domain.Subject{}},
+
+	// Faculty
+	{Method: http.MethodPost, Path: "/faculty/register", Tag: "faculty", Summary: "Register a faculty member",
+		Body: domain.FacultyRegisterPayload{}, Data: facultyIDData{}},
+	{Method: http.MethodPost, Path: "/faculty/login", Tag: "faculty", Summary: "Log in as faculty",
+		Body: domain.FacultyLoginPayload{}, Data: tokenData{}},
+	{Method: http.MethodGet, Path: "/faculty/getfaculty", Tag: "faculty", Summary: "Profile of the logged in faculty",
+		Auth: facultyAuth, Data: domain.Faculty{}},
+	{Method: http.MethodGet, Path: "/faculty", Tag: "faculty", Summary: "List faculty",
+		Params: joinParams(queryParam("department", false), []openapi.Param{{Name: "q", Description: "Matches name or email"}}, pageParams),
+		Data:   []domain.Faculty{}, Paged: true},
+	{Method: http.MethodGet, Path: "/faculty/department/:dept", Tag: "faculty", Summary: "Faculty of a department",
+		Params: pageParams, Data: []domain.Faculty{}, Paged: true},
+	{Method: http.MethodGet, Path: "/faculty/notifications", Tag: "notifications", Summary: "Notification preferences of the faculty",
+		Auth: facultyAuth, Data: []domain.NotificationPreference{}},
+	{Method: http.MethodPut, Path: "/faculty/notifications", Tag: "notifications", Summary: "Opt in or out of a notification kind",
+		Auth: facultyAuth, Body: domain.NotificationPreferencePayload{}},
+	{Method: http.MethodPost, Path: "/faculty/sessions", Tag: "teaching", Summary: "Log a taught session",
+		Auth: facultyAuth, Body: domain.TeachingSessionPayload{}, Data: sessionIDData{}},
+	{Method: http.MethodGet, Path: "/faculty/sessions", Tag: "teaching", Summary: "Teaching log of the logged in faculty",
+		Auth: facultyAuth, Params: teachingLogParams, Data: domain.TeachingLogReport{}},
+	{Method: http.MethodPut, Path: "/faculty/sessions/:id", Tag: "teaching", Summary: "Correct a logged session",
+		Auth: facultyAuth, Params: idPath, Body: domain.TeachingSessionUpdatePayload{}},
+	{Method: http.MethodDelete, Path: "/faculty/sessions/:id", Tag: "teaching", Summary: "Delete a logged session",
+		Auth: facultyAuth, Params: idPath},
+	{Method: http.MethodGet, Path: "/faculty/substitutions", Tag: "substitutions", Summary: "Classes the logged in faculty covers or hands over",
+		Auth: facultyAuth, Params: rangeParams, Data: []domain.Substitution{}},
+	{Method: http.MethodGet, Path: "/faculty/flags", Tag: "anomalies", Summary: "Flagged captures of the logged in faculty's department",
+		Auth: facultyAuth, Params: flagParams, Data: []domain.AnomalyFlag{}},
+	{Method: http.MethodPost, Path: "/faculty/flags/:id/review", Tag: "anomalies", Summary: "Accept a flagged capture as attendance or reject it",
+		Auth: facultyAuth, Params: idPath, Body: domain.FlagReviewPayload{}, Data: domain.AnomalyFlag{}},
+	{Method: http.MethodGet, Path: "/faculty/flags/:id/face-crop", Tag: "anomalies", Summary: "Face crop sent with a flagged capture",
+		Auth: facultyAuth, Params: idPath, Produces: []string{"image/jpeg", "image/png"}},
+
+	// Attendance
+	{Method: http.MethodPost, Path: "/attendance", Tag: "attendance", Summary: "Record one capture, or hold it for review if it looks anomalous",
+		Body: domain.AttendancePayload{}, Data: domain.CaptureResult{}},
+	{Method: http.MethodPost, Path: "/attendance/bulk", Tag: "attendance", Summary: "Record a batch of captures, holding anomalous ones for review",
+		Body: []domain.AttendancePayload{}, Data: insertedData{}},
+	{Method: http.MethodGet, Path: "/attendance", Tag: "attendance", Summary: "Attendance of the logged in student in a subject",
+		Auth: studentAuth, Params: joinParams(subjectCodeParam, filterParams, pageParams),
+		Data: []domain.AttendanceWithNames{}, Paged: true},
+	{Method: http.MethodGet, Path: "/attendance/subject", Tag: "attendance", Summary: "Attendance of a subject on a date",
+		Params: joinParams(subjectCodeParam, classDateParam), Data: []domain.AttendanceWithNames{}},
+	{Method: http.MethodGet, Path: "/attendance/summary/subject", Tag: "attendance", Summary: "Per-student summary of a subject",
+		Params: joinParams(subjectCodeParam, filterParams, pageParams), Data: []domain.StudentSummary{}, Paged: true},
+	{Method: http.MethodGet, Path: "/attendance/class", Tag: "attendance", Summary: "Class list with status on a date",
+		Params: joinParams(subjectCodeParam, classDateParam), Data: []domain.ClassAttendance{}},
+	{Method: http.MethodGet, Path: "/attendance/student/history", Tag: "attendance", Summary: "Day by day history of the logged in student",
+		Auth: studentAuth, Params: joinParams(queryParam("subjectCode", false), filterParams, pageParams),
+		Data: []domain.StudentHistory{}, Paged: true},
+	{Method: http.MethodPost, Path: "/attendance/assignsubject", Tag: "attendance", Summary: "Assign unassigned captures in a time range to a subject",
+		Auth: facultyAuth, Body: domain.AssignSubjectPayload{}, Data: assignedData{}},
+	{Method: http.MethodGet, Path: "/attendance/summary/student", Tag: "attendance", Summary: "Per-subject summary of the logged in student",
+		Auth: studentAuth, Params: filterParams, Data: []domain.SubjectSummary{}},
+	{Method: http.MethodGet, Path: "/attendance/student/calendar", Tag: "attendance", Summary: "Month calendar of the logged in student for a heatmap",
+		Auth: studentAuth, Params: []openapi.Param{{Name: "month", Required: true, Description: "YYYY-MM"}}, Data: domain.AttendanceCalendar{}},
+	{Method: http.MethodGet, Path: "/attendance/student/trend", Tag: "attendance", Summary: "Weekly and rolling percentages per subject of the logged in student",
+		Auth: studentAuth, Params: rangeParams, Data: []domain.SubjectTrend{}},
+	{Method: http.MethodGet, Path: "/attendance/student/projection", Tag: "attendance", Summary: "Projected end-of-term percentage per subject of the logged in student",
+		Auth: studentAuth, Params: rangeParams, Data: domain.AttendanceProjection{}},
+	{Method: http.MethodGet, Path: "/attendance/export", Tag: "reports", Summary: "Download the register of a subject you teach or substitute for",
+		Auth: facultyAuth, Params: exportParams, Produces: exportFormats},
+	{Method: http.MethodGet, Path: "/attendance/defaulters", Tag: "reports", Summary: "Students below the eligibility threshold in your subjects",
+		Auth: facultyAuth, Params: defaulterParams, Data: domain.DefaulterReport{}},
+	{Method: http.MethodGet, Path: "/attendance/live", Tag: "attendance", Summary: "Server-Sent Events feed of a subject",
+		Auth: facultyAuth, Params: joinParams(subjectCodeParam, []openapi.Param{
+			{Name: "access_token", Description: "JWT for EventSource clients that cannot set headers"},
+		}), Produces: []string{"text/event-stream"}},
+	{Method: http.MethodPatch, Path: "/attendance/:id/status", Tag: "attendance", Summary: "Correct one attendance row",
+		Auth: facultyAuth, Params: idPath, Body: domain.AttendanceCorrectionPayload{}, Data: domain.AttendanceCorrection{}},
+
+	// Admin
+	{Method: http.MethodPost, Path: "/admin/login", Tag: "admin", Summary: "Log in as admin",
+		Body: domain.AdminLoginPayload{}, Data: tokenData{}},
+	{Method: http.MethodPost, Path: "/admin/register", Tag: "admin", Summary: "Create another admin",
+		Auth: adminAuth, Body: domain.AdminRegisterPayload{}, Data: adminIDData{}},
+	{Method: http.MethodPost, Path: "/admin/import/:entity", Tag: "admin", Summary: "Import a CSV or XLSX roster",
+		Auth: adminAuth, Params: []openapi.Param{
+			{Name: "entity", In: "path", Enum: []string{"students", "faculty", "subjects"}},
+			{Name: "dry_run", Type: "boolean"},
+		}, Upload: "file", Data: domain.ImportResult{}},
+	{Method: http.MethodGet, Path: "/admin/attendance/export", Tag: "reports", Summary: "Download the attendance register",
+		Auth: adminAuth, Params: exportParams, Produces: exportFormats},
+	{Method: http.MethodGet, Path: "/admin/attendance/defaulters", Tag: "reports", Summary: "Students below the eligibility threshold",
+		Auth: adminAuth, Params: defaulterParams, Data: domain.DefaulterReport{}},
+	{Method: http.MethodPut, Path: "/admin/condonations", Tag: "reports", Summary: "Condone classes for a student",
+		Auth: adminAuth, Body: domain.CondonationPayload{}},
+	{Method: http.MethodDelete, Path: "/admin/condonations", Tag: "reports", Summary: "Remove a condonation",
+		Auth: adminAuth, Params: joinParams(queryParam("usn", true), subjectCodeParam)},
+	{Method: http.MethodPost, Path: "/admin/terms", Tag: "terms", Summary: "Create a term",
+		Auth: adminAuth, Body: domain.TermPayload{}, Data: termIDData{}},
+	{Method: http.MethodPost, Path: "/admin/departments", Tag: "departments", Summary: "Create a department",
+		Auth: adminAuth, Body: domain.DepartmentPayload{}, Data: departmentCodeData{}},
+	{Method: http.MethodPut, Path: "/admin/departments/:code", Tag: "departments", Summary: "Update a department's name, HOD and semesters",
+		Auth: adminAuth, Body: domain.DepartmentUpdatePayload{}},
+	{Method: http.MethodDelete, Path: "/admin/departments/:code", Tag: "departments", Summary: "Delete a department nobody belongs to",
+		Auth: adminAuth},
+	{Method: http.MethodGet, Path: "/admin/departments/:code/analytics", Tag: "departments", Summary: "Attendance analytics of a department",
+		Auth: adminAuth, Params: analyticsParams, Data: domain.DepartmentAnalytics{}},
+	{Method: http.MethodGet, Path: "/admin/teaching-log", Tag: "teaching", Summary: "Teaching log for accreditation audits",
+		Auth: adminAuth, Params: joinParams([]openapi.Param{{Name: "faculty_id", Type: "integer"}}, teachingLogParams),
+		Data: domain.TeachingLogReport{}},
+	{Method: http.MethodPut, Path: "/admin/subjects/:code/planned-classes", Tag: "subjects", Summary: "Set the planned class count",
+		Auth: adminAuth, Body: domain.PlannedClassesPayload{}},
+	{Method: http.MethodGet, Path: "/admin/class-advisors", Tag: "notifications", Summary: "List class advisors",
+		Auth: adminAuth, Data: []domain.ClassAdvisor{}},
+	{Method: http.MethodPut, Path: "/admin/class-advisors", Tag: "notifications", Summary: "Set the advisor of a department and sem",
+		Auth: adminAuth, Body: domain.ClassAdvisorPayload{}},
+	{Method: http.MethodPost, Path: "/admin/guardians", Tag: "guardians", Summary: "Register a guardian",
+		Auth: adminAuth, Body: domain.GuardianPayload{}, Data: guardianIDData{}},
+	{Method: http.MethodPost, Path: "/admin/guardians/:id/students", Tag: "guardians", Summary: "Link a guardian to a student",
+		Auth: adminAuth, Params: idPath, Body: domain.GuardianLinkPayload{}},
+	{Method: http.MethodGet, Path: "/admin/students/:usn/guardians", Tag: "guardians", Summary: "Guardians of a student",
+		Auth: adminAuth, Data: []domain.Guardian{}},
+	{Method: http.MethodPost, Path: "/admin/webhooks", Tag: "webhooks", Summary: "Register a webhook",
+		Auth: adminAuth, Body: domain.WebhookPayload{}, Data: webhookCreatedData{}},
+	{Method: http.MethodGet, Path: "/admin/webhooks", Tag: "webhooks", Summary: "List webhooks",
+		Auth: adminAuth, Data: []domain.Webhook{}},
+	{Method: http.MethodDelete, Path: "/admin/webhooks/:id", Tag: "webhooks", Summary: "Delete a webhook",
+		Auth: adminAuth, Params: idPath},
+	{Method: http.MethodGet, Path: "/admin/webhooks/deliveries", Tag: "webhooks", Summary: "List deliveries",
+		Auth: adminAuth, Params: joinParams([]openapi.Param{
+			{Name: "webhook_id", Type: "integer"},
+			{Name: "status", Enum: []string{domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryDead}},
+		}, pageParams), Data: []domain.WebhookDelivery{}, Paged: true},
+	{Method: http.MethodGet, Path: "/admin/webhooks/deliveries/:id", Tag: "webhooks", Summary: "One delivery with its last response",
+		Auth: adminAuth, Params: idPath, Data: domain.WebhookDelivery{}},
+	{Method: http.MethodPost, Path: "/admin/webhooks/deliveries/:id/replay", Tag: "webhooks", Summary: "Queue a delivery again",
+		Auth: adminAuth, Params: idPath},
+	{Method: http.MethodPost, Path: "/admin/notifications/run/:job", Tag: "notifications", Summary: "Run a notification job now",
+		Auth: adminAuth, Params: []openapi.Param{{Name: "job", In: "path", Enum: []string{
+			notification_service.JobLowAttendance, notification_service.JobWeeklyDigest, notification_service.JobAbsenteeSummary,
+		}}}, Data: domain.NotificationRun{}},
+
+	// Head of department
+	{Method: http.MethodGet, Path: "/hod/departments/:code/analytics", Tag: "departments", Summary: "Attendance analytics of the HOD's department",
+		Auth: facultyAuth, Params: analyticsParams, Data: domain.DepartmentAnalytics{}},
+	{Method: http.MethodPost, Path: "/hod/departments/:code/substitutions", Tag: "substitutions", Summary: "Assign a substitute for a subject's classes on a date",
+		Auth: facultyAuth, Body: domain.SubstitutionPayload{}, Data: substitutionIDData{}},
+	{Method: http.MethodGet, Path: "/hod/departments/:code/substitutions", Tag: "substitutions", Summary: "Substitutions in the department",
+		Auth: facultyAuth, Params: rangeParams, Data: []domain.Substitution{}},
+	{Method: http.MethodDelete, Path: "/hod/departments/:code/substitutions/:id", Tag: "substitutions", Summary: "Withdraw a substitution",
+		Auth: facultyAuth, Params: idPath},
+	{Method: http.MethodPost, Path: "/hod/departments/:code/swaps", Tag: "substitutions", Summary: "Record two subjects swapping slots",
+		Auth: facultyAuth, Body: domain.ClassSwapPayload{}, Data: swapIDData{}},
+	{Method: http.MethodGet, Path: "/hod/departments/:code/swaps", Tag: "substitutions", Summary: "Class swaps in the department",
+		Auth: facultyAuth, Params: rangeParams, Data: []domain.ClassSwap{}},
+	{Method: http.MethodDelete, Path: "/hod/departments/:code/swaps/:id", Tag: "substitutions", Summary: "Delete a class swap record",
+		Auth: facultyAuth, Params: idPath},
+
+	// Guardian
+	{Method: http.MethodPost, Path: "/guardians/login", Tag: "guardians", Summary: "Log in as a guardian",
+		Body: domain.GuardianLoginPayload{}, Data: tokenData{}},
+	{Method: http.MethodGet, Path: "/guardians/wards", Tag: "guardians", Summary: "Students linked to the guardian",
+		Auth: guardianAuth, Data: []domain.Ward{}},
+	{Method: http.MethodGet, Path: "/guardians/wards/:usn/summary", Tag: "guardians", Summary: "Per-subject summary of a ward",
+		Auth: guardianAuth, Params: filterParams, Data: []domain.SubjectSummary{}},
+	{Method: http.MethodGet, Path: "/guardians/wards/:usn/history", Tag: "guardians", Summary: "Day by day history of a ward",
+		Auth: guardianAuth, Params: joinParams(queryParam("subjectCode", false), filterParams, pageParams),
+		Data: []domain.StudentHistory{}, Paged: true},
+	{Method: http.MethodGet, Path: "/guardians/notifications", Tag: "notifications", Summary: "Notification preferences of the guardian",
+		Auth: guardianAuth, Data: []domain.NotificationPreference{}},
+	{Method: http.MethodPut, Path: "/guardians/notifications", Tag: "notifications", Summary: "Opt in or out of a notification kind",
+		Auth: guardianAuth, Body: domain.NotificationPreferencePayload{}},
+
+	{Method: http.MethodGet, Path: "/terms", Tag: "terms", Summary: "List terms", Data: []domain.Term{}},
+	{Method: http.MethodGet, Path: "/departments", Tag: "departments", Summary: "List departments", Data: []domain.Department{}},
+	{Method: http.MethodGet, Path: "/departments/:code", Tag: "departments", Summary: "One department", Data: domain.Department{}},
+	{Method: http.MethodGet, Path: "/", Tag: "health", Summary: "Health check", Produces: []string{"text/plain"}},
+	{Method: http.MethodGet, Path: "/openapi.json", Tag: "health", Summary: "This document", Produces: []string{"application/json"}},
+	{Method: http.MethodGet, Path: "/docs", Tag: "health", Summary: "Swagger UI", Produces: []string{"text/html"}},
+}
+
+// NewAPIDocument builds the OpenAPI document served at /openapi.json.
+func NewAPIDocument() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:   "Smart Attendance System API",
+		Version: "1.0.0",
+		Description: "Successful JSON responses are wrapped in {status, message, data, meta}; " +
+			"errors are {status: \"error\", error}.",
+	}, map[string]string{
+		studentAuth:  "Token from POST /students/login",
+		facultyAuth:  "Token from POST /faculty/login",
+		adminAuth:    "Token from POST /admin/login",
+		guardianAuth: "Token from POST /guardians/login",
+	})
+	doc.Add(routes...)
+	return doc
+}
@@ -0,0 +1,124 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/cmd"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/config"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/supervisor"
+)
+
+// memoryStore is always migrated.
+type memoryStore struct {
+	*memory.MemoryRepo
+}
+
+func (memoryStore) MigrateUp() ([]int, error)                             { return nil, nil }
+func (memoryStore) MigrateDown(int) ([]int, error)                        { return nil, nil }
+func (memoryStore) MigrationStatus() ([]repository.MigrationState, error) { return nil, nil }
+func (memoryStore) CheckSchema() error                                    { return nil }
+
+func newServer(t *testing.T) *echo.Echo {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Institution.Calendar = calendar.New(time.UTC)
+
+	sup := supervisor.New(5 * time.Second)
+	t.Cleanup(func() { _ = sup.Stop() })
+
+	e := echo.New()
+	cmd.SetupRoutes(e, memoryStore{memory.NewMemoryRepo(cfg.Institution.Calendar)}, &cfg, sup)
+	return e
+}
+
+func TestRoutesDocumented(t *testing.T) {
+	e := newServer(t)
+	doc := cmd.NewAPIDocument()
+
+	if missing := doc.Undocumented(e.Routes()); len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI document: %v", missing)
+	}
+
+	registered := map[string]bool{}
+	for _, r := range e.Routes() {
+		registered[r.Method+" "+r.Path] = true
+	}
+	for path, item := range doc.Paths {
+		for method := range *item {
+			echoPath := path
+			for _, p := range []string{"id", "dept", "entity", "code", "usn", "job"} {
+				echoPath = strings.ReplaceAll(echoPath, "{"+p+"}", ":"+p)
+			}
+			if key := strings.ToUpper(method) + " " + echoPath; !registered[key] {
+				t.Errorf("documented route %s is not registered", key)
+			}
+		}
+	}
+}
+
+func TestValidatorEnforcesSpec(t *testing.T) {
+	e := newServer(t)
+
+	tests := []struct {
+		name, method, target, body string
+		code                       int
+		errContains                string
+	}{
+		{"non-integer query", http.MethodGet, "/subjects?department=CSE&sem=five", "", http.StatusBadRequest, "query parameter sem must be an integer"},
+		{"missing query", http.MethodGet, "/subjects?sem=5", "", http.StatusBadRequest, "query parameter department is required"},
+		{"bad enum in body", http.MethodPost, "/attendance", `{"usn":"1RV21CS001","status":"Late","recorded_at":"2025-06-02T09:00:00Z"}`, http.StatusBadRequest, "body.status must be one of Present, Absent"},
+		{"missing body field", http.MethodPost, "/attendance", `{"status":"Present","recorded_at":"2025-06-02T09:00:00Z"}`, http.StatusBadRequest, "body.usn is required"},
+		{"array items", http.MethodPost, "/attendance/bulk", `[{"usn":"1RV21CS001","status":"Present","recorded_at":"yesterday"}]`, http.StatusBadRequest, "body[0].recorded_at must be an RFC 3339 timestamp"},
+		{"valid", http.MethodGet, "/subjects?department=CSE&sem=5", "", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.code {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.code, rec.Body)
+			}
+			if tt.errContains != "" && !strings.Contains(rec.Body.String(), tt.errContains) {
+				t.Errorf("body %s does not mention %q", rec.Body, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestServesDocument(t *testing.T) {
+	e := newServer(t)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+
+	var doc struct {
+		OpenAPI    string                    `json:"openapi"`
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.0.3" || doc.Paths["/attendance/{id}/status"]["patch"] == nil {
+		t.Errorf("unexpected document: openapi %q, %d paths", doc.OpenAPI, len(doc.Paths))
+	}
+	if doc.Components.Schemas["AttendancePayload"] == nil {
+		t.Error("AttendancePayload schema missing from components")
+	}
+}
@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"log"
-	"os"
 
 	"github.com/streadway/amqp"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/config"
 )
 
 type RabbitmqConnection struct {
@@ -12,15 +12,12 @@ type RabbitmqConnection struct {
 	chann *amqp.Channel
 }
 
-func NewRabbitmqConnection() *RabbitmqConnection {
-	rabbitmqUrl := os.Getenv("RABBITMQ_URL")
-
-	log.Print(rabbitmqUrl)
-	if rabbitmqUrl == "" {
-		log.Fatalln("missing rabbitmq url env variable")
+func NewRabbitmqConnection(cfg config.BrokerConfig) *RabbitmqConnection {
+	if cfg.URL == "" {
+		log.Fatalln("missing rabbitmq url (RABBITMQ_URL)")
 	}
 
-	conn, err := amqp.Dial(rabbitmqUrl)
+	conn, err := amqp.Dial(cfg.URL)
 
 	if err != nil {
 		log.Fatalln("failed to connect rabbitmq broker Error:", err.Error())
@@ -32,10 +29,8 @@ func NewRabbitmqConnection() *RabbitmqConnection {
 		log.Fatalln("failed to open rabbitmq channel , Error:", err.Error())
 	}
 
-	QUEUE_NAME := os.Getenv("QUEUE_NAME")
-
 	_, err = ch.QueueDeclare(
-		QUEUE_NAME,
+		cfg.QueueName,
 		false,
 		false,
 		false,
@@ -53,3 +48,12 @@ func NewRabbitmqConnection() *RabbitmqConnection {
 		chann: ch,
 	}
 }
+
+// Close closes the channel and then the connection.
+func (r *RabbitmqConnection) Close() error {
+	if err := r.chann.Close(); err != nil {
+		_ = r.conn.Close()
+		return err
+	}
+	return r.conn.Close()
+}
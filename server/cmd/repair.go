@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository"
+)
+
+const repairUsage = "usage: repair-dates [--dry-run]"
+
+// RunRepairDates implements the `repair-dates` subcommand. It moves
+// attendance rows to the class date of their recorded_at in the institution
+// timezone, fixing rows that older builds filed under the UTC day:
+//
+//	repair-dates             move the rows
+//	repair-dates --dry-run   only list what would move
+func RunRepairDates(repo repository.DateRepairer, args []string) error {
+	dryRun := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		default:
+			return fmt.Errorf("unknown argument %q; %s", arg, repairUsage)
+		}
+	}
+
+	repairs, err := repo.RepairAttendanceDates(dryRun)
+	if err != nil {
+		return err
+	}
+
+	moved, conflicts := 0, 0
+	for _, r := range repairs {
+		line := fmt.Sprintf("attendance %d (%s, recorded %s): %s -> %s", r.AttendanceID, r.USN,
+			r.RecordedAt.UTC().Format("2006-01-02 15:04:05Z"),
+			r.OldDate.Format(calendar.DateLayout), r.NewDate.Format(calendar.DateLayout))
+		if r.Conflict {
+			conflicts++
+			fmt.Println(line, "SKIPPED: the student already has a row on that date")
+			continue
+		}
+		moved++
+		fmt.Println(line)
+	}
+
+	verb := "moved"
+	if dryRun {
+		verb = "would move"
+	}
+	fmt.Printf("%s %d rows; %d conflicts left as they are\n", verb, moved, conflicts)
+	return nil
+}
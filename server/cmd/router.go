@@ -1,31 +1,83 @@
 package cmd
 
 import (
-	"database/sql"
+	"encoding/base64"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 
+	admin_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/admin"
+	analytics_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/analytics"
+	anomaly_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/anomaly"
 	attendance_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/attendance"
+	department_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/department"
+	export_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/export"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/faculty"
+	guardian_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/guardian"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/httperror"
+	import_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/importer"
+	notification_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/notification"
+	realtime_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/realtime"
+	report_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/report"
+	term_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/term"
+	substitution_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/substitution"
+	teaching_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/teaching"
+	trend_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/trend"
+	webhook_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/webhook"
 	student_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/student"
 	subject_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/subjects"
+	adminmiddlerware "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/admin_middlerware.go"
 	facultymiddlerware "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/faculty_middlerware.go"
+	guardianmiddlerware "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/guardian_middlerware.go"
 	studentmiddlerwarego "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/student_middlerware.go"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/blob"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/config"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/notify"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/openapi"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/realtime"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/supervisor"
 
+	admin_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/admin"
+	analytics_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/analytics"
+	anomaly_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/anomaly"
 	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
+	department_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/department"
+	export_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/export"
 	faculty_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/faculty"
+	guardian_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/guardian"
+	import_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/importer"
+	notification_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/notification"
+	report_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/report"
+	term_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/term"
+	substitution_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/substitution"
+	teaching_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/teaching"
+	trend_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/trend"
+	webhook_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/webhook"
 	student_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/student"
 	subject_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/subject"
 )
-func SetupRoutes(e *echo.Echo, db *sql.DB) {
-	repo := repository.NewPostgresRepo(db)
 
-	if err := repo.InitTables(); err != nil {
-		log.Fatalf("Error Initializing the tables")
+// Request body limits of the capture routes: captureJSONBytes covers every
+// field of a capture but its face crop, and bulkCaptureCrops is how many
+// full-size crops one bulk upload may carry.
+const (
+	captureJSONBytes = 4 << 10
+	bulkCaptureCrops = 100
+)
+
+// SetupRoutes wires services and routes and hands background workers to sup
+// so they stop cleanly on shutdown.
+func SetupRoutes(e *echo.Echo, repo repository.Store, cfg *config.Config, sup *supervisor.Supervisor) {
+	if err := repo.CheckSchema(); err != nil {
+		log.Fatalf("Database schema is not up to date (%v); run `go run main.go migrate up` first", err)
 	}
+	e.HTTPErrorHandler = httperror.Handler
 
 	studentService := student_service.NewStudentService(repo)
 	studentHandler := student_handler.NewStudentHandler(studentService)
@@ -36,9 +88,108 @@ func SetupRoutes(e *echo.Echo, db *sql.DB) {
 	facultyService := faculty_service.NewFacultyService(repo)
 	facultyHandler := faculty.NewFacultyHandler(facultyService)
 
-	attendanceService := attendence_service.NewAttendanceService(repo)
-	attendanceHandler := attendance_handler.NewAttendanceHandler(attendanceService)
+	hub := realtime.NewHub()
+	// End live feeds as soon as shutdown starts; otherwise they hold the
+	// HTTP drain open until it times out.
+	e.Server.RegisterOnShutdown(hub.Close)
+	e.TLSServer.RegisterOnShutdown(hub.Close)
+
+	webhookService := webhook_service.NewWebhookService(repo, nil, webhook_service.DefaultConfig())
+	webhookHandler := webhook_handler.NewWebhookHandler(webhookService)
+	sup.Go("webhook sender", webhookService.Run)
+
+	publishers := domain.Publishers{hub, webhookService}
+
+	// Captures are screened before they count; anomalous ones wait in the
+	// faculty review queue.
+	evidence, err := blob.NewLocalStore(cfg.Evidence.Dir)
+	if err != nil {
+		log.Fatalf("Error initializing evidence store: %v", err)
+	}
+	anomalyService := anomaly_service.NewAnomalyService(repo, repo, repo, repo, publishers, anomaly_service.Config{
+		TravelWindow:     cfg.Anomaly.TravelWindow,
+		BurstWindow:      cfg.Anomaly.BurstWindow,
+		BurstSize:        cfg.Anomaly.BurstSize,
+		MinConfidence:    cfg.Anomaly.MinConfidence,
+		SessionGrace:     cfg.Anomaly.SessionGrace,
+		Calendar:         cfg.Institution.Calendar,
+		Evidence:         evidence,
+		MaxFaceCropBytes: cfg.Evidence.MaxFaceCropBytes,
+	})
+	anomalyHandler := anomaly_handler.NewAnomalyHandler(anomalyService)
+
+	attendanceService := attendence_service.NewAttendanceService(repo, repo, repo, publishers, anomalyService)
+	attendanceHandler := attendance_handler.NewAttendanceHandler(attendanceService, cfg.Institution.Calendar)
+	liveHandler := realtime_handler.NewLiveHandler(hub, attendanceService, cfg.Institution.Calendar)
+
+	guardianService := guardian_service.NewGuardianService(repo)
+	guardianHandler := guardian_handler.NewGuardianHandler(guardianService, attendanceService)
+
+	adminService := admin_service.NewAdminService(repo)
+	adminHandler := admin_handler.NewAdminHandler(adminService)
 
+	importService := import_service.NewImportService(repo)
+	importHandler := import_handler.NewImportHandler(importService)
+
+	exportService := export_service.NewExportService(repo, repo, repo, repo)
+	exportHandler := export_handler.NewExportHandler(exportService)
+
+	reportService := report_service.NewReportService(repo, repo, repo, cfg.Institution.AttendanceThreshold)
+	reportHandler := report_handler.NewReportHandler(reportService)
+
+	termService := term_service.NewTermService(repo)
+	termHandler := term_handler.NewTermHandler(termService)
+
+	trendService := trend_service.NewTrendService(repo, repo, cfg.Institution.AttendanceThreshold)
+	trendHandler := trend_handler.NewTrendHandler(trendService)
+
+	departmentService := department_service.NewDepartmentService(repo)
+	departmentHandler := department_handler.NewDepartmentHandler(departmentService)
+
+	analyticsService := analytics_service.NewAnalyticsService(repo, repo, repo)
+	analyticsHandler := analytics_handler.NewAnalyticsHandler(analyticsService)
+
+	teachingService := teaching_service.NewTeachingService(repo, repo, cfg.Institution.Calendar)
+	teachingHandler := teaching_handler.NewTeachingHandler(teachingService)
+
+	substitutionService := substitution_service.NewSubstitutionService(repo, repo, repo)
+	substitutionHandler := substitution_handler.NewSubstitutionHandler(substitutionService)
+
+	// Email goes out only when SMTP_HOST is set; MailHog on localhost:1025
+	// works for local testing.
+	var channel notify.Channel
+	if cfg.SMTP.Host != "" {
+		channel = notify.NewSMTPChannel(notify.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		})
+	}
+
+	notificationService, err := notification_service.NewNotificationService(repo, repo, channel, notification_service.Config{
+		Threshold: cfg.Institution.AttendanceThreshold,
+		Calendar:  cfg.Institution.Calendar,
+		DailyHour: cfg.Notifications.DailyHour,
+		DigestDay: time.Monday,
+	})
+	if err != nil {
+		log.Fatalf("Error initializing notifications: %v", err)
+	}
+	notificationHandler := notification_handler.NewNotificationHandler(notificationService)
+	sup.Go("notification scheduler", notificationService.Run)
+
+	// Bootstrap the first admin from config so a fresh database is usable.
+	if cfg.Admin.Email != "" {
+		if err := adminService.EnsureAdmin(domain.AdminRegisterPayload{
+			Username: cfg.Admin.Username,
+			Email:    cfg.Admin.Email,
+			Password: cfg.Admin.Password,
+		}); err != nil {
+			log.Fatalf("Error creating bootstrap admin: %v", err)
+		}
+	}
 
 	//  Student 
 	student := e.Group("/students")
@@ -48,6 +199,8 @@ func SetupRoutes(e *echo.Echo, db *sql.DB) {
 		//student.PUT("/:student_id", studentHandler.UpdateStudentInfoHandler) 
 		//student.GET("/:student_id", studentHandler.GetStudentByIDHandler)    
 		student.GET("/subjects", subjectHandler.GetSubjectsByStudentIDHandler, studentmiddlerwarego.JWTMiddleware) 
+		student.GET("/notifications", notificationHandler.GetPreferencesHandler, studentmiddlerwarego.JWTMiddleware)
+		student.PUT("/notifications", notificationHandler.SetPreferenceHandler, studentmiddlerwarego.JWTMiddleware)
 
 	//  Subject 
 	subject := e.Group("/subjects")
@@ -67,12 +220,25 @@ func SetupRoutes(e *echo.Echo, db *sql.DB) {
 		faculty.GET("/getfaculty", facultyHandler.GetFacultyByIDHandler,facultymiddlerware.FacultyJWTMiddleware) 
 		faculty.GET("", facultyHandler.GetAllFacultyHandler)              
 		faculty.GET("/department/:dept", facultyHandler.GetFacultyByDepartmentHandler) 
+		faculty.GET("/notifications", notificationHandler.GetPreferencesHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.PUT("/notifications", notificationHandler.SetPreferenceHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.POST("/sessions", teachingHandler.LogSessionHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.GET("/sessions", teachingHandler.GetFacultyTeachingLogHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.PUT("/sessions/:id", teachingHandler.UpdateSessionHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.DELETE("/sessions/:id", teachingHandler.DeleteSessionHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.GET("/substitutions", substitutionHandler.GetFacultySubstitutionsHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.GET("/flags", anomalyHandler.GetFlagsHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.POST("/flags/:id/review", anomalyHandler.ReviewFlagHandler, facultymiddlerware.FacultyJWTMiddleware)
+		faculty.GET("/flags/:id/face-crop", anomalyHandler.GetFaceCropHandler, facultymiddlerware.FacultyJWTMiddleware)
 	}
 
+	// A capture is a few hundred bytes of JSON plus at most one base64 face
+	// crop; a bulk upload may carry bulkCaptureCrops full-size crops.
+	captureLimit := base64.StdEncoding.EncodedLen(cfg.Evidence.MaxFaceCropBytes) + captureJSONBytes
 	attendance := e.Group("/attendance")
 	{
-		attendance.POST("", attendanceHandler.MarkAttendanceHandler)
-		attendance.POST("/bulk", attendanceHandler.BulkAttendanceHandler,)
+		attendance.POST("", attendanceHandler.MarkAttendanceHandler, middleware.BodyLimit(strconv.Itoa(captureLimit)))
+		attendance.POST("/bulk", attendanceHandler.BulkAttendanceHandler, middleware.BodyLimit(strconv.Itoa(bulkCaptureCrops*captureLimit)))
 		attendance.GET("", attendanceHandler.GetAttendanceByStudentAndSubjectHandler,studentmiddlerwarego.JWTMiddleware)
 		attendance.GET("/subject", attendanceHandler.GetAttendanceBySubjectAndDateHandler)
 		attendance.GET("/summary/subject", attendanceHandler.GetAttendanceSummaryBySubjectHandler)
@@ -80,19 +246,84 @@ func SetupRoutes(e *echo.Echo, db *sql.DB) {
 		attendance.GET("/student/history", attendanceHandler.GetStudentAttendanceHistoryHandler,studentmiddlerwarego.JWTMiddleware)
 		attendance.POST("/assignsubject",attendanceHandler.AssignSubjectToTimeRangeHandler,facultymiddlerware.FacultyJWTMiddleware)
 		attendance.GET("/summary/student", attendanceHandler.GetAttendanceSummaryByStudentHandler,studentmiddlerwarego.JWTMiddleware)
+		attendance.GET("/student/calendar", trendHandler.GetAttendanceCalendarHandler, studentmiddlerwarego.JWTMiddleware)
+		attendance.GET("/student/trend", trendHandler.GetAttendanceTrendHandler, studentmiddlerwarego.JWTMiddleware)
+		attendance.GET("/student/projection", trendHandler.GetAttendanceProjectionHandler, studentmiddlerwarego.JWTMiddleware)
+		attendance.GET("/export", exportHandler.ExportFacultyAttendanceRegisterHandler, facultymiddlerware.FacultyJWTMiddleware)
+		attendance.GET("/defaulters", reportHandler.GetFacultyDefaulterReportHandler, facultymiddlerware.FacultyJWTMiddleware)
+		attendance.GET("/live", liveHandler.LiveAttendanceHandler, facultymiddlerware.FacultyStreamJWTMiddleware)
+		attendance.PATCH("/:id/status", attendanceHandler.CorrectAttendanceHandler, facultymiddlerware.FacultyJWTMiddleware)
+	}
+
+	// Admin
+	admin := e.Group("/admin")
+	{
+		admin.POST("/login", adminHandler.LoginAdminHandler)
+		admin.POST("/register", adminHandler.RegisterAdminHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/import/:entity", importHandler.ImportRosterHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/attendance/export", exportHandler.ExportAttendanceRegisterHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/attendance/defaulters", reportHandler.GetDefaulterReportHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.PUT("/condonations", reportHandler.SetCondonationHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.DELETE("/condonations", reportHandler.RemoveCondonationHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/terms", termHandler.CreateTermHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/departments", departmentHandler.CreateDepartmentHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.PUT("/departments/:code", departmentHandler.UpdateDepartmentHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.DELETE("/departments/:code", departmentHandler.DeleteDepartmentHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/departments/:code/analytics", analyticsHandler.GetDepartmentAnalyticsHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/teaching-log", teachingHandler.GetTeachingLogHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.PUT("/subjects/:code/planned-classes", subjectHandler.SetPlannedClassesHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/class-advisors", notificationHandler.GetClassAdvisorsHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.PUT("/class-advisors", notificationHandler.SetClassAdvisorHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/guardians", guardianHandler.RegisterGuardianHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/guardians/:id/students", guardianHandler.LinkWardHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/students/:usn/guardians", guardianHandler.GetStudentGuardiansHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/webhooks", webhookHandler.CreateWebhookHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/webhooks", webhookHandler.GetWebhooksHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.DELETE("/webhooks/:id", webhookHandler.DeleteWebhookHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/webhooks/deliveries", webhookHandler.GetDeliveriesHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.GET("/webhooks/deliveries/:id", webhookHandler.GetDeliveryHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/webhooks/deliveries/:id/replay", webhookHandler.ReplayDeliveryHandler, adminmiddlerware.AdminJWTMiddleware)
+		admin.POST("/notifications/run/:job", notificationHandler.RunNotificationJobHandler, adminmiddlerware.AdminJWTMiddleware)
 	}
 
+	// Head of department: faculty named as a department's HOD
+	hod := e.Group("/hod")
+	{
+		hod.GET("/departments/:code/analytics", analyticsHandler.GetHODAnalyticsHandler, facultymiddlerware.FacultyJWTMiddleware)
+		hod.POST("/departments/:code/substitutions", substitutionHandler.AssignSubstituteHandler, facultymiddlerware.FacultyJWTMiddleware)
+		hod.GET("/departments/:code/substitutions", substitutionHandler.GetSubstitutionsHandler, facultymiddlerware.FacultyJWTMiddleware)
+		hod.DELETE("/departments/:code/substitutions/:id", substitutionHandler.RemoveSubstituteHandler, facultymiddlerware.FacultyJWTMiddleware)
+		hod.POST("/departments/:code/swaps", substitutionHandler.RecordSwapHandler, facultymiddlerware.FacultyJWTMiddleware)
+		hod.GET("/departments/:code/swaps", substitutionHandler.GetSwapsHandler, facultymiddlerware.FacultyJWTMiddleware)
+		hod.DELETE("/departments/:code/swaps/:id", substitutionHandler.RemoveSwapHandler, facultymiddlerware.FacultyJWTMiddleware)
+	}
+
+	// Guardian (read-only access to their wards)
+	guardian := e.Group("/guardians")
+	{
+		guardian.POST("/login", guardianHandler.LoginGuardianHandler)
+		guardian.GET("/wards", guardianHandler.GetWardsHandler, guardianmiddlerware.GuardianJWTMiddleware)
+		guardian.GET("/wards/:usn/summary", guardianHandler.GetWardSummaryHandler, guardianmiddlerware.GuardianJWTMiddleware)
+		guardian.GET("/wards/:usn/history", guardianHandler.GetWardHistoryHandler, guardianmiddlerware.GuardianJWTMiddleware)
+		guardian.GET("/notifications", notificationHandler.GetPreferencesHandler, guardianmiddlerware.GuardianJWTMiddleware)
+		guardian.PUT("/notifications", notificationHandler.SetPreferenceHandler, guardianmiddlerware.GuardianJWTMiddleware)
+	}
+
+	e.GET("/terms", termHandler.GetTermsHandler)
+	e.GET("/departments", departmentHandler.GetDepartmentsHandler)
+	e.GET("/departments/:code", departmentHandler.GetDepartmentHandler)
+
 	// Health
 	e.GET("/", func(c echo.Context) error {
 		return c.String(http.StatusOK, "server is healthy")
 	})
+
+	// API docs; the validator rejects requests that do not match them
+	// before the handler runs.
+	doc := NewAPIDocument()
+	e.GET("/openapi.json", doc.JSONHandler)
+	e.GET("/docs", openapi.UIHandler("/openapi.json"))
+	e.Use(doc.Validator())
 }
 }
 
-
-// {
-//   "usn": "4AL23IS059",
-//   "status": "Present",
-// "recorded_at": "2025-09-18T03:45:00+00:00"
-// }
-// 9:00 to 9:50
\ No newline at end of file
@@ -0,0 +1,94 @@
+// Package blob keeps binary evidence, such as the face crops sent with
+// captures, outside the database.
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned by Get for a key that was never stored.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps blobs under keys it chooses. Equal content gets the same key,
+// so storing a blob twice is harmless.
+type Store interface {
+	Put(data []byte) (string, error)
+	Get(key string) ([]byte, error)
+}
+
+// LocalStore writes each blob to a file under its directory, named after the
+// SHA-256 of the content and fanned out by the first two hex digits.
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create blob directory: %w", err)
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.dir, key[:2], key)
+}
+
+// validKey keeps keys from the outside world to the 64 hex digits Put hands
+// out, so they cannot name a path outside the store.
+func validKey(key string) bool {
+	if len(key) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+func (s *LocalStore) Put(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	key := hex.EncodeToString(sum[:])
+	path := s.path(key)
+	if _, err := os.Stat(path); err == nil {
+		return key, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("create blob directory: %w", err)
+	}
+	// Write to a temporary file first so a crash never leaves a truncated
+	// blob under its final name.
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("store blob: %w", err)
+	}
+	return key, nil
+}
+
+func (s *LocalStore) Get(key string) ([]byte, error) {
+	if !validKey(key) {
+		return nil, ErrNotFound
+	}
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("read blob: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,80 @@
+// Package calendar decides which class date an instant belongs to.
+//
+// Class dates are calendar days in the institution's timezone. They are
+// carried as midnight UTC of that day, which is how the DATE columns scan
+// back and how params parses YYYY-MM-DD, so dates compare with Equal no
+// matter where they came from.
+package calendar
+
+import "time"
+
+const DateLayout = "2006-01-02"
+
+type Calendar struct {
+	loc *time.Location
+	now func() time.Time
+}
+
+// New returns a calendar for loc; a nil loc means UTC.
+func New(loc *time.Location) *Calendar {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &Calendar{loc: loc, now: time.Now}
+}
+
+// WithClock returns a copy of c that reads the current time from now, for
+// tests and replays.
+func (c *Calendar) WithClock(now func() time.Time) *Calendar {
+	return &Calendar{loc: c.loc, now: now}
+}
+
+func (c *Calendar) Location() *time.Location { return c.loc }
+
+// Now is the current time in the institution's timezone.
+func (c *Calendar) Now() time.Time { return c.now().In(c.loc) }
+
+// Today is the current class date.
+func (c *Calendar) Today() time.Time { return c.DateOf(c.now()) }
+
+// DateOf is the class date t falls on.
+func (c *Calendar) DateOf(t time.Time) time.Time {
+	local := t.In(c.loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ParseDate reads a YYYY-MM-DD class date.
+func (c *Calendar) ParseDate(s string) (time.Time, error) {
+	return time.Parse(DateLayout, s)
+}
+
+// DayBounds is the first and last instant of date's class day, in UTC.
+func (c *Calendar) DayBounds(date time.Time) (time.Time, time.Time) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, c.loc)
+	end := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, c.loc)
+	return start.UTC(), end.UTC()
+}
+
+// Window is the span of a class held on date from start to end, read as
+// wall-clock times; the end minute is included. Both instants are in UTC.
+func (c *Calendar) Window(date, start, end time.Time) (time.Time, time.Time) {
+	from := time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, c.loc)
+	to := time.Date(date.Year(), date.Month(), date.Day(), end.Hour(), end.Minute(), 59, 999999999, c.loc)
+	return from.UTC(), to.UTC()
+}
+
+// Session is the Window of a class logged on date starting at start, a
+// "15:04" wall-clock time, and lasting minutes.
+func (c *Calendar) Session(date time.Time, start string, minutes int) (time.Time, time.Time, error) {
+	begin, err := time.Parse("15:04", start)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	from, to := c.Window(date, begin, begin.Add(time.Duration(minutes)*time.Minute))
+	return from, to, nil
+}
+
+// WeekStart is the Monday of date's week, as date_trunc('week') returns it.
+func WeekStart(date time.Time) time.Time {
+	return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,52 @@
+package calendar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+)
+
+func TestDateOfUsesInstitutionDay(t *testing.T) {
+	ist, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skip("tzdata unavailable:", err)
+	}
+	cal := calendar.New(ist)
+
+	// 04:30 IST on 3 June is still 2 June in UTC.
+	capture := time.Date(2025, 6, 2, 23, 0, 0, 0, time.UTC)
+	want := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+	if got := cal.DateOf(capture); !got.Equal(want) {
+		t.Errorf("DateOf(%s) = %s, want %s", capture, got, want)
+	}
+
+	start, end := cal.DayBounds(want)
+	if !start.Equal(time.Date(2025, 6, 2, 18, 30, 0, 0, time.UTC)) || !end.Before(time.Date(2025, 6, 3, 18, 30, 0, 0, time.UTC)) {
+		t.Errorf("DayBounds = %s .. %s", start, end)
+	}
+	if capture.Before(start) || capture.After(end) {
+		t.Errorf("capture %s outside its own day %s .. %s", capture, start, end)
+	}
+}
+
+func TestWindowIncludesEndMinute(t *testing.T) {
+	cal := calendar.New(nil)
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	clock := func(h, m int) time.Time { return time.Date(0, 1, 1, h, m, 0, 0, time.UTC) }
+
+	from, to := cal.Window(date, clock(9, 0), clock(9, 50))
+	if !from.Equal(date.Add(9*time.Hour)) || !to.After(date.Add(9*time.Hour+50*time.Minute+59*time.Second)) {
+		t.Errorf("Window = %s .. %s", from, to)
+	}
+}
+
+func TestToday(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	cal := calendar.New(ist).WithClock(func() time.Time {
+		return time.Date(2025, 6, 2, 20, 0, 0, 0, time.UTC)
+	})
+	if got := cal.Today(); !got.Equal(time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Today = %s", got)
+	}
+}
@@ -0,0 +1,405 @@
+// Package config loads server settings from defaults, an optional YAML file,
+// a .env file and the environment, in that order of precedence (last wins).
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server        ServerConfig        `yaml:"server"`
+	Database      DatabaseConfig      `yaml:"database"`
+	JWT           JWTConfig           `yaml:"jwt"`
+	Institution   InstitutionConfig   `yaml:"institution"`
+	Broker        BrokerConfig        `yaml:"broker"`
+	SMTP          SMTPConfig          `yaml:"smtp"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Anomaly       AnomalyConfig       `yaml:"anomaly"`
+	Evidence      EvidenceConfig      `yaml:"evidence"`
+	Admin         AdminConfig         `yaml:"admin"`
+}
+
+type ServerConfig struct {
+	Port int `yaml:"port"`
+	// TLS is served when both files are set.
+	TLSCertFile string   `yaml:"tls_cert_file"`
+	TLSKeyFile  string   `yaml:"tls_key_file"`
+	CORSOrigins []string `yaml:"cors_origins"`
+	// ShutdownTimeout bounds draining HTTP requests and stopping each worker.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+func (s ServerConfig) Addr() string { return ":" + strconv.Itoa(s.Port) }
+
+func (s ServerConfig) TLS() bool { return s.TLSCertFile != "" && s.TLSKeyFile != "" }
+
+// Database drivers. With DriverSQLite the URL is the path of the database
+// file, e.g. attendance.db.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+type DatabaseConfig struct {
+	Driver          string        `yaml:"driver"`
+	URL             string        `yaml:"url"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
+
+type JWTConfig struct {
+	Secret string `yaml:"secret"`
+	Issuer string `yaml:"issuer"`
+	// TTL of issued tokens; 0 issues tokens that never expire.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type InstitutionConfig struct {
+	// Timezone is an IANA name; class dates and times are read in it.
+	Timezone string `yaml:"timezone"`
+	// Campuses maps campus names to their IANA timezone; Campus picks the
+	// one this server runs for and overrides Timezone.
+	Campuses map[string]string `yaml:"campuses"`
+	Campus   string            `yaml:"campus"`
+	// AttendanceThreshold is the minimum percentage to sit exams.
+	AttendanceThreshold float64 `yaml:"attendance_threshold"`
+	// USNPattern and SubjectCodePattern are regular expressions that USNs
+	// and subject codes must match in full.
+	USNPattern         string `yaml:"usn_pattern"`
+	SubjectCodePattern string `yaml:"subject_code_pattern"`
+
+	// Calendar buckets captures into class dates in the resolved timezone.
+	Calendar *calendar.Calendar `yaml:"-"`
+}
+
+// ResolvedTimezone is the campus timezone when a campus is selected, else
+// the institution's.
+func (i InstitutionConfig) ResolvedTimezone() string {
+	if tz, ok := i.Campuses[i.Campus]; ok && i.Campus != "" {
+		return tz
+	}
+	return i.Timezone
+}
+
+// Rules are the payload validation rules of the institution.
+func (i InstitutionConfig) Rules() validation.Rules {
+	return validation.Rules{
+		USNPattern:         i.USNPattern,
+		SubjectCodePattern: i.SubjectCodePattern,
+	}
+}
+
+type BrokerConfig struct {
+	URL       string `yaml:"url"`
+	QueueName string `yaml:"queue_name"`
+}
+
+// SMTPConfig enables email when Host is set.
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+type NotificationsConfig struct {
+	// DailyHour is the local hour at which daily jobs run.
+	DailyHour int `yaml:"daily_hour"`
+}
+
+// AnomalyConfig holds the rules that hold a capture for faculty review
+// instead of recording it. A zero window, size or confidence turns its rule
+// off.
+type AnomalyConfig struct {
+	// TravelWindow is how soon after a capture in one room a capture in
+	// another is impossible travel.
+	TravelWindow time.Duration `yaml:"travel_window"`
+	// BurstSize captures of a student within BurstWindow are a duplicate
+	// burst.
+	BurstWindow time.Duration `yaml:"burst_window"`
+	BurstSize   int           `yaml:"burst_size"`
+	// MinConfidence is the recognizer score, 0 to 1, below which a match
+	// is doubted.
+	MinConfidence float64 `yaml:"min_confidence"`
+	// SessionGrace widens each logged session for the outside-session rule.
+	SessionGrace time.Duration `yaml:"session_grace"`
+}
+
+// EvidenceConfig is where the face crops sent with captures are kept.
+type EvidenceConfig struct {
+	// Dir is created on startup if missing.
+	Dir string `yaml:"dir"`
+	// MaxFaceCropBytes caps one decoded face crop.
+	MaxFaceCropBytes int `yaml:"max_face_crop_bytes"`
+}
+
+// AdminConfig bootstraps the first admin when Email is set.
+type AdminConfig struct {
+	Username string `yaml:"username"`
+	Email    string `yaml:"email"`
+	Password string `yaml:"password"`
+}
+
+func Default() Config {
+	rules := validation.DefaultRules()
+	return Config{
+		Server: ServerConfig{
+			Port:            8080,
+			CORSOrigins:     []string{"*"},
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:          DriverPostgres,
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+		JWT: JWTConfig{
+			Issuer: "smart-attendence-system",
+			TTL:    24 * time.Hour,
+		},
+		Institution: InstitutionConfig{
+			Timezone:            "Asia/Kolkata",
+			AttendanceThreshold: 75,
+			USNPattern:          rules.USNPattern,
+			SubjectCodePattern:  rules.SubjectCodePattern,
+		},
+		SMTP:          SMTPConfig{Port: 25},
+		Notifications: NotificationsConfig{DailyHour: 18},
+		Anomaly: AnomalyConfig{
+			TravelWindow:  10 * time.Minute,
+			BurstWindow:   time.Minute,
+			BurstSize:     5,
+			MinConfidence: 0.6,
+			SessionGrace:  15 * time.Minute,
+		},
+		Evidence: EvidenceConfig{
+			Dir:              "evidence",
+			MaxFaceCropBytes: 256 << 10,
+		},
+	}
+}
+
+// Load builds the configuration. The YAML file is CONFIG_FILE if set, else
+// config.yaml when it exists; a missing .env is not an error.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read .env: %w", err)
+	}
+
+	path, required := os.Getenv("CONFIG_FILE"), true
+	if path == "" {
+		path, required = "config.yaml", false
+	}
+	if err := cfg.loadFile(path, required); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) loadFile(path string, required bool) error {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read config file: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(body))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// envVar binds an environment variable to a field. DatabaseURL is the name
+// older .env files use; DATABASE_URL comes after it so it wins when both are set.
+type envVar struct {
+	name  string
+	field any
+}
+
+func (c *Config) envVars() []envVar {
+	return []envVar{
+		{"PORT", &c.Server.Port},
+		{"TLS_CERT_FILE", &c.Server.TLSCertFile},
+		{"TLS_KEY_FILE", &c.Server.TLSKeyFile},
+		{"CORS_ORIGINS", &c.Server.CORSOrigins},
+		{"SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout},
+		{"DB_DRIVER", &c.Database.Driver},
+		{"DatabaseURL", &c.Database.URL},
+		{"DATABASE_URL", &c.Database.URL},
+		{"DB_MAX_OPEN_CONNS", &c.Database.MaxOpenConns},
+		{"DB_MAX_IDLE_CONNS", &c.Database.MaxIdleConns},
+		{"DB_CONN_MAX_LIFETIME", &c.Database.ConnMaxLifetime},
+		{"JWT_SECRET", &c.JWT.Secret},
+		{"JWT_ISSUER", &c.JWT.Issuer},
+		{"JWT_TTL", &c.JWT.TTL},
+		{"TIMEZONE", &c.Institution.Timezone},
+		{"CAMPUS", &c.Institution.Campus},
+		{"ATTENDANCE_THRESHOLD", &c.Institution.AttendanceThreshold},
+		{"USN_PATTERN", &c.Institution.USNPattern},
+		{"SUBJECT_CODE_PATTERN", &c.Institution.SubjectCodePattern},
+		{"RABBITMQ_URL", &c.Broker.URL},
+		{"QUEUE_NAME", &c.Broker.QueueName},
+		{"SMTP_HOST", &c.SMTP.Host},
+		{"SMTP_PORT", &c.SMTP.Port},
+		{"SMTP_USERNAME", &c.SMTP.Username},
+		{"SMTP_PASSWORD", &c.SMTP.Password},
+		{"SMTP_FROM", &c.SMTP.From},
+		{"NOTIFY_DAILY_HOUR", &c.Notifications.DailyHour},
+		{"ANOMALY_TRAVEL_WINDOW", &c.Anomaly.TravelWindow},
+		{"ANOMALY_BURST_WINDOW", &c.Anomaly.BurstWindow},
+		{"ANOMALY_BURST_SIZE", &c.Anomaly.BurstSize},
+		{"ANOMALY_MIN_CONFIDENCE", &c.Anomaly.MinConfidence},
+		{"ANOMALY_SESSION_GRACE", &c.Anomaly.SessionGrace},
+		{"EVIDENCE_DIR", &c.Evidence.Dir},
+		{"EVIDENCE_MAX_FACE_CROP_BYTES", &c.Evidence.MaxFaceCropBytes},
+		{"ADMIN_USERNAME", &c.Admin.Username},
+		{"ADMIN_EMAIL", &c.Admin.Email},
+		{"ADMIN_PASSWORD", &c.Admin.Password},
+	}
+}
+
+func (c *Config) loadEnv() error {
+	var errs []string
+	for _, ev := range c.envVars() {
+		// Blank values, as left by .env templates, keep the default.
+		v := strings.TrimSpace(os.Getenv(ev.name))
+		if v == "" {
+			continue
+		}
+		if err := set(ev.field, v); err != nil {
+			errs = append(errs, fmt.Sprintf("%s=%q: %v", ev.name, v, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid environment:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+func set(field any, v string) error {
+	switch f := field.(type) {
+	case *string:
+		*f = v
+	case *int:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.New("not an integer")
+		}
+		*f = n
+	case *float64:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return errors.New("not a number")
+		}
+		*f = n
+	case *time.Duration:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.New("not a duration such as 30m or 24h")
+		}
+		*f = d
+	case *[]string:
+		*f = nil
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				*f = append(*f, s)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported field type %T", field)
+	}
+	return nil
+}
+
+// validate reports every problem at once so a misconfigured deploy can be
+// fixed in one pass, and builds the institution calendar.
+func (c *Config) validate() error {
+	var errs []string
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "server port %d is out of range", c.Server.Port)
+	check((c.Server.TLSCertFile == "") == (c.Server.TLSKeyFile == ""), "TLS needs both a certificate and a key file")
+	check(len(c.Server.CORSOrigins) > 0, "at least one CORS origin is required (use * to allow any)")
+	check(c.Server.ShutdownTimeout > 0, "server shutdown_timeout must be positive")
+
+	check(c.Database.Driver == DriverPostgres || c.Database.Driver == DriverSQLite,
+		"database driver %q must be %s or %s", c.Database.Driver, DriverPostgres, DriverSQLite)
+	check(c.Database.URL != "", "database url is required (DATABASE_URL)")
+	check(c.Database.MaxOpenConns >= 0, "database max_open_conns must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database max_idle_conns must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database max_idle_conns (%d) exceeds max_open_conns (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	check(c.Database.ConnMaxLifetime >= 0, "database conn_max_lifetime must not be negative")
+
+	check(len(c.JWT.Secret) >= 32, "jwt secret is required and must be at least 32 characters (JWT_SECRET)")
+	check(c.JWT.Issuer != "", "jwt issuer is required")
+	check(c.JWT.TTL >= 0, "jwt ttl must not be negative")
+
+	for name, tz := range c.Institution.Campuses {
+		_, err := time.LoadLocation(tz)
+		check(err == nil, "unknown timezone %q for campus %s", tz, name)
+	}
+	_, known := c.Institution.Campuses[c.Institution.Campus]
+	check(c.Institution.Campus == "" || known, "campus %q is not listed under institution campuses", c.Institution.Campus)
+	loc, err := time.LoadLocation(c.Institution.ResolvedTimezone())
+	check(err == nil, "unknown institution timezone %q", c.Institution.ResolvedTimezone())
+	c.Institution.Calendar = calendar.New(loc)
+	check(c.Institution.AttendanceThreshold > 0 && c.Institution.AttendanceThreshold <= 100,
+		"attendance threshold %.2f must be within (0, 100]", c.Institution.AttendanceThreshold)
+	_, err = regexp.Compile(c.Institution.USNPattern)
+	check(err == nil, "invalid institution usn_pattern: %v", err)
+	_, err = regexp.Compile(c.Institution.SubjectCodePattern)
+	check(err == nil, "invalid institution subject_code_pattern: %v", err)
+
+	check(c.SMTP.Host == "" || (c.SMTP.Port > 0 && c.SMTP.Port < 65536), "smtp port %d is out of range", c.SMTP.Port)
+	check(c.Notifications.DailyHour >= 0 && c.Notifications.DailyHour <= 23,
+		"notification daily hour %d must be within 0-23", c.Notifications.DailyHour)
+
+	check(c.Anomaly.TravelWindow >= 0, "anomaly travel_window must not be negative")
+	check(c.Anomaly.BurstWindow >= 0, "anomaly burst_window must not be negative")
+	check(c.Anomaly.BurstSize == 0 || c.Anomaly.BurstSize >= 2,
+		"anomaly burst_size %d must be 0 (off) or at least 2", c.Anomaly.BurstSize)
+	check(c.Anomaly.MinConfidence >= 0 && c.Anomaly.MinConfidence <= 1,
+		"anomaly min_confidence %.2f must be within [0, 1]", c.Anomaly.MinConfidence)
+	check(c.Anomaly.SessionGrace >= 0, "anomaly session_grace must not be negative")
+
+	check(c.Evidence.Dir != "", "evidence dir is required (EVIDENCE_DIR)")
+	check(c.Evidence.MaxFaceCropBytes > 0, "evidence max_face_crop_bytes must be positive")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// isolate runs the test in an empty directory with none of the server's
+// variables set, and unsets whatever a .env file adds once it ends.
+func isolate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, ev := range (&Config{}).envVars() {
+		t.Setenv(ev.name, "")
+		os.Unsetenv(ev.name)
+	}
+	t.Setenv("CONFIG_FILE", "")
+	os.Unsetenv("CONFIG_FILE")
+	return dir
+}
+
+func write(t *testing.T, dir, name, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		yaml       string
+		dotenv     string
+		env        map[string]string
+		wantPort   int
+		wantIssuer string
+	}{
+		{
+			name:       "defaults",
+			env:        map[string]string{"DATABASE_URL": "postgres://db", "JWT_SECRET": testSecret},
+			wantPort:   8080,
+			wantIssuer: "smart-attendence-system",
+		},
+		{
+			name:       "yaml over defaults",
+			yaml:       "server:\n  port: 9000\njwt:\n  issuer: campus\n",
+			env:        map[string]string{"DATABASE_URL": "postgres://db", "JWT_SECRET": testSecret},
+			wantPort:   9000,
+			wantIssuer: "campus",
+		},
+		{
+			name:       ".env over yaml",
+			yaml:       "server:\n  port: 9000\njwt:\n  issuer: campus\n",
+			dotenv:     "PORT=9100\nDATABASE_URL=postgres://db\nJWT_SECRET=" + testSecret + "\n",
+			wantPort:   9100,
+			wantIssuer: "campus",
+		},
+		{
+			name:       "env over .env and yaml",
+			yaml:       "server:\n  port: 9000\njwt:\n  issuer: campus\n",
+			dotenv:     "PORT=9100\nJWT_ISSUER=dotenv\n",
+			env:        map[string]string{"PORT": "9200", "DATABASE_URL": "postgres://db", "JWT_SECRET": testSecret},
+			wantPort:   9200,
+			wantIssuer: "dotenv",
+		},
+		{
+			name:       "blank env keeps yaml",
+			yaml:       "server:\n  port: 9000\n",
+			env:        map[string]string{"PORT": " ", "DATABASE_URL": "postgres://db", "JWT_SECRET": testSecret},
+			wantPort:   9000,
+			wantIssuer: "smart-attendence-system",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolate(t)
+			if tt.yaml != "" {
+				write(t, dir, "config.yaml", tt.yaml)
+			}
+			if tt.dotenv != "" {
+				write(t, dir, ".env", tt.dotenv)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.Server.Port != tt.wantPort {
+				t.Errorf("port = %d, want %d", cfg.Server.Port, tt.wantPort)
+			}
+			if cfg.JWT.Issuer != tt.wantIssuer {
+				t.Errorf("issuer = %q, want %q", cfg.JWT.Issuer, tt.wantIssuer)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := isolate(t)
+	t.Setenv("DATABASE_URL", "postgres://db")
+	t.Setenv("JWT_SECRET", testSecret)
+
+	t.Setenv("CONFIG_FILE", filepath.Join(dir, "missing.yaml"))
+	if _, err := Load(); err == nil {
+		t.Error("Load accepted a CONFIG_FILE that does not exist")
+	}
+
+	write(t, dir, "campus.yaml", "server:\n  prot: 9000\n")
+	t.Setenv("CONFIG_FILE", filepath.Join(dir, "campus.yaml"))
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "prot") {
+		t.Errorf("err = %v, want the unknown field reported", err)
+	}
+
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("PORT", "eighty")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "PORT") {
+		t.Errorf("err = %v, want the bad PORT reported", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"missing secret", func(c *Config) { c.JWT.Secret = "" }, "jwt secret"},
+		{"short secret", func(c *Config) { c.JWT.Secret = testSecret[:31] }, "at least 32 characters"},
+		{"unknown timezone", func(c *Config) { c.Institution.Timezone = "Mars/Olympus" }, `unknown institution timezone "Mars/Olympus"`},
+		{"unknown campus timezone", func(c *Config) {
+			c.Institution.Campuses = map[string]string{"north": "Mars/Olympus"}
+		}, "unknown timezone \"Mars/Olympus\" for campus north"},
+		{"unlisted campus", func(c *Config) {
+			c.Institution.Campuses = map[string]string{"north": "Asia/Kolkata"}
+			c.Institution.Campus = "south"
+		}, `campus "south" is not listed`},
+		{"campus timezone", func(c *Config) {
+			c.Institution.Timezone = "Mars/Olympus"
+			c.Institution.Campuses = map[string]string{"dubai": "Asia/Dubai"}
+			c.Institution.Campus = "dubai"
+		}, ""},
+		{"unknown driver", func(c *Config) { c.Database.Driver = "mysql" }, `database driver "mysql"`},
+		{"threshold", func(c *Config) { c.Institution.AttendanceThreshold = 120 }, "attendance threshold"},
+		{"half tls", func(c *Config) { c.Server.TLSCertFile = "cert.pem" }, "TLS needs both"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			c.Database.URL = "postgres://db"
+			c.JWT.Secret = testSecret
+			tt.mutate(&c)
+
+			err := c.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validate: %v", err)
+				}
+				if c.Institution.Calendar == nil {
+					t.Error("validate did not build the calendar")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	c := Default()
+	err := c.validate()
+	if err == nil {
+		t.Fatal("validate accepted a config without a database url or secret")
+	}
+	for _, want := range []string{"database url", "jwt secret"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err = %v, want it to mention %q", err, want)
+		}
+	}
+}
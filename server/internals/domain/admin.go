@@ -0,0 +1,24 @@
+package domain
+
+type Admin struct {
+	ID       int64  `json:"admin_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type AdminRegisterPayload struct {
+	Username string `json:"username" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,password"`
+}
+
+type AdminLoginPayload struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type AdminRepo interface {
+	CreateAdmin(username, email, password string) (int64, error)
+	AuthenticateAdmin(req AdminLoginPayload) (string, error)
+	CountAdmins() (int, error)
+}
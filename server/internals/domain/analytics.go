@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// DefaultTopAbsentees is how many students DepartmentAnalytics lists when
+// the caller does not ask for a number.
+const DefaultTopAbsentees = 10
+
+// AnalyticsQuery selects what GetDepartmentAnalytics aggregates: the
+// department's subjects over Filter's dates, with the TopAbsentees students
+// missing the most classes.
+type AnalyticsQuery struct {
+	Department   string
+	Filter       AttendanceFilter
+	TopAbsentees int
+}
+
+// DepartmentAnalytics is the head of department's view over every subject
+// of a department. Percentages are of recorded rows, as in the summaries.
+type DepartmentAnalytics struct {
+	Department   string                 `json:"department"`
+	Semesters    []SemesterAttendance   `json:"semesters"`
+	Subjects     []SubjectAttendance    `json:"subjects"`
+	Weekly       []WeeklyAttendance     `json:"weekly"`
+	Faculty      []FacultySessions      `json:"faculty"`
+	TopAbsentees []AbsenteeStat         `json:"top_absentees"`
+	Unassigned   []UnassignedAttendance `json:"unassigned"`
+}
+
+type SemesterAttendance struct {
+	Sem        int     `json:"sem"`
+	Subjects   int     `json:"subjects"`
+	Students   int     `json:"students"`
+	Present    int     `json:"present"`
+	Total      int     `json:"total"`
+	Percentage float64 `json:"percentage"`
+}
+
+// SubjectAttendance counts ClassesHeld the way FacultySessions does.
+type SubjectAttendance struct {
+	SubjectCode string  `json:"subject_code"`
+	SubjectName string  `json:"subject_name"`
+	Sem         int     `json:"sem"`
+	FacultyName string  `json:"faculty_name"`
+	ClassesHeld int     `json:"classes_held"`
+	Present     int     `json:"present"`
+	Total       int     `json:"total"`
+	Percentage  float64 `json:"percentage"`
+}
+
+// WeeklyAttendance buckets the department's rows by the Monday starting
+// their week.
+type WeeklyAttendance struct {
+	WeekStart  time.Time `json:"week_start"`
+	Present    int       `json:"present"`
+	Total      int       `json:"total"`
+	Percentage float64   `json:"percentage"`
+}
+
+// FacultySessions counts the classes held in the department's subjects per
+// teaching faculty member: a subject's logged teaching sessions, or else its
+// class dates with attendance.
+type FacultySessions struct {
+	FacultyID   int64  `json:"faculty_id"`
+	FacultyName string `json:"faculty_name"`
+	Subjects    int    `json:"subjects"`
+	Sessions    int    `json:"sessions"`
+}
+
+type AbsenteeStat struct {
+	USN         string  `json:"usn"`
+	StudentName string  `json:"student_name"`
+	Sem         int     `json:"sem"`
+	Absent      int     `json:"absent"`
+	Total       int     `json:"total"`
+	Percentage  float64 `json:"percentage"`
+}
+
+// UnassignedAttendance counts captures of the department's students on a
+// class date that no faculty has assigned to a subject yet.
+type UnassignedAttendance struct {
+	Sem  int       `json:"sem"`
+	Date time.Time `json:"date"`
+	Rows int       `json:"rows"`
+}
+
+type AnalyticsRepo interface {
+	// GetDepartmentAnalytics fills everything but the percentages.
+	GetDepartmentAnalytics(query AnalyticsQuery) (DepartmentAnalytics, error)
+}
@@ -0,0 +1,116 @@
+package domain
+
+import "time"
+
+// Anomaly kinds a capture can be flagged for.
+const (
+	// AnomalyImpossibleTravel: the student was captured in another room
+	// too recently to have walked over.
+	AnomalyImpossibleTravel = "impossible_travel"
+	// AnomalyDuplicateBurst: the student was captured many times in a few
+	// seconds, as a photo held up to the camera would be.
+	AnomalyDuplicateBurst = "duplicate_burst"
+	// AnomalyOutsideSession: sessions of the student's semester are logged
+	// that day, but the capture falls in none of them.
+	AnomalyOutsideSession = "outside_session"
+	// AnomalyLowConfidence: the recognizer was unsure of the match.
+	AnomalyLowConfidence = "low_confidence"
+)
+
+// Reviews of a flag.
+const (
+	FlagOpen     = "open"
+	FlagAccepted = "accepted"
+	FlagRejected = "rejected"
+)
+
+// RecognitionEvent is a capture as the recognizer sent it, whether or not it
+// was recorded as attendance.
+type RecognitionEvent struct {
+	ID         int64     `json:"event_id"`
+	USN        string    `json:"usn"`
+	Status     string    `json:"status"`
+	Room       string    `json:"room,omitempty"`
+	Confidence *float64  `json:"confidence,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+	// DeviceID is the camera that took the capture.
+	DeviceID    string       `json:"device_id,omitempty"`
+	BoundingBox *BoundingBox `json:"bounding_box,omitempty"`
+	// FaceCropKey is the blob store key of the face crop, if one was sent;
+	// reviewers fetch the image from FaceCropURL.
+	FaceCropKey string `json:"-"`
+	FaceCropURL string `json:"face_crop_url,omitempty"`
+}
+
+// AnomalyFlag holds a capture back until a faculty member of the student's
+// department accepts it, recording it as attendance, or rejects it.
+type AnomalyFlag struct {
+	ID          int64            `json:"flag_id"`
+	Event       RecognitionEvent `json:"event"`
+	StudentName string           `json:"student_name"`
+	Department  string           `json:"department"`
+	Reasons     []string         `json:"reasons"`
+	Detail      string           `json:"detail,omitempty"`
+	Review      string           `json:"review"`
+	ReviewedBy  *int64           `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time       `json:"reviewed_at,omitempty"`
+	// AttendanceID is the row an accepted capture was recorded as.
+	AttendanceID *int64    `json:"attendance_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type FlagReviewPayload struct {
+	Decision string `json:"decision" validate:"required,oneof=accept reject"`
+}
+
+// FlagQuery narrows flags; zero fields match everything. Filter applies to
+// the class date of the capture.
+type FlagQuery struct {
+	Department string
+	Review     string
+	Filter     AttendanceFilter
+}
+
+type AnomalyRepo interface {
+	// RecordRecognitionEvent stores a capture as received; its ID is
+	// ignored.
+	RecordRecognitionEvent(e RecognitionEvent) (int64, error)
+	// GetRecognitionEvents returns usn's captures recorded from from to to,
+	// oldest first.
+	GetRecognitionEvents(usn string, from, to time.Time) ([]RecognitionEvent, error)
+	// GetStudentSessions returns the sessions logged on date for subjects of
+	// the student's department and semester.
+	GetStudentSessions(usn string, date time.Time) ([]TeachingSession, error)
+	CreateAnomalyFlag(eventID int64, reasons []string, detail string) (int64, error)
+	// GetAnomalyFlags returns the matching flags, newest capture first.
+	GetAnomalyFlags(query FlagQuery) ([]AnomalyFlag, error)
+	GetAnomalyFlag(id int64) (AnomalyFlag, error)
+	// ResolveAnomalyFlag closes an open flag; a flag already reviewed is a
+	// conflict.
+	ResolveAnomalyFlag(id, facultyID int64, review string, attendanceID *int64) error
+}
+
+// CaptureScreener vets captures before they are recorded. Check runs the
+// rules against a, counting pending (the captures of the same batch checked
+// before it) as already seen, and stores nothing. Record then keeps the
+// capture as a recognition event and, when it was held, opens a flag for
+// review and returns its id. Callers record only once the attendance rows
+// are written, so a failed batch leaves no events to be counted again when
+// it is retried.
+type CaptureScreener interface {
+	Check(a AttendancePayload, pending []AttendancePayload) (Screening, error)
+	Record(s Screening) (flagID int64, err error)
+}
+
+// Screening is a checked capture waiting to be recorded.
+type Screening struct {
+	Capture AttendancePayload
+	// Reasons are the anomaly kinds found, Detail describes them.
+	Reasons []string
+	Detail  string
+	// FaceCrop is the decoded crop sent with the capture.
+	FaceCrop []byte
+}
+
+// Held reports whether the capture waits for review instead of counting.
+func (s Screening) Held() bool { return len(s.Reasons) > 0 }
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 type Attendance struct {
 	ID string `json:"attendance_id"`
@@ -15,9 +18,37 @@ type Attendance struct {
 type AttendancePayload struct {
 	USN      string    `json:"usn" validate:"required"`
 	Status   string    `json:"status" validate:"required,oneof=Present Absent"`
-	RecordedAt time.Time `json:"recorded_at" validate:"required"`
+	RecordedAt time.Time `json:"recorded_at" validate:"required,notfuture"`
+	// Room and Confidence come from the recognizer when it knows them; they
+	// feed anomaly screening only.
+	Room       string   `json:"room,omitempty" validate:"max=50"`
+	Confidence *float64 `json:"confidence,omitempty" validate:"omitempty,gte=0,lte=1"`
+	// DeviceID, BoundingBox and FaceCrop are evidence kept with the capture
+	// for reviewers. FaceCrop is a base64 JPEG or PNG of the detected face.
+	DeviceID    string       `json:"device_id,omitempty" validate:"max=100"`
+	BoundingBox *BoundingBox `json:"bounding_box,omitempty"`
+	FaceCrop    string       `json:"face_crop,omitempty" validate:"omitempty,base64"`
 }
 
+// BoundingBox is where the face was found in the camera frame, in pixels.
+type BoundingBox struct {
+	X      int `json:"x" validate:"min=0"`
+	Y      int `json:"y" validate:"min=0"`
+	Width  int `json:"width" validate:"min=1"`
+	Height int `json:"height" validate:"min=1"`
+}
+
+// CaptureResult is what became of a capture: recorded as AttendanceID, or
+// held for review as FlagID because of Reasons.
+type CaptureResult struct {
+	AttendanceID int64    `json:"attendance_id,omitempty"`
+	FlagID       int64    `json:"flag_id,omitempty"`
+	Reasons      []string `json:"reasons,omitempty"`
+}
+
+// Held reports whether the capture waits for review instead of counting.
+func (r CaptureResult) Held() bool { return r.FlagID != 0 }
+
 
 type ClassAttendance struct {
     USN       string    `json:"usn"`
@@ -43,11 +74,98 @@ type AttendanceWithNames struct {
 type AttendanceRepository interface {
 	MarkAttendance(attendance *AttendancePayload) (int64, error)
     BulkMarkAttendance(attendances []AttendancePayload) (int, error)
-	GetAttendanceByStudentAndSubject(usn string, subjectCode string) ([]AttendanceWithNames, error)
+	GetAttendanceByStudentAndSubject(usn string, subjectCode string, filter AttendanceFilter, page PageRequest) ([]AttendanceWithNames, int, error)
 	GetAttendanceBySubjectAndDate(subjectCode string, date time.Time) ([]AttendanceWithNames, error)
 	AssignSubjectToTimeRange(facultyID int64, subjectCode string, classDate time.Time, start time.Time, end time.Time) (int64, int64, error)
-	GetAttendanceSummaryBySubject(subjectCode string) ([]StudentSummary, error)
+	GetAttendanceSummaryBySubject(subjectCode string, filter AttendanceFilter, page PageRequest) ([]StudentSummary, int, error)
 	GetClassAttendance(subjectCode string, date time.Time) ([]ClassAttendance, error)
-	GetStudentAttendanceHistory(usn string, subjectCode string) ([]StudentHistory, error)
-    GetAttendanceSummaryByStudent(usn string) ([]SubjectSummary, error)
-}
\ No newline at end of file
+	GetStudentAttendanceHistory(usn string, subjectCode string, filter AttendanceFilter, page PageRequest) ([]StudentHistory, int, error)
+    GetAttendanceSummaryByStudent(usn string, filter AttendanceFilter) ([]SubjectSummary, error)
+	CorrectAttendance(facultyID int64, attendanceID int64, status string) (AttendanceCorrection, error)
+	GetSubjectOwner(subjectCode string) (int64, error)
+	// GetCaptureSubject returns the code of the subject, among usn's
+	// enrolled ones, whose session logged that day covers recordedAt, or ""
+	// when none does. The capture itself stays unassigned.
+	GetCaptureSubject(usn string, recordedAt time.Time) (string, error)
+}
+type AttendanceCorrectionPayload struct {
+	Status string `json:"status" validate:"required,oneof=Present Absent"`
+}
+
+// AssignSubjectPayload gives the unassigned rows recorded on ClassDate
+// between Start and End (institution time) to a subject.
+type AssignSubjectPayload struct {
+	SubjectCode string `json:"subjectCode" validate:"required,subject_code"`
+	ClassDate   string `json:"class_date" validate:"required"` // YYYY-MM-DD
+	Start       string `json:"start" validate:"required"`      // HH:MM
+	End         string `json:"end" validate:"required"`        // HH:MM
+}
+
+// DateRepair is an attendance row whose stored date is not the class date
+// of its recorded_at, as left behind by builds that bucketed captures by UTC
+// day.
+type DateRepair struct {
+	AttendanceID int64
+	USN          string
+	SubjectID    int64 // 0 while unassigned
+	RecordedAt   time.Time
+	OldDate      time.Time
+	NewDate      time.Time
+	// Conflict is set when the student would be left with another row on
+	// NewDate for the same subject; such rows are left for someone to merge
+	// by hand.
+	Conflict bool
+}
+
+// PlanDateRepairs takes every attendance row, with NewDate its class date,
+// and returns those that must move, ordered by id. A move conflicts when
+// its row would share (USN, SubjectID, date) with another row once every
+// other move is made; since a conflicting row stays put and may block a
+// move in turn, conflicts are settled until none is added. The outcome is
+// the same in whatever order the rows come.
+func PlanDateRepairs(rows []DateRepair) []DateRepair {
+	type slot struct {
+		usn       string
+		subjectID int64
+		date      string
+	}
+	var moves []DateRepair
+	for _, r := range rows {
+		if !r.NewDate.Equal(r.OldDate) {
+			moves = append(moves, r)
+		}
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].AttendanceID < moves[j].AttendanceID })
+
+	for changed := true; changed; {
+		taken := map[slot]int{}
+		for _, r := range rows {
+			taken[slot{r.USN, r.SubjectID, r.OldDate.Format("2006-01-02")}]++
+		}
+		// Take each move off its old date and onto its new one.
+		for _, r := range moves {
+			if !r.Conflict {
+				taken[slot{r.USN, r.SubjectID, r.OldDate.Format("2006-01-02")}]--
+				taken[slot{r.USN, r.SubjectID, r.NewDate.Format("2006-01-02")}]++
+			}
+		}
+		changed = false
+		for i := range moves {
+			r := &moves[i]
+			if !r.Conflict && taken[slot{r.USN, r.SubjectID, r.NewDate.Format("2006-01-02")}] > 1 {
+				r.Conflict, changed = true, true
+			}
+		}
+	}
+	return moves
+}
+
+// AttendanceCorrection is the outcome of a status change on an attendance row.
+type AttendanceCorrection struct {
+	AttendanceID int64     `json:"attendance_id"`
+	USN          string    `json:"usn"`
+	SubjectCode  string    `json:"subject_code"`
+	OldStatus    string    `json:"old_status"`
+	Status       string    `json:"status"`
+	RecordedAt   time.Time `json:"recorded_at"`
+}
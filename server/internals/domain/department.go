@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Department is the entity faculty, students and subjects belong to. Code is
+// the key the other tables refer to, e.g. CSE.
+type Department struct {
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	HODFacultyID *int64    `json:"hod_faculty_id,omitempty"`
+	HODName      string    `json:"hod_name,omitempty"`
+	Semesters    int       `json:"semesters"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type DepartmentPayload struct {
+	Code         string `json:"code" validate:"required,department"`
+	Name         string `json:"name" validate:"required"`
+	HODFacultyID *int64 `json:"hod_faculty_id,omitempty"`
+	Semesters    int    `json:"semesters" validate:"required,min=1,max=8"`
+}
+
+// DepartmentUpdatePayload replaces everything but the code; a nil
+// HODFacultyID clears the head of department.
+type DepartmentUpdatePayload struct {
+	Name         string `json:"name" validate:"required"`
+	HODFacultyID *int64 `json:"hod_faculty_id,omitempty"`
+	Semesters    int    `json:"semesters" validate:"required,min=1,max=8"`
+}
+
+// DepartmentCode normalizes a department as typed by a user, so "cse " and
+// "CSE" name the same department.
+func DepartmentCode(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+type DepartmentRepo interface {
+	CreateDepartment(req DepartmentPayload) error
+	GetDepartments() ([]Department, error)
+	GetDepartment(code string) (Department, error)
+	UpdateDepartment(code string, req DepartmentUpdatePayload) error
+	// DeleteDepartment fails with a conflict while faculty, students or
+	// subjects still belong to the department.
+	DeleteDepartment(code string) error
+}
+
+// AuthorizeHOD checks that facultyID is the head of department code. The
+// role is read from the department on every request, so reassigning the HOD
+// takes effect without new tokens.
+func AuthorizeHOD(departments DepartmentRepo, facultyID int64, code string) error {
+	d, err := departments.GetDepartment(DepartmentCode(code))
+	if err != nil {
+		return err
+	}
+	if d.HODFacultyID == nil || *d.HODFacultyID != facultyID {
+		return Forbidden("not the head of department %s", DepartmentCode(code))
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package domain
+
+import "fmt"
+
+// Error codes clients can switch on; the HTTP error handler maps each to a
+// status code.
+const (
+	CodeBadRequest   = "bad_request"
+	CodeValidation   = "validation_failed"
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeInternal     = "internal_error"
+)
+
+// Sentinels for errors.Is; they match any *Error with the same code.
+var (
+	ErrBadRequest   = &Error{Code: CodeBadRequest}
+	ErrValidation   = &Error{Code: CodeValidation}
+	ErrUnauthorized = &Error{Code: CodeUnauthorized}
+	ErrForbidden    = &Error{Code: CodeForbidden}
+	ErrNotFound     = &Error{Code: CodeNotFound}
+	ErrConflict     = &Error{Code: CodeConflict}
+)
+
+// Error is an error that is safe to show to the client. Message never
+// carries driver or SQL text; the underlying cause, if any, is kept in Err
+// for logs only.
+type Error struct {
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// FieldError explains why one field of a payload was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Message == "" && t.Code == e.Code
+}
+
+// Wrap records cause as the underlying error of e.
+func (e *Error) Wrap(cause error) *Error {
+	e.Err = cause
+	return e
+}
+
+func newError(code, format string, args []any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func BadRequest(format string, args ...any) *Error {
+	return newError(CodeBadRequest, format, args)
+}
+
+func Unauthorized(format string, args ...any) *Error {
+	return newError(CodeUnauthorized, format, args)
+}
+
+func Forbidden(format string, args ...any) *Error {
+	return newError(CodeForbidden, format, args)
+}
+
+func NotFound(format string, args ...any) *Error {
+	return newError(CodeNotFound, format, args)
+}
+
+func Conflict(format string, args ...any) *Error {
+	return newError(CodeConflict, format, args)
+}
+
+// Invalid reports a single invalid field.
+func Invalid(field, rule, format string, args ...any) *Error {
+	msg := fmt.Sprintf(format, args...)
+	return &Error{
+		Code:    CodeValidation,
+		Message: "validation error: " + msg,
+		Fields:  []FieldError{{Field: field, Rule: rule, Message: msg}},
+	}
+}
+
+// Validation reports every invalid field of a payload at once.
+func Validation(fields ...FieldError) *Error {
+	msg := "validation error"
+	if len(fields) > 0 {
+		msg += ": " + fields[0].Message
+		if len(fields) > 1 {
+			msg += fmt.Sprintf(" (and %d more)", len(fields)-1)
+		}
+	}
+	return &Error{Code: CodeValidation, Message: msg, Fields: fields}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+const (
+	// EventAttendanceRecorded fires for every capture stored by MarkAttendance
+	// or BulkMarkAttendance. Its subject is the one whose logged session
+	// covers the capture, if any; the row carries none until assigned.
+	EventAttendanceRecorded = "attendance.recorded"
+	// EventAttendanceAssigned fires when a faculty assigns a time range of
+	// captures to a subject.
+	EventAttendanceAssigned = "attendance.assigned"
+	// EventAttendanceCorrected fires when a faculty changes a row's status.
+	EventAttendanceCorrected = "attendance.corrected"
+)
+
+type AttendanceEvent struct {
+	Type         string    `json:"type"`
+	AttendanceID int64     `json:"attendance_id,omitempty"`
+	USN          string    `json:"usn,omitempty"`
+	SubjectCode  string    `json:"subject_code,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	OldStatus    string    `json:"old_status,omitempty"`
+	RecordedAt   time.Time `json:"recorded_at,omitzero"`
+	UpdatedCount int64     `json:"updated_count,omitempty"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// EventPublisher receives attendance events after they are committed.
+// Implementations must not block the caller.
+type EventPublisher interface {
+	Publish(event AttendanceEvent)
+}
+
+// Publishers fans every event out to each of its publishers in order.
+type Publishers []EventPublisher
+
+func (ps Publishers) Publish(event AttendanceEvent) {
+	for _, p := range ps {
+		p.Publish(event)
+	}
+}
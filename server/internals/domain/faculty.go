@@ -9,20 +9,26 @@ type Faculty struct {
 }
 
 type FacultyRegisterPayload struct {
-	Name       string `json:"name"`
-	Email      string `json:"email"`
-	Password   string `json:"password"`
-	Department string `json:"department"`
+	Name       string `json:"name" validate:"required"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required,password"`
+	Department string `json:"department" validate:"required,department"`
 }
 
 type FacultyLoginPayload struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// FacultyFilter narrows the faculty list; Search matches name or email.
+type FacultyFilter struct {
+	Department string
+	Search     string
 }
 
 type FacultyRepo interface {
 	GetFacultyByID(facultyID int64) (Faculty, error)
 	CreateFaculty(req FacultyRegisterPayload) (int64, error)
 	AuthenticateFaculty(req FacultyLoginPayload) (string, error)
-	GetAllFaculty() ([]Faculty, error)
+	GetAllFaculty(filter FacultyFilter, page PageRequest) ([]Faculty, int, error)
 }
@@ -0,0 +1,53 @@
+package domain
+
+type Guardian struct {
+	ID       int64  `json:"guardian_id"`
+	Name     string `json:"name"`
+	Relation string `json:"relation"`
+	Phone    string `json:"phone"`
+	Email    string `json:"email"`
+}
+
+// GuardianPayload creates a guardian and links them to the listed wards.
+type GuardianPayload struct {
+	Name     string   `json:"name" validate:"required"`
+	Relation string   `json:"relation" validate:"required"`
+	Phone    string   `json:"phone" validate:"required"`
+	Email    string   `json:"email" validate:"required,email"`
+	Password string   `json:"password" validate:"required,password"`
+	USNs     []string `json:"usns" validate:"required,min=1,dive,required,usn"`
+}
+
+type GuardianLoginPayload struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type GuardianLinkPayload struct {
+	USN string `json:"usn" validate:"required,usn"`
+}
+
+// Ward is a student as seen by their guardian.
+type Ward struct {
+	USN        string `json:"usn"`
+	Name       string `json:"name"`
+	Department string `json:"department"`
+	Sem        int    `json:"sem"`
+}
+
+// GuardianContact is a guardian to alert about one of their wards.
+type GuardianContact struct {
+	GuardianID int64  `json:"guardian_id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	USN        string `json:"usn"`
+}
+
+type GuardianRepo interface {
+	CreateGuardian(req GuardianPayload) (int64, error)
+	LinkGuardianStudent(guardianID int64, usn string) error
+	AuthenticateGuardian(req GuardianLoginPayload) (string, error)
+	GetGuardianWards(guardianID int64) ([]Ward, error)
+	IsGuardianOf(guardianID int64, usn string) (bool, error)
+	GetGuardiansByStudent(usn string) ([]Guardian, error)
+}
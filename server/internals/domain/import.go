@@ -0,0 +1,42 @@
+package domain
+
+const (
+	ImportEntityStudents = "students"
+	ImportEntityFaculty  = "faculty"
+	ImportEntitySubjects = "subjects"
+)
+
+// ImportRowError points at a single offending cell of an uploaded roster.
+// Row is the spreadsheet row number, so the header is row 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Entity    string           `json:"entity"`
+	DryRun    bool             `json:"dry_run"`
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Imported  int              `json:"imported"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// ImportLookups is a snapshot of the keys a roster row may collide with or
+// refer to, loaded once per import so rows can be checked without a query each.
+type ImportLookups struct {
+	USNs          map[string]bool
+	FacultyEmails map[string]bool
+	FacultyIDs    map[int64]bool
+	SubjectCodes  map[string]bool
+	// Departments maps each department code to its number of semesters.
+	Departments map[string]int
+}
+
+type ImportRepo interface {
+	GetImportLookups() (ImportLookups, error)
+	ImportStudents(students []StudentRegisterPayload) (int, error)
+	ImportFaculty(faculty []FacultyRegisterPayload) (int, error)
+	ImportSubjects(subjects []SubjectPayload) (int, error)
+}
@@ -0,0 +1,113 @@
+package domain
+
+import "time"
+
+// Notification kinds. Each can be switched off per recipient.
+const (
+	NotificationLowAttendance   = "low_attendance"
+	NotificationWeeklyDigest    = "weekly_digest"
+	NotificationAbsenteeSummary = "absentee_summary"
+)
+
+// Recipient types used for preferences and the notification log. Students
+// are identified by USN, faculty by faculty_id, guardians by guardian_id.
+const (
+	RecipientStudent  = "student"
+	RecipientFaculty  = "faculty"
+	RecipientGuardian = "guardian"
+)
+
+// NotificationKindsFor lists the kinds a recipient type can receive.
+func NotificationKindsFor(recipientType string) []string {
+	switch recipientType {
+	case RecipientStudent:
+		return []string{NotificationLowAttendance, NotificationWeeklyDigest}
+	case RecipientFaculty:
+		return []string{NotificationAbsenteeSummary}
+	case RecipientGuardian:
+		return []string{NotificationLowAttendance}
+	}
+	return nil
+}
+
+// StudentContact is a student notifications may concern. Email is empty when
+// the student has none on record.
+type StudentContact struct {
+	USN        string `json:"usn"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Department string `json:"department"`
+	Sem        int    `json:"sem"`
+}
+
+// ClassAdvisor is the faculty member responsible for a department and sem.
+type ClassAdvisor struct {
+	Department string `json:"department"`
+	Sem        int    `json:"sem"`
+	FacultyID  int64  `json:"faculty_id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+}
+
+type ClassAdvisorPayload struct {
+	Department string `json:"department" validate:"required,department"`
+	Sem        int    `json:"sem" validate:"required,min=1,max=8"`
+	FacultyID  int64  `json:"faculty_id" validate:"required"`
+}
+
+// Absentee is one absence of a student in a subject on a given day.
+type Absentee struct {
+	USN         string `json:"usn"`
+	StudentName string `json:"student_name"`
+	SubjectCode string `json:"subject_code"`
+	SubjectName string `json:"subject_name"`
+}
+
+type NotificationPreference struct {
+	Kind    string `json:"kind"`
+	Enabled bool   `json:"enabled"`
+}
+
+type NotificationPreferencePayload struct {
+	Kind    string `json:"kind" validate:"required"`
+	Enabled *bool  `json:"enabled" validate:"required"`
+}
+
+// NotificationLogEntry records one delivery attempt. DedupeKey identifies
+// what the notification was about (a subject, a week, a day) so scheduled
+// jobs do not send it twice.
+type NotificationLogEntry struct {
+	Kind          string    `json:"kind"`
+	RecipientType string    `json:"recipient_type"`
+	RecipientID   string    `json:"recipient_id"`
+	DedupeKey     string    `json:"dedupe_key"`
+	Channel       string    `json:"channel"`
+	Status        string    `json:"status"`
+	Error         string    `json:"error,omitempty"`
+	SentAt        time.Time `json:"sent_at"`
+}
+
+// NotificationRun summarizes one run of a notification job.
+type NotificationRun struct {
+	Job     string `json:"job"`
+	Sent    int    `json:"sent"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"`
+}
+
+type NotificationRepo interface {
+	GetStudentContacts() ([]StudentContact, error)
+	// GetGuardianContacts returns guardians keyed by their ward's USN.
+	GetGuardianContacts() (map[string][]GuardianContact, error)
+	GetClassAdvisors() ([]ClassAdvisor, error)
+	SetClassAdvisor(req ClassAdvisorPayload) error
+	GetAbsentees(department string, sem int, date time.Time) ([]Absentee, error)
+	GetNotificationPreferences(recipientType, recipientID string) ([]NotificationPreference, error)
+	SetNotificationPreference(recipientType, recipientID, kind string, enabled bool) error
+	// GetOptedOut returns the recipients of the given type that disabled kind.
+	GetOptedOut(recipientType, kind string) (map[string]bool, error)
+	// WasNotified reports whether a successful delivery with this key was
+	// logged at or after since.
+	WasNotified(kind, recipientType, recipientID, dedupeKey string, since time.Time) (bool, error)
+	LogNotification(entry NotificationLogEntry) error
+}
@@ -0,0 +1,32 @@
+package domain
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// PageRequest is an offset page of a list endpoint. Sort names one of the
+// endpoint's whitelisted sort keys; empty means the endpoint's default order.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+}
+
+// Pagination is returned alongside a page so clients can request the next one.
+type Pagination struct {
+	Limit   int  `json:"limit"`
+	Offset  int  `json:"offset"`
+	Total   int  `json:"total"`
+	HasMore bool `json:"has_more"`
+}
+
+func NewPagination(page PageRequest, total int) *Pagination {
+	return &Pagination{
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+		Total:   total,
+		HasMore: page.Offset+page.Limit < total,
+	}
+}
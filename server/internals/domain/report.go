@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// AttendanceRegister describes the traditional register grid of a subject:
+// one column per class, one row per enrolled student.
+type AttendanceRegister struct {
+	SubjectID   int64       `json:"subject_id"`
+	SubjectCode string      `json:"subject_code"`
+	SubjectName string      `json:"subject_name"`
+	Department  string      `json:"department"`
+	Sem         int         `json:"sem"`
+	FacultyName string      `json:"faculty_name"`
+	From        time.Time   `json:"from"`
+	To          time.Time   `json:"to"`
+	Dates       []time.Time `json:"dates"`
+	// StartTimes holds the start time of each logged session in Dates, so
+	// two sessions on one date get separate columns; it is empty otherwise.
+	StartTimes []string `json:"start_times,omitempty"`
+	// SessionsLogged means Dates are the subject's logged teaching sessions,
+	// so every student's total is len(Dates) and blank cells are absences.
+	SessionsLogged bool `json:"sessions_logged"`
+}
+
+// AttendanceRegisterRow holds one student's cells, aligned with
+// AttendanceRegister.Dates: "P", "A" or "" when nothing was recorded.
+type AttendanceRegisterRow struct {
+	USN         string   `json:"usn"`
+	StudentName string   `json:"student_name"`
+	Cells       []string `json:"cells"`
+	Attended    int      `json:"attended"`
+	Total       int      `json:"total"`
+	Percentage  float64  `json:"percentage"`
+}
+
+type ReportRepo interface {
+	GetAttendanceRegister(subjectCode string, from, to time.Time) (AttendanceRegister, error)
+	StreamAttendanceRegisterRows(register AttendanceRegister, fn func(row AttendanceRegisterRow) error) error
+}
+
+type DefaulterQuery struct {
+	Department  string
+	Sem         int
+	SubjectCode string
+	// Threshold is the minimum eligible percentage, e.g. 75.
+	Threshold float64
+	// FacultyID, when set, limits the report to the subjects they teach.
+	FacultyID int64
+	Filter    AttendanceFilter
+}
+
+// Defaulter is a student below the attendance threshold in one subject.
+// RemainingClasses and CanReachThreshold are nil when the subject has no
+// planned class count to compare against.
+type Defaulter struct {
+	USN               string  `json:"usn"`
+	StudentName       string  `json:"student_name"`
+	Department        string  `json:"department"`
+	Sem               int     `json:"sem"`
+	SubjectCode       string  `json:"subject_code"`
+	SubjectName       string  `json:"subject_name"`
+	TotalClasses      int     `json:"total_classes"`
+	Attended          int     `json:"attended"`
+	Percentage        float64 `json:"percentage"`
+	ClassesHeld       int     `json:"classes_held"`
+	PlannedClasses    int     `json:"planned_classes"`
+	RemainingClasses  *int    `json:"remaining_classes"`
+	ClassesNeeded     int     `json:"classes_needed"`
+	CanReachThreshold *bool   `json:"can_reach_threshold"`
+	Condoned          bool    `json:"condoned"`
+	CondonationReason string  `json:"condonation_reason,omitempty"`
+}
+
+type DefaulterReport struct {
+	Threshold  float64     `json:"threshold"`
+	Defaulters []Defaulter `json:"defaulters"`
+}
+
+type CondonationPayload struct {
+	USN         string `json:"usn" validate:"required"`
+	SubjectCode string `json:"subjectCode" validate:"required,subject_code"`
+	Reason      string `json:"reason"`
+}
+
+type DefaulterRepo interface {
+	GetDefaulters(query DefaulterQuery) ([]Defaulter, error)
+	// SetCondonation grants or replaces the condonation of req.USN in
+	// req.SubjectCode; an unknown student or subject is not found, and a
+	// student not enrolled in the subject is invalid.
+	SetCondonation(adminID int64, req CondonationPayload) error
+	RemoveCondonation(usn, subjectCode string) error
+}
@@ -4,9 +4,12 @@ type SuccessResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
 	Data    any    `json:"data,omitempty"`
+	Meta    *Pagination `json:"meta,omitempty"`
 }
 
 type ErrorResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status  string       `json:"status"`
+	Code    string       `json:"code,omitempty"`
+	Error   string       `json:"error"`
+	Details []FieldError `json:"details,omitempty"`
 }
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Student struct {
 	ID           int64   `json:"student_id"`
@@ -8,16 +11,25 @@ type Student struct {
 	Username     string  `json:"username"`
 	Department   string  `json:"department"`
 	Sem          int     `json:"sem"`
+	Email        string  `json:"email,omitempty"`
 	FaceEncoding []byte  `json:"face_encoding,omitempty"`
 	NFCUID       *string `json:"nfc_uid,omitempty"`
 }
 
+// NormalizeUSN trims and upper-cases a USN, the form it is stored in. The
+// institution format is only enforced when a student is created, so students
+// registered under an older format can still sign in and be marked.
+func NormalizeUSN(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
 type StudentRegisterPayload struct {
-	USN        string `json:"usn"`
-	Username   string `json:"username"`
-	Password   string `json:"password"`
-	Department string `json:"department"`
-	Sem        int    `json:"sem"`
+	USN        string `json:"usn" validate:"required,usn"`
+	Username   string `json:"username" validate:"required"`
+	Password   string `json:"password" validate:"required,password"`
+	Department string `json:"department" validate:"required,department"`
+	Sem        int    `json:"sem" validate:"required,min=1,max=8"`
+	Email      string `json:"email,omitempty" validate:"omitempty,email"`
 }
 
 type StudentLoginPayload struct {
@@ -31,9 +43,10 @@ type GetStudentsWithSubjectsPayload struct {
 }
 
 type StudentUpdatePayload struct {
-	Username   string `json:"username"`
-	Department string `json:"department"`
-	Sem        int    `json:"sem"`
+	Username   string `json:"username" validate:"required"`
+	Department string `json:"department" validate:"required,department"`
+	Sem        int    `json:"sem" validate:"required,min=1,max=8"`
+	Email      string `json:"email,omitempty" validate:"omitempty,email"`
 }
 
 type StudentSummary struct {
@@ -1,11 +1,17 @@
 package domain
 
 type SubjectPayload struct {
-	Code       string `json:"subject_code"`
-	Name       string `json:"subject_name"`
-	FacultyID  int64  `json:"faculty_id"`
-	Department string `json:"department"`
-	Sem        int    `json:"sem"`
+	Code       string `json:"subject_code" validate:"required,subject_code"`
+	Name       string `json:"subject_name" validate:"required"`
+	FacultyID  int64  `json:"faculty_id" validate:"required"`
+	Department string `json:"department" validate:"required,department"`
+	Sem        int    `json:"sem" validate:"required,min=1,max=8"`
+	// PlannedClasses is the number of classes scheduled for the term, 0 if unknown.
+	PlannedClasses int `json:"planned_classes" validate:"min=0"`
+}
+
+type PlannedClassesPayload struct {
+	PlannedClasses int `json:"planned_classes" validate:"min=0"`
 }
 
 type Subject struct {
@@ -36,7 +42,8 @@ type SubjectSummary struct {
 
 type SubjectRepo interface {
 	AddSubject(subject SubjectPayload) (int64, error)
-	GetSubjectsByDeptAndSem(department string, sem int) ([]Subject, error)
+	GetSubjectsByDeptAndSem(department string, sem int, page PageRequest) ([]Subject, int, error)
 	GetSubjectsByFacultyID(facultyID int64) ([]Subject, error)
 	GetSubjectsByStudentID(studentID int64) ([]SubjectPayload, error)
+	SetPlannedClasses(subjectCode string, planned int) error
 }
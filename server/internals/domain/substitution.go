@@ -0,0 +1,108 @@
+package domain
+
+import "time"
+
+// Substitution hands one date's classes of a subject to another faculty
+// member. For that date the substitute may assign and correct the subject's
+// attendance and log its teaching session.
+type Substitution struct {
+	ID          int64     `json:"substitution_id"`
+	SubjectCode string    `json:"subject_code"`
+	SubjectName string    `json:"subject_name"`
+	Department  string    `json:"department"`
+	Date        time.Time `json:"date"`
+	// FacultyID teaches the subject; SubstituteID covers it on Date.
+	FacultyID      int64     `json:"faculty_id"`
+	FacultyName    string    `json:"faculty_name"`
+	SubstituteID   int64     `json:"substitute_faculty_id"`
+	SubstituteName string    `json:"substitute_name"`
+	Reason         string    `json:"reason,omitempty"`
+	AssignedBy     int64     `json:"assigned_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type SubstitutionPayload struct {
+	SubjectCode         string `json:"subjectCode" validate:"required,subject_code"`
+	ClassDate           string `json:"class_date" validate:"required,datetime=2006-01-02"`
+	SubstituteFacultyID int64  `json:"substitute_faculty_id" validate:"required,min=1"`
+	Reason              string `json:"reason" validate:"max=500"`
+}
+
+// ClassSwap records that SubjectCode, due from Start to End on Date, took
+// OtherSubjectCode's slot from OtherStart to OtherEnd and the other way
+// round. Both subjects stay with their own faculty, so a swap grants nothing.
+type ClassSwap struct {
+	ID               int64     `json:"swap_id"`
+	Department       string    `json:"department"`
+	Date             time.Time `json:"date"`
+	SubjectCode      string    `json:"subject_code"`
+	SubjectName      string    `json:"subject_name"`
+	Start            string    `json:"start"` // HH:MM
+	End              string    `json:"end"`
+	OtherSubjectCode string    `json:"other_subject_code"`
+	OtherSubjectName string    `json:"other_subject_name"`
+	OtherStart       string    `json:"other_start"`
+	OtherEnd         string    `json:"other_end"`
+	Reason           string    `json:"reason,omitempty"`
+	AssignedBy       int64     `json:"assigned_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type ClassSwapPayload struct {
+	ClassDate        string `json:"class_date" validate:"required,datetime=2006-01-02"`
+	SubjectCode      string `json:"subjectCode" validate:"required,subject_code"`
+	Start            string `json:"start" validate:"required,datetime=15:04"`
+	End              string `json:"end" validate:"required,datetime=15:04"`
+	OtherSubjectCode string `json:"otherSubjectCode" validate:"required,subject_code"`
+	OtherStart       string `json:"other_start" validate:"required,datetime=15:04"`
+	OtherEnd         string `json:"other_end" validate:"required,datetime=15:04"`
+	Reason           string `json:"reason" validate:"max=500"`
+}
+
+// SubstitutionQuery narrows substitutions and swaps; zero fields match
+// everything.
+type SubstitutionQuery struct {
+	Department string
+	// FacultyID matches the substitutions a faculty member covers or hands
+	// over, and the swaps of their subjects.
+	FacultyID int64
+	Filter    AttendanceFilter
+}
+
+type SubstitutionRepo interface {
+	// CreateSubstitution records s for s.SubjectCode, which must belong to
+	// s.Department; a subject has at most one substitute per date.
+	CreateSubstitution(assignedBy int64, s Substitution) (int64, error)
+	// DeleteSubstitution removes substitution id of a subject in department.
+	DeleteSubstitution(department string, id int64) error
+	// GetSubstitutions returns the matching substitutions by date and
+	// subject code.
+	GetSubstitutions(query SubstitutionQuery) ([]Substitution, error)
+	// CreateClassSwap records s; both subjects must belong to s.Department.
+	CreateClassSwap(assignedBy int64, s ClassSwap) (int64, error)
+	DeleteClassSwap(department string, id int64) error
+	GetClassSwaps(query SubstitutionQuery) ([]ClassSwap, error)
+}
+
+// AuthorizeSubject checks that facultyID teaches subjectCode or substitutes
+// for one of its classes between filter's dates.
+func AuthorizeSubject(attendance AttendanceRepository, substitutions SubstitutionRepo, facultyID int64, subjectCode string, filter AttendanceFilter) error {
+	ownerID, err := attendance.GetSubjectOwner(subjectCode)
+	if err != nil {
+		return err
+	}
+	if ownerID == facultyID {
+		return nil
+	}
+
+	covered, err := substitutions.GetSubstitutions(SubstitutionQuery{FacultyID: facultyID, Filter: filter})
+	if err != nil {
+		return err
+	}
+	for _, s := range covered {
+		if s.SubjectCode == subjectCode && s.SubstituteID == facultyID {
+			return nil
+		}
+	}
+	return Forbidden("not authorized for subject %s", subjectCode)
+}
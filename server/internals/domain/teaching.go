@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+// TeachingSession is a lecture a faculty member logged as held. Once a
+// subject has logged sessions in a date range, they are its classes held:
+// percentages divide by them, and a present row counts only on a logged date.
+type TeachingSession struct {
+	ID              int64     `json:"session_id"`
+	SubjectCode     string    `json:"subject_code"`
+	SubjectName     string    `json:"subject_name"`
+	FacultyID       int64     `json:"faculty_id"`
+	FacultyName     string    `json:"faculty_name"`
+	Date            time.Time `json:"date"`
+	StartTime       string    `json:"start_time"` // HH:MM
+	DurationMinutes int       `json:"duration_minutes"`
+	Topic           string    `json:"topic"`
+	Notes           string    `json:"notes,omitempty"`
+	// Present and Absent count the attendance recorded for the session.
+	Present   int       `json:"present"`
+	Absent    int       `json:"absent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TeachingSessionPayload struct {
+	SubjectCode     string `json:"subjectCode" validate:"required,subject_code"`
+	ClassDate       string `json:"class_date" validate:"required,datetime=2006-01-02"`
+	Start           string `json:"start" validate:"required,datetime=15:04"`
+	DurationMinutes int    `json:"duration_minutes" validate:"required,min=1,max=480"`
+	Topic           string `json:"topic" validate:"required,max=500"`
+	Notes           string `json:"notes" validate:"max=2000"`
+}
+
+// TeachingSessionUpdatePayload corrects a logged session; to move it to
+// another date or subject, delete it and log it again.
+type TeachingSessionUpdatePayload struct {
+	Start           string `json:"start" validate:"required,datetime=15:04"`
+	DurationMinutes int    `json:"duration_minutes" validate:"required,min=1,max=480"`
+	Topic           string `json:"topic" validate:"required,max=500"`
+	Notes           string `json:"notes" validate:"max=2000"`
+}
+
+// TeachingLogQuery narrows the log; zero fields match everything.
+type TeachingLogQuery struct {
+	FacultyID   int64
+	SubjectCode string
+	Filter      AttendanceFilter
+}
+
+// TeachingLogReport is the audit view of the log: totals per subject and
+// every session in date order.
+type TeachingLogReport struct {
+	From          *time.Time           `json:"from,omitempty"`
+	To            *time.Time           `json:"to,omitempty"`
+	TotalSessions int                  `json:"total_sessions"`
+	TotalMinutes  int                  `json:"total_minutes"`
+	Subjects      []TeachingLogSubject `json:"subjects"`
+	Sessions      []TeachingSession    `json:"sessions"`
+}
+
+type TeachingLogSubject struct {
+	SubjectCode string `json:"subject_code"`
+	SubjectName string `json:"subject_name"`
+	FacultyName string `json:"faculty_name"`
+	Sessions    int    `json:"sessions"`
+	Minutes     int    `json:"minutes"`
+}
+
+type TeachingSessionRepo interface {
+	// LogTeachingSession records s for s.SubjectCode, which facultyID must
+	// teach or substitute for on s.Date; a second session of the subject on
+	// the same date and start time conflicts.
+	LogTeachingSession(facultyID int64, s TeachingSession) (int64, error)
+	// UpdateTeachingSession replaces the start, duration, topic and notes
+	// of session s.ID, which facultyID must have logged; a start taken by
+	// another session that day conflicts.
+	UpdateTeachingSession(facultyID int64, s TeachingSession) error
+	DeleteTeachingSession(facultyID, sessionID int64) error
+	// GetTeachingLog returns the matching sessions ordered by date, start
+	// time and subject code.
+	GetTeachingLog(query TeachingLogQuery) ([]TeachingSession, error)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Term is a named slice of the academic calendar, e.g. a semester or an
+// internal-assessment period, that attendance reads can be limited to.
+type Term struct {
+	ID        int64     `json:"term_id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+type TermPayload struct {
+	Name      string `json:"name" validate:"required"`
+	StartDate string `json:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" validate:"required"`   // YYYY-MM-DD
+}
+
+// AttendanceFilter narrows attendance reads to a range of class dates. Zero
+// From or To leaves that side open; Term is resolved to its dates by the
+// services before the filter reaches a repository. Status, when set, keeps
+// only Present or only Absent rows on list endpoints.
+type AttendanceFilter struct {
+	From   time.Time
+	To     time.Time
+	Term   string
+	Status string
+}
+
+// WithTerm intersects the filter's range with the term's dates.
+func (f AttendanceFilter) WithTerm(term Term) AttendanceFilter {
+	if f.From.IsZero() || term.StartDate.After(f.From) {
+		f.From = term.StartDate
+	}
+	if f.To.IsZero() || term.EndDate.Before(f.To) {
+		f.To = term.EndDate
+	}
+	f.Term = ""
+	return f
+}
+
+type TermRepo interface {
+	CreateTerm(name string, start, end time.Time) (int64, error)
+	GetTerms() ([]Term, error)
+	GetTermByName(name string) (Term, error)
+}
+
+// ResolveAttendanceFilter looks up filter.Term, narrows the range to it and
+// rejects ranges that end before they start.
+func ResolveAttendanceFilter(terms TermRepo, filter AttendanceFilter) (AttendanceFilter, error) {
+	if filter.Term != "" {
+		term, err := terms.GetTermByName(filter.Term)
+		if err != nil {
+			return filter, err
+		}
+		filter = filter.WithTerm(term)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return filter, fmt.Errorf("validation error: to must not be before from")
+	}
+	if filter.Status != "" && filter.Status != "Present" && filter.Status != "Absent" {
+		return filter, fmt.Errorf("validation error: status must be Present or Absent")
+	}
+	return filter, nil
+}
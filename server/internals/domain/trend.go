@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// RollingWeeks is how many weeks TrendWeek.Rolling spans, the current one
+// included.
+const RollingWeeks = 4
+
+// DailyAttendance is one assigned row of a student, the unit the calendar
+// and trend views are built from.
+type DailyAttendance struct {
+	Date        time.Time
+	SubjectCode string
+	SubjectName string
+	Status      string
+}
+
+// AttendanceCalendar has a day for every date of Month, classes or not, so
+// clients can draw a heatmap without filling gaps.
+type AttendanceCalendar struct {
+	Month string        `json:"month"`
+	Days  []CalendarDay `json:"days"`
+}
+
+type CalendarDay struct {
+	Date    time.Time       `json:"date"`
+	Present int             `json:"present"`
+	Absent  int             `json:"absent"`
+	Entries []CalendarEntry `json:"entries"`
+}
+
+type CalendarEntry struct {
+	SubjectCode string `json:"subject_code"`
+	SubjectName string `json:"subject_name"`
+	Status      string `json:"status"`
+}
+
+type SubjectTrend struct {
+	SubjectCode string      `json:"subject_code"`
+	SubjectName string      `json:"subject_name"`
+	Weeks       []TrendWeek `json:"weeks"`
+}
+
+// TrendWeek is one week with classes. Rolling is the percentage over the
+// last RollingWeeks weeks and Cumulative over every week so far.
+type TrendWeek struct {
+	WeekStart  time.Time `json:"week_start"`
+	Present    int       `json:"present"`
+	Total      int       `json:"total"`
+	Percentage float64   `json:"percentage"`
+	Rolling    float64   `json:"rolling"`
+	Cumulative float64   `json:"cumulative"`
+}
+
+// SubjectProjection estimates where a student ends the term in a subject.
+// The repository fills the counts; RemainingClasses is nil when the subject
+// has no planned class count, and the projections then equal Percentage.
+type SubjectProjection struct {
+	SubjectCode    string  `json:"subject_code"`
+	SubjectName    string  `json:"subject_name"`
+	Attended       int     `json:"attended"`
+	Total          int     `json:"total"`
+	Percentage     float64 `json:"percentage"`
+	ClassesHeld    int     `json:"classes_held"`
+	PlannedClasses int     `json:"planned_classes"`
+
+	RemainingClasses *int `json:"remaining_classes"`
+	// RecentPercentage is the Rolling percentage of the latest week with
+	// classes; ProjectedPercentage assumes the student keeps that rate.
+	RecentPercentage    float64 `json:"recent_percentage"`
+	ProjectedPercentage float64 `json:"projected_percentage"`
+	BestCase            float64 `json:"best_case"`
+	WorstCase           float64 `json:"worst_case"`
+	// AllowedAbsences is how many remaining classes can be missed while
+	// ending at or above the threshold; -1 when even full attendance falls
+	// short.
+	AllowedAbsences *int `json:"allowed_absences"`
+}
+
+type AttendanceProjection struct {
+	Threshold float64             `json:"threshold"`
+	Subjects  []SubjectProjection `json:"subjects"`
+}
+
+type StudentTrendRepo interface {
+	// GetStudentDailyAttendance returns the student's rows in enrolled
+	// subjects, ordered by date and subject code.
+	GetStudentDailyAttendance(usn string, filter AttendanceFilter) ([]DailyAttendance, error)
+	// GetStudentProgress returns a row per enrolled subject, classes or not,
+	// ordered by subject code.
+	GetStudentProgress(usn string, filter AttendanceFilter) ([]SubjectProjection, error)
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery states. A delivery that exhausts its retries is dead and
+// stays in the log until an admin replays it.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookAllEvents subscribes a webhook to every event type.
+const WebhookAllEvents = "*"
+
+type Webhook struct {
+	ID         int64     `json:"webhook_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookPayload registers a subscription. A secret is generated when none
+// is given; it is only returned on creation.
+type WebhookPayload struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=* attendance.recorded attendance.assigned attendance.corrected"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"delivery_id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// PendingDelivery is a claimed delivery together with where and how to send it.
+type PendingDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+type DeliveryFilter struct {
+	WebhookID int64
+	Status    string
+}
+
+type WebhookRepo interface {
+	CreateWebhook(req WebhookPayload) (int64, error)
+	GetWebhooks() ([]Webhook, error)
+	DeleteWebhook(webhookID int64) error
+	// EnqueueDeliveries queues payload for every active webhook subscribed
+	// to eventType and returns how many deliveries were queued.
+	EnqueueDeliveries(eventType string, payload []byte) (int, error)
+	// ClaimDueDeliveries returns up to limit pending deliveries that are due
+	// and pushes their next attempt out by lease so no other worker picks
+	// them up meanwhile.
+	ClaimDueDeliveries(limit int, lease time.Duration) ([]PendingDelivery, error)
+	MarkDeliverySucceeded(deliveryID int64, statusCode int) error
+	// MarkDeliveryFailed records a failed attempt. A nil nextAttempt moves the
+	// delivery to the dead-letter state.
+	MarkDeliveryFailed(deliveryID int64, statusCode int, errMsg string, nextAttempt *time.Time) error
+	GetDeliveries(filter DeliveryFilter, page PageRequest) ([]WebhookDelivery, int, error)
+	GetDelivery(deliveryID int64) (WebhookDelivery, error)
+	// ReplayDelivery puts a delivery back in the queue with a fresh retry budget.
+	ReplayDelivery(deliveryID int64) error
+}
@@ -0,0 +1,58 @@
+package admin_handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	admin_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/admin"
+)
+
+type AdminHandler struct {
+	AdminService *admin_service.AdminService
+}
+
+func NewAdminHandler(as *admin_service.AdminService) *AdminHandler {
+	return &AdminHandler{
+		AdminService: as,
+	}
+}
+
+func (h *AdminHandler) RegisterAdminHandler(c echo.Context) error {
+	var req domain.AdminRegisterPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	id, err := h.AdminService.RegisterAdmin(req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Admin registered successfully",
+		Data:    map[string]int64{"admin_id": id},
+	})
+}
+
+func (h *AdminHandler) LoginAdminHandler(c echo.Context) error {
+	var req domain.AdminLoginPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	token, err := h.AdminService.AuthenticateAdmin(req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Authentication successful",
+		Data:    map[string]string{"token": token},
+	})
+}
@@ -0,0 +1,63 @@
+package analytics_handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	analytics_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/analytics"
+)
+
+type AnalyticsHandler struct {
+	AnalyticsService *analytics_service.AnalyticsService
+}
+
+func NewAnalyticsHandler(as *analytics_service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		AnalyticsService: as,
+	}
+}
+
+// GetHODAnalyticsHandler serves the dashboard to the head of the department
+// named in the path.
+func (h *AnalyticsHandler) GetHODAnalyticsHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	if err := h.AnalyticsService.AuthorizeHOD(facultyID, c.Param("code")); err != nil {
+		return err
+	}
+	return h.GetDepartmentAnalyticsHandler(c)
+}
+
+// GetDepartmentAnalyticsHandler aggregates the department's attendance over
+// from/to or term; limit caps the absentee list.
+func (h *AnalyticsHandler) GetDepartmentAnalyticsHandler(c echo.Context) error {
+	query := domain.AnalyticsQuery{Department: c.Param("code")}
+
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return domain.BadRequest("invalid limit parameter")
+		}
+		query.TopAbsentees = limit
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	query.Filter = filter
+
+	analytics, err := h.AnalyticsService.GetDepartmentAnalytics(query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Department analytics fetched successfully",
+		Data:    analytics,
+	})
+}
@@ -0,0 +1,84 @@
+package anomaly_handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	anomaly_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/anomaly"
+)
+
+type AnomalyHandler struct {
+	AnomalyService *anomaly_service.AnomalyService
+}
+
+func NewAnomalyHandler(as *anomaly_service.AnomalyService) *AnomalyHandler {
+	return &AnomalyHandler{
+		AnomalyService: as,
+	}
+}
+
+// GetFlagsHandler lists the flagged captures of the logged in faculty
+// member's department, optionally narrowed by review and date range.
+func (h *AnomalyHandler) GetFlagsHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+
+	flags, err := h.AnomalyService.GetFlags(facultyID, domain.FlagQuery{Review: c.QueryParam("review"), Filter: filter})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Flags fetched successfully",
+		Data:    flags,
+	})
+}
+
+func (h *AnomalyHandler) ReviewFlagHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid flag id")
+	}
+
+	var req domain.FlagReviewPayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	flag, err := h.AnomalyService.ReviewFlag(facultyID, id, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Flag reviewed successfully",
+		Data:    flag,
+	})
+}
+
+// GetFaceCropHandler serves the face crop sent with a flagged capture.
+func (h *AnomalyHandler) GetFaceCropHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid flag id")
+	}
+
+	data, err := h.AnomalyService.GetFaceCrop(facultyID, id)
+	if err != nil {
+		return err
+	}
+	return c.Blob(http.StatusOK, http.DetectContentType(data), data)
+}
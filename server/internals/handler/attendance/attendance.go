@@ -3,45 +3,49 @@ package attendance_handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
 	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
 )
 
 type AttendanceHandler struct {
 	AttendanceService *attendence_service.AttendanceService
+	// Calendar reads class dates and times in the institution timezone.
+	Calendar *calendar.Calendar
 }
 
-func NewAttendanceHandler(ar *attendence_service.AttendanceService) *AttendanceHandler {
+func NewAttendanceHandler(ar *attendence_service.AttendanceService, cal *calendar.Calendar) *AttendanceHandler {
 	return &AttendanceHandler{
 		AttendanceService: ar,
+		Calendar:          cal,
 	}
 }
 
 func (h *AttendanceHandler) MarkAttendanceHandler(c echo.Context) error {
 	var req domain.AttendancePayload
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "invalid request payload" + err.Error(),
-		})
+	if err := params.Bind(c, &req); err != nil {
+		return err
 	}
-	id, err := h.AttendanceService.MarkAttendance(&req)
+	result, err := h.AttendanceService.MarkAttendance(&req)
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to mark attendance" + err.Error(),
-		})
+		return err
 	}
 
+	message := "Attendance marked successfully"
+	if result.Held() {
+		message = "Attendance held for review"
+	}
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
 		Status:  "success",
-		Message: "Attendance marked successfully",
-		Data:    map[string]int64{"attendance_id": id},
+		Message: message,
+		Data:    result,
 	})
 }
 
@@ -49,26 +53,20 @@ func (h *AttendanceHandler) MarkAttendanceHandler(c echo.Context) error {
 func (h *AttendanceHandler) BulkAttendanceHandler(c echo.Context) error {
     var req []domain.AttendancePayload
 
-    if err := c.Bind(&req); err != nil {
-        return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-            Status: "error",
-            Error:  "invalid request payload: " + err.Error(),
-        })
+    if err := params.Bind(c, &req); err != nil {
+        return err
     }
 
     // Call service
-    count, err := h.AttendanceService.BulkMarkAttendance(req)
+    count, held, err := h.AttendanceService.BulkMarkAttendance(req)
     if err != nil {
-        return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-            Status: "error",
-            Error:  "Failed to mark bulk attendance: " + err.Error(),
-        })
+        return err
     }
 
     return c.JSON(http.StatusOK, domain.SuccessResponse{
         Status:  "success",
         Message: fmt.Sprintf("Attendance marked successfully for %d students", count),
-        Data:    map[string]int{"inserted_count": count},
+        Data:    map[string]int{"inserted_count": count, "held_count": held},
     })
 }
 
@@ -78,26 +76,29 @@ func (h *AttendanceHandler) GetAttendanceByStudentAndSubjectHandler(c echo.Conte
 	var subjectCode = c.QueryParam("subjectCode")
 
 	if usn == "" || subjectCode == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "usn and subject_id are required",
-		})
+		return domain.BadRequest("usn and subject_id are required")
 	}
 
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	page, err := params.Page(c)
+	if err != nil {
+		return err
+	}
 
-	attendances, err := h.AttendanceService.GetAttendanceByStudentAndSubject(usn, subjectCode)
+	attendances, total, err := h.AttendanceService.GetAttendanceByStudentAndSubject(usn, subjectCode, filter, page)
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to fetch attendance" + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
 		Status:  "success",
 		Message: "Attendance fetched successfully",
 		Data:    attendances,
+		Meta:    domain.NewPagination(page, total),
 	})
 }
 
@@ -107,28 +108,19 @@ func (h *AttendanceHandler) GetAttendanceBySubjectAndDateHandler(c echo.Context)
 	dateStr := c.QueryParam("date") // e.g. "2025-06-12"
 
 	if subjectCode == "" || dateStr == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "subject_id and date are required",
-		})
+		return domain.BadRequest("subject_id and date are required")
 	}
 
 
 	// Parse only the date part (no time, assume YYYY-MM-DD)
-	date, err := time.Parse("2006-01-02", dateStr)
+	date, err := h.Calendar.ParseDate(dateStr)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "invalid date: " + err.Error(),
-		})
+		return domain.BadRequest("invalid date, expected YYYY-MM-DD").Wrap(err)
 	}
 
 	attendances, err := h.AttendanceService.GetAttendanceBySubjectAndDate(subjectCode, date)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "failed to fetch attendance: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -138,23 +130,15 @@ func (h *AttendanceHandler) GetAttendanceBySubjectAndDateHandler(c echo.Context)
 	})
 }
 func (h *AttendanceHandler) AssignSubjectToTimeRangeHandler(c echo.Context) error {
-	var req struct {
-		SubjectCode string  `json:"subjectCode"`
-		ClassDate string `json:"class_date"` // YYYY-MM-DD
-		Start     string `json:"start"`      // HH:MM
-		End       string `json:"end"`        // HH:MM
-	}
-	
+	var req domain.AssignSubjectPayload
+
 	var FacultyID = c.Get("faculty_id").(int64)
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error", Error: "invalid request: " + err.Error(),
-		})
+	if err := params.Bind(c, &req); err != nil {
+		return err
 	}
 
-	loc, _ := time.LoadLocation("Asia/Kolkata")
-	classDate, _ := time.ParseInLocation("2006-01-02", req.ClassDate, loc)
+	classDate, _ := h.Calendar.ParseDate(req.ClassDate)
 	startTime, _ := time.Parse("15:04", req.Start)
 	endTime, _ := time.Parse("15:04", req.End)
 
@@ -162,9 +146,7 @@ func (h *AttendanceHandler) AssignSubjectToTimeRangeHandler(c echo.Context) erro
 		FacultyID, req.SubjectCode, classDate, startTime, endTime,
 	)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error", Error: err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -179,24 +161,28 @@ func (h *AttendanceHandler) GetAttendanceSummaryBySubjectHandler(c echo.Context)
 	var subjectCode = c.QueryParam("subjectCode")
 
 	if subjectCode == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "subject_id is required",
-		})
+		return domain.BadRequest("subject_id is required")
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	page, err := params.Page(c)
+	if err != nil {
+		return err
 	}
 
-	summaries, err := h.AttendanceService.GetAttendanceSummaryBySubject(subjectCode)
+	summaries, total, err := h.AttendanceService.GetAttendanceSummaryBySubject(subjectCode, filter, page)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to fetch attendance summary" + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
 		Status:  "success",
 		Message: "Attendance summary fetched successfully",
 		Data:    summaries,
+		Meta:    domain.NewPagination(page, total),
 	})
 }
 
@@ -206,26 +192,17 @@ func (h *AttendanceHandler) GetClassAttendanceHandler(c echo.Context) error {
 	date := c.QueryParam("date")
 
 	if subjectCode == "" || date == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "subject_code and date are required",
-		})
+		return domain.BadRequest("subject_code and date are required")
 	}
 
-	dateTime, err := time.Parse("2006-01-02", date)
+	dateTime, err := h.Calendar.ParseDate(date)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "invalid date: " + err.Error(),
-		})
+		return domain.BadRequest("invalid date, expected YYYY-MM-DD").Wrap(err)
 	}
 
 	classAttendance, err := h.AttendanceService.GetClassAttendance(subjectCode, dateTime)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "failed to get class attendance: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -241,25 +218,29 @@ func (h *AttendanceHandler) GetStudentAttendanceHistoryHandler(c echo.Context) e
 	var usn = c.Get("usn").(string)
 
 	if subjectCode == "" || usn == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "usn and subject_id are required",
-		})
+		return domain.BadRequest("usn and subject_id are required")
 	}
 
-	attendanceHistory, err := h.AttendanceService.GetStudentAttendanceHistory(usn, subjectCode)
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	page, err := params.Page(c)
+	if err != nil {
+		return err
+	}
+
+	attendanceHistory, total, err := h.AttendanceService.GetStudentAttendanceHistory(usn, subjectCode, filter, page)
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to fetch attendance history" + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
 		Status:  "success",
 		Message: "Attendance history fetched successfully",
 		Data:    attendanceHistory,
+		Meta:    domain.NewPagination(page, total),
 	})
 }
 
@@ -268,18 +249,17 @@ func (h *AttendanceHandler) GetAttendanceSummaryByStudentHandler(c echo.Context)
 	usn,ok := c.Get("usn").(string)
 
 	if !ok || usn == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Invalid or missing usn in token",
-		})
+		return domain.BadRequest("Invalid or missing usn in token")
 	}
-	
-	summary, err := h.AttendanceService.GetAttendanceSummaryByStudent(usn)
+
+	filter, err := params.AttendanceFilter(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to get attendance summary: " + err.Error(),
-		})
+		return err
+	}
+
+	summary, err := h.AttendanceService.GetAttendanceSummaryByStudent(usn, filter)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -288,3 +268,30 @@ func (h *AttendanceHandler) GetAttendanceSummaryByStudentHandler(c echo.Context)
 		Data:    summary,
 	})
 }
+
+// CorrectAttendanceHandler lets the subject's faculty flip a single row
+// between Present and Absent.
+func (h *AttendanceHandler) CorrectAttendanceHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	attendanceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid attendance id")
+	}
+
+	var req domain.AttendanceCorrectionPayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	correction, err := h.AttendanceService.CorrectAttendance(facultyID, attendanceID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Attendance corrected successfully",
+		Data:    correction,
+	})
+}
@@ -0,0 +1,174 @@
+package attendance_handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	attendance_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/attendance"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/httperror"
+	facultymiddlerware "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/faculty_middlerware.go"
+	studentmiddlerwarego "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/student_middlerware.go"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+type fixture struct {
+	e *echo.Echo
+	// Bearer tokens of the subject's faculty, another faculty and a student.
+	owner, other, student string
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	utils.ConfigureJWT("test-secret", "test", time.Hour)
+	repo := memory.NewMemoryRepo(nil)
+
+	facultyToken := func(name, email string) (int64, string) {
+		id, err := repo.CreateFaculty(domain.FacultyRegisterPayload{Name: name, Email: email, Password: "secret123", Department: "CSE"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := repo.AuthenticateFaculty(domain.FacultyLoginPayload{Email: email, Password: "secret123"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id, token
+	}
+
+	var f fixture
+	ownerID, ownerToken := facultyToken("Ravi", "ravi@college.edu")
+	_, f.other = facultyToken("Meera", "meera@college.edu")
+	f.owner = ownerToken
+	if _, err := repo.AddSubject(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ownerID, Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.StudentRegister(domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice", Password: "secret123", Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	token, err := repo.LoginStudent("1RV21CS001", "secret123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.student = token
+
+	h := attendance_handler.NewAttendanceHandler(attendence_service.NewAttendanceService(repo, repo, repo, domain.Publishers{}, nil), calendar.New(nil))
+	f.e = echo.New()
+	f.e.HTTPErrorHandler = httperror.Handler
+	g := f.e.Group("/attendance")
+	g.POST("", h.MarkAttendanceHandler)
+	g.GET("", h.GetAttendanceByStudentAndSubjectHandler, studentmiddlerwarego.JWTMiddleware)
+	g.POST("/assignsubject", h.AssignSubjectToTimeRangeHandler, facultymiddlerware.FacultyJWTMiddleware)
+	g.PATCH("/:id/status", h.CorrectAttendanceHandler, facultymiddlerware.FacultyJWTMiddleware)
+	return f
+}
+
+func (f fixture) do(method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	f.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+	return v
+}
+
+const assign = `{"subjectCode":"CS501","class_date":"2025-06-12","start":"09:00","end":"10:00"}`
+
+func TestMarkAssignAndRead(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.do(http.MethodPost, "/attendance", "", `{"usn":"1RV21CS001","status":"Present","recorded_at":"2025-06-12T09:05:00Z"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mark: %d %s", rec.Code, rec.Body)
+	}
+	marked := decode[struct {
+		Data struct {
+			AttendanceID int64 `json:"attendance_id"`
+		} `json:"data"`
+	}](t, rec)
+
+	if rec := f.do(http.MethodPost, "/attendance/assignsubject", f.other, assign); rec.Code != http.StatusForbidden {
+		t.Fatalf("assign by another faculty: %d %s", rec.Code, rec.Body)
+	}
+	rec = f.do(http.MethodPost, "/attendance/assignsubject", f.owner, assign)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("assign: %d %s", rec.Code, rec.Body)
+	}
+	assigned := decode[struct {
+		Data map[string]int64 `json:"data"`
+	}](t, rec)
+	if assigned.Data["updatedCount"] != 1 || assigned.Data["skipped"] != 0 {
+		t.Fatalf("assign result = %v", assigned.Data)
+	}
+
+	rec = f.do(http.MethodGet, "/attendance?subjectCode=CS501&limit=10", f.student, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("read: %d %s", rec.Code, rec.Body)
+	}
+	read := decode[struct {
+		Data []domain.AttendanceWithNames `json:"data"`
+		Meta domain.Pagination            `json:"meta"`
+	}](t, rec)
+	if read.Meta.Total != 1 || len(read.Data) != 1 || read.Data[0].ID != marked.Data.AttendanceID || read.Data[0].SubjectName != "Compilers" {
+		t.Fatalf("read = %+v", read)
+	}
+}
+
+func TestCorrectAttendanceOwnership(t *testing.T) {
+	f := newFixture(t)
+	f.do(http.MethodPost, "/attendance", "", `{"usn":"1RV21CS001","status":"Absent","recorded_at":"2025-06-12T09:05:00Z"}`)
+	f.do(http.MethodPost, "/attendance/assignsubject", f.owner, assign)
+
+	path := fmt.Sprintf("/attendance/%d/status", 1)
+	if rec := f.do(http.MethodPatch, path, f.other, `{"status":"Present"}`); rec.Code != http.StatusForbidden {
+		t.Fatalf("correct by another faculty: %d %s", rec.Code, rec.Body)
+	}
+	rec := f.do(http.MethodPatch, path, f.owner, `{"status":"Present"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("correct: %d %s", rec.Code, rec.Body)
+	}
+	c := decode[struct {
+		Data domain.AttendanceCorrection `json:"data"`
+	}](t, rec)
+	if c.Data.OldStatus != "Absent" || c.Data.Status != "Present" {
+		t.Fatalf("correction = %+v", c.Data)
+	}
+
+	if rec := f.do(http.MethodPatch, "/attendance/abc/status", f.owner, `{"status":"Present"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad id: %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	f := newFixture(t)
+
+	if rec := f.do(http.MethodGet, "/attendance?subjectCode=CS501", "", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("read without token: %d", rec.Code)
+	}
+	// A faculty token carries no usn, so it cannot read a student's rows.
+	if rec := f.do(http.MethodGet, "/attendance?subjectCode=CS501", f.owner, ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("read with a faculty token: %d", rec.Code)
+	}
+	if rec := f.do(http.MethodPost, "/attendance/assignsubject", f.student, assign); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("assign with a student token: %d", rec.Code)
+	}
+}
@@ -0,0 +1,92 @@
+package department_handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	department_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/department"
+)
+
+type DepartmentHandler struct {
+	DepartmentService *department_service.DepartmentService
+}
+
+func NewDepartmentHandler(ds *department_service.DepartmentService) *DepartmentHandler {
+	return &DepartmentHandler{
+		DepartmentService: ds,
+	}
+}
+
+func (h *DepartmentHandler) CreateDepartmentHandler(c echo.Context) error {
+	var req domain.DepartmentPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.DepartmentService.CreateDepartment(req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Department created successfully",
+		Data:    map[string]string{"code": domain.DepartmentCode(req.Code)},
+	})
+}
+
+func (h *DepartmentHandler) GetDepartmentsHandler(c echo.Context) error {
+	departments, err := h.DepartmentService.GetDepartments()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Departments fetched successfully",
+		Data:    departments,
+	})
+}
+
+func (h *DepartmentHandler) GetDepartmentHandler(c echo.Context) error {
+	department, err := h.DepartmentService.GetDepartment(c.Param("code"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Department fetched successfully",
+		Data:    department,
+	})
+}
+
+func (h *DepartmentHandler) UpdateDepartmentHandler(c echo.Context) error {
+	var req domain.DepartmentUpdatePayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.DepartmentService.UpdateDepartment(c.Param("code"), req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Department updated successfully",
+	})
+}
+
+func (h *DepartmentHandler) DeleteDepartmentHandler(c echo.Context) error {
+	if err := h.DepartmentService.DeleteDepartment(c.Param("code")); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Department deleted successfully",
+	})
+}
@@ -0,0 +1,76 @@
+package export_handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	export_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/export"
+)
+
+type ExportHandler struct {
+	ExportService *export_service.ExportService
+}
+
+func NewExportHandler(es *export_service.ExportService) *ExportHandler {
+	return &ExportHandler{
+		ExportService: es,
+	}
+}
+
+// ExportFacultyAttendanceRegisterHandler serves the register to the faculty
+// teaching the subject or substituting for it in the period.
+func (h *ExportHandler) ExportFacultyAttendanceRegisterHandler(c echo.Context) error {
+	return h.exportRegister(c, c.Get("faculty_id").(int64))
+}
+
+// ExportAttendanceRegisterHandler downloads the register of a subject between
+// from and to (YYYY-MM-DD), or over a named term, as csv, xlsx or pdf.
+func (h *ExportHandler) ExportAttendanceRegisterHandler(c echo.Context) error {
+	return h.exportRegister(c, 0)
+}
+
+// exportRegister writes the register; a non-zero facultyID must be allowed
+// to see the subject.
+func (h *ExportHandler) exportRegister(c echo.Context, facultyID int64) error {
+	subjectCode := c.QueryParam("subjectCode")
+	format := c.QueryParam("format")
+	if format == "" {
+		format = export_service.FormatCSV
+	}
+
+	contentType, ok := export_service.ContentType(format)
+	if !ok {
+		return domain.BadRequest("format must be one of csv, xlsx or pdf")
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+
+	reg, err := h.ExportService.PrepareRegister(subjectCode, filter)
+	if err != nil {
+		return err
+	}
+	if facultyID != 0 {
+		if err := h.ExportService.AuthorizeRegister(facultyID, reg); err != nil {
+			return err
+		}
+	}
+
+	filename := fmt.Sprintf("attendance_%s_%s_%s.%s", reg.SubjectCode,
+		reg.From.Format("20060102"), reg.To.Format("20060102"), format)
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	// Headers are already sent, so a failure here can only be logged.
+	if err := h.ExportService.WriteRegister(format, c.Response(), reg); err != nil {
+		log.Printf("export register %s: %v", reg.SubjectCode, err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"github.com/labstack/echo/v4"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
 	faculty_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/faculty"
 )
 
@@ -20,20 +21,14 @@ func NewFacultyHandler(fr *faculty_service.FacultyService) *FacultyHandler {
 func (h *FacultyHandler) RegisterFacultyHandler(c echo.Context) error {
 	var req domain.FacultyRegisterPayload
 	
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "invalid request payload" + err.Error(),
-		})
+	if err := params.Bind(c, &req); err != nil {
+		return err
 	}
 
 	id ,err := h.FacultyService.RegisterFaculty(req);
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to register faculty" + err.Error(),
-		})
+		return err
 	}
 	
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -46,20 +41,14 @@ func (h *FacultyHandler) RegisterFacultyHandler(c echo.Context) error {
 func (h *FacultyHandler) AuthenticateFacultyHandler(c echo.Context) error {
 	var req domain.FacultyLoginPayload
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "invalid request payload" + err.Error(),
-		})
+	if err := params.Bind(c, &req); err != nil {
+		return err
 	}
 
 	token, err := h.FacultyService.AuthenticateFaculty(req)
 
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Authentication failed: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -73,10 +62,7 @@ func (h *FacultyHandler) GetFacultyByIDHandler(c echo.Context) error {
 
 	faculty, err := h.FacultyService.GetFacultyByID(facultyID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to get faculty: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -86,42 +72,52 @@ func (h *FacultyHandler) GetFacultyByIDHandler(c echo.Context) error {
 	})
 }
 
+// GetAllFacultyHandler lists faculty a page at a time, optionally filtered by
+// department and a q search on name or email.
 func (h *FacultyHandler) GetAllFacultyHandler(c echo.Context) error {
-	faculties, err := h.FacultyService.GetAllFaculty()
+	page, err := params.Page(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to get faculties: " + err.Error(),
-		})
+		return err
+	}
+
+	filter := domain.FacultyFilter{
+		Department: c.QueryParam("department"),
+		Search:     c.QueryParam("q"),
+	}
+
+	faculties, total, err := h.FacultyService.GetAllFaculty(filter, page)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
 		Status:  "success",
 		Message: "Faculties retrieved successfully",
 		Data:    faculties,
+		Meta:    domain.NewPagination(page, total),
 	})
 }
 
 func (h *FacultyHandler) GetFacultyByDepartmentHandler(c echo.Context) error {
 	department := c.Param("dept")
 	if department == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "department query parameter is required",
-		})
+		return domain.BadRequest("department query parameter is required")
+	}
+
+	page, err := params.Page(c)
+	if err != nil {
+		return err
 	}
 
-	faculties, err := h.FacultyService.GetFacultyByDepartment(department)
+	faculties, total, err := h.FacultyService.GetFacultyByDepartment(department, page)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to get faculties: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
 		Status:  "success",
 		Message: "Faculties retrieved successfully",
 		Data:    faculties,
+		Meta:    domain.NewPagination(page, total),
 	})
 }
\ No newline at end of file
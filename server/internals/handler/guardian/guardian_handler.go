@@ -0,0 +1,173 @@
+package guardian_handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
+	guardian_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/guardian"
+)
+
+// GuardianHandler serves guardian accounts and their read-only view of their
+// wards' attendance.
+type GuardianHandler struct {
+	GuardianService   *guardian_service.GuardianService
+	AttendanceService *attendence_service.AttendanceService
+}
+
+func NewGuardianHandler(gs *guardian_service.GuardianService, as *attendence_service.AttendanceService) *GuardianHandler {
+	return &GuardianHandler{
+		GuardianService:   gs,
+		AttendanceService: as,
+	}
+}
+
+func (h *GuardianHandler) RegisterGuardianHandler(c echo.Context) error {
+	var req domain.GuardianPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	id, err := h.GuardianService.RegisterGuardian(req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Guardian registered successfully",
+		Data:    map[string]int64{"guardian_id": id},
+	})
+}
+
+func (h *GuardianHandler) LinkWardHandler(c echo.Context) error {
+	guardianID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid guardian id")
+	}
+
+	var req domain.GuardianLinkPayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.GuardianService.LinkWard(guardianID, req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Student linked to guardian successfully",
+	})
+}
+
+func (h *GuardianHandler) GetStudentGuardiansHandler(c echo.Context) error {
+	guardians, err := h.GuardianService.GetGuardiansByStudent(c.Param("usn"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Guardians fetched successfully",
+		Data:    guardians,
+	})
+}
+
+func (h *GuardianHandler) LoginGuardianHandler(c echo.Context) error {
+	var req domain.GuardianLoginPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	token, err := h.GuardianService.AuthenticateGuardian(req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Guardian authenticated successfully",
+		Data:    map[string]string{"token": token},
+	})
+}
+
+func (h *GuardianHandler) GetWardsHandler(c echo.Context) error {
+	guardianID := c.Get("guardian_id").(int64)
+
+	wards, err := h.GuardianService.GetWards(guardianID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Wards fetched successfully",
+		Data:    wards,
+	})
+}
+
+func (h *GuardianHandler) GetWardSummaryHandler(c echo.Context) error {
+	guardianID := c.Get("guardian_id").(int64)
+	usn := domain.NormalizeUSN(c.Param("usn"))
+
+	if err := h.GuardianService.AuthorizeWard(guardianID, usn); err != nil {
+		return err
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+
+	summary, err := h.AttendanceService.GetAttendanceSummaryByStudent(usn, filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Attendance summary retrieved successfully",
+		Data:    summary,
+	})
+}
+
+func (h *GuardianHandler) GetWardHistoryHandler(c echo.Context) error {
+	guardianID := c.Get("guardian_id").(int64)
+	usn := domain.NormalizeUSN(c.Param("usn"))
+
+	if err := h.GuardianService.AuthorizeWard(guardianID, usn); err != nil {
+		return err
+	}
+
+	subjectCode := c.QueryParam("subjectCode")
+	if subjectCode == "" {
+		return domain.BadRequest("subjectCode is required")
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	page, err := params.Page(c)
+	if err != nil {
+		return err
+	}
+
+	history, total, err := h.AttendanceService.GetStudentAttendanceHistory(usn, subjectCode, filter, page)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Attendance history fetched successfully",
+		Data:    history,
+		Meta:    domain.NewPagination(page, total),
+	})
+}
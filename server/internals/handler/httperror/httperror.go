@@ -0,0 +1,85 @@
+// Package httperror is the server's single place for turning handler errors
+// into HTTP responses.
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+var statusByCode = map[string]int{
+	domain.CodeBadRequest:   http.StatusBadRequest,
+	domain.CodeValidation:   http.StatusBadRequest,
+	domain.CodeUnauthorized: http.StatusUnauthorized,
+	domain.CodeForbidden:    http.StatusForbidden,
+	domain.CodeNotFound:     http.StatusNotFound,
+	domain.CodeConflict:     http.StatusConflict,
+	domain.CodeInternal:     http.StatusInternalServerError,
+}
+
+// Handler is the echo.HTTPErrorHandler. Domain errors are answered with
+// their own code and message, echo's errors (unknown route, oversized body)
+// with theirs, and anything else as an opaque internal error whose cause is
+// only logged, so driver and SQL text never reach the client.
+func Handler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, body := Response(err)
+	if status == http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request().Method, c.Path(), err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, body)
+	}
+	if err != nil {
+		log.Printf("%s %s: write error response: %v", c.Request().Method, c.Path(), err)
+	}
+}
+
+// Response returns the status and body Handler sends for err.
+func Response(err error) (int, domain.ErrorResponse) {
+	var de *domain.Error
+	if errors.As(err, &de) {
+		status, ok := statusByCode[de.Code]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		return status, domain.ErrorResponse{Status: "error", Code: de.Code, Error: de.Message, Details: de.Fields}
+	}
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		msg, ok := he.Message.(string)
+		if !ok {
+			msg = fmt.Sprint(he.Message)
+		}
+		return he.Code, domain.ErrorResponse{Status: "error", Code: codeFor(he.Code), Error: msg}
+	}
+
+	return http.StatusInternalServerError, domain.ErrorResponse{
+		Status: "error",
+		Code:   domain.CodeInternal,
+		Error:  "internal server error",
+	}
+}
+
+// codeFor names an HTTP status the way the domain codes are named.
+func codeFor(status int) string {
+	for code, s := range statusByCode {
+		if s == status && code != domain.CodeValidation {
+			return code
+		}
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
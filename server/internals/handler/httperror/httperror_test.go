@@ -0,0 +1,62 @@
+package httperror_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/httperror"
+)
+
+func TestResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		msg    string
+	}{
+		{"not found", domain.NotFound("subject not found for code: %s", "CS501"), http.StatusNotFound, "not_found", "subject not found for code: CS501"},
+		{"wrapped conflict", fmt.Errorf("register: %w", domain.Conflict("student with usn %s already exists", "1RV21CS001")), http.StatusConflict, "conflict", "student with usn 1RV21CS001 already exists"},
+		{"forbidden", domain.Forbidden("not authorized to correct this attendance"), http.StatusForbidden, "forbidden", "not authorized to correct this attendance"},
+		{"validation", domain.Invalid("sem", "range", "sem must be between 1 and 8"), http.StatusBadRequest, "validation_failed", "validation error: sem must be between 1 and 8"},
+		{"cause hidden", domain.Conflict("term T1 already exists").Wrap(errors.New(`duplicate key value violates unique constraint "terms_name_key"`)), http.StatusConflict, "conflict", "term T1 already exists"},
+		{"echo error", echo.ErrNotFound, http.StatusNotFound, "not_found", "Not Found"},
+		{"unknown", fmt.Errorf("query students: %w", errors.New(`pq: relation "students" does not exist`)), http.StatusInternalServerError, "internal_error", "internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := httperror.Response(tt.err)
+			if status != tt.status || body.Code != tt.code || body.Error != tt.msg {
+				t.Errorf("Response = %d %q %q, want %d %q %q", status, body.Code, body.Error, tt.status, tt.code, tt.msg)
+			}
+		})
+	}
+}
+
+func TestHandlerWritesDetails(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = httperror.Handler
+	e.POST("/", func(c echo.Context) error {
+		return domain.Validation(
+			domain.FieldError{Field: "usn", Rule: "required", Message: "usn is required"},
+			domain.FieldError{Field: "sem", Rule: "max", Message: "sem must be at most 8"},
+		)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status %d", rec.Code)
+	}
+	for _, want := range []string{`"code":"validation_failed"`, `"field":"usn"`, `"field":"sem"`, `(and 1 more)`} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("body %s does not contain %s", rec.Body, want)
+		}
+	}
+}
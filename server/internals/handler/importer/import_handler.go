@@ -0,0 +1,64 @@
+package import_handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	import_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/importer"
+)
+
+type ImportHandler struct {
+	ImportService *import_service.ImportService
+}
+
+func NewImportHandler(is *import_service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		ImportService: is,
+	}
+}
+
+// ImportRosterHandler accepts a multipart "file" field holding a CSV or XLSX roster
+// for the entity in the path (students, faculty or subjects).
+// Pass ?dry_run=true to only validate.
+func (h *ImportHandler) ImportRosterHandler(c echo.Context) error {
+	entity := c.Param("entity")
+
+	dryRun := false
+	if v := c.QueryParam("dry_run"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return domain.BadRequest("invalid dry_run parameter")
+		}
+		dryRun = parsed
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return domain.BadRequest("file is required").Wrap(err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return domain.BadRequest("failed to read uploaded file").Wrap(err)
+	}
+	defer file.Close()
+
+	result, err := h.ImportService.Import(entity, fileHeader.Filename, file, dryRun)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Imported %d of %d %s rows", result.Imported, result.TotalRows, entity)
+	if dryRun {
+		message = fmt.Sprintf("Validated %d %s rows, %d valid", result.TotalRows, entity, result.ValidRows)
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: message,
+		Data:    result,
+	})
+}
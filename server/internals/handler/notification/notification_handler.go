@@ -0,0 +1,114 @@
+package notification_handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	notification_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/notification"
+)
+
+type NotificationHandler struct {
+	NotificationService *notification_service.NotificationService
+}
+
+func NewNotificationHandler(ns *notification_service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationService: ns,
+	}
+}
+
+// recipient identifies the caller from the JWT set by the student, faculty
+// or guardian middleware.
+func recipient(c echo.Context) (string, string) {
+	if usn, ok := c.Get("usn").(string); ok && usn != "" {
+		return domain.RecipientStudent, usn
+	}
+	if facultyID, ok := c.Get("faculty_id").(int64); ok {
+		return domain.RecipientFaculty, strconv.FormatInt(facultyID, 10)
+	}
+	if guardianID, ok := c.Get("guardian_id").(int64); ok {
+		return domain.RecipientGuardian, strconv.FormatInt(guardianID, 10)
+	}
+	return "", ""
+}
+
+func (h *NotificationHandler) GetPreferencesHandler(c echo.Context) error {
+	recipientType, recipientID := recipient(c)
+
+	prefs, err := h.NotificationService.GetPreferences(recipientType, recipientID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Notification preferences fetched successfully",
+		Data:    prefs,
+	})
+}
+
+func (h *NotificationHandler) SetPreferenceHandler(c echo.Context) error {
+	recipientType, recipientID := recipient(c)
+
+	var req domain.NotificationPreferencePayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.NotificationService.SetPreference(recipientType, recipientID, req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Notification preference updated successfully",
+	})
+}
+
+func (h *NotificationHandler) SetClassAdvisorHandler(c echo.Context) error {
+	var req domain.ClassAdvisorPayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.NotificationService.SetClassAdvisor(req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Class advisor set successfully",
+	})
+}
+
+func (h *NotificationHandler) GetClassAdvisorsHandler(c echo.Context) error {
+	advisors, err := h.NotificationService.GetClassAdvisors()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Class advisors fetched successfully",
+		Data:    advisors,
+	})
+}
+
+// RunNotificationJobHandler runs low-attendance, weekly-digest or
+// absentee-summary now instead of waiting for the scheduler.
+func (h *NotificationHandler) RunNotificationJobHandler(c echo.Context) error {
+	run, err := h.NotificationService.RunJob(c.Param("job"), time.Now())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Notification job completed",
+		Data:    run,
+	})
+}
@@ -0,0 +1,80 @@
+// Package params parses the request body and the query parameters shared by
+// several handlers.
+package params
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// AttendanceFilter reads the optional from/to (YYYY-MM-DD), term and status
+// query parameters.
+func AttendanceFilter(c echo.Context) (domain.AttendanceFilter, error) {
+	filter := domain.AttendanceFilter{Term: c.QueryParam("term"), Status: c.QueryParam("status")}
+
+	if v := c.QueryParam("from"); v != "" {
+		from, err := time.Parse(calendar.DateLayout, v)
+		if err != nil {
+			return filter, domain.BadRequest("invalid from date, expected YYYY-MM-DD").Wrap(err)
+		}
+		filter.From = from
+	}
+	if v := c.QueryParam("to"); v != "" {
+		to, err := time.Parse(calendar.DateLayout, v)
+		if err != nil {
+			return filter, domain.BadRequest("invalid to date, expected YYYY-MM-DD").Wrap(err)
+		}
+		filter.To = to
+	}
+	return filter, nil
+}
+
+// Page reads limit, offset, sort and order (asc or desc) query parameters,
+// applying the default limit and capping it at the maximum.
+func Page(c echo.Context) (domain.PageRequest, error) {
+	page := domain.PageRequest{Limit: domain.DefaultPageLimit, Sort: c.QueryParam("sort")}
+
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return page, domain.BadRequest("invalid limit parameter")
+		}
+		page.Limit = min(limit, domain.MaxPageLimit)
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return page, domain.BadRequest("invalid offset parameter")
+		}
+		page.Offset = offset
+	}
+
+	switch c.QueryParam("order") {
+	case "", "asc":
+	case "desc":
+		page.Desc = true
+	default:
+		return page, domain.BadRequest("order must be asc or desc")
+	}
+	return page, nil
+}
+
+// Bind decodes the request body into v, reporting a malformed body as a bad
+// request with echo's description of what was wrong.
+func Bind(c echo.Context, v any) error {
+	err := c.Bind(v)
+	if err == nil {
+		return nil
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) && he.Code == http.StatusBadRequest {
+		return domain.BadRequest("invalid request payload: %v", he.Message).Wrap(err)
+	}
+	return err
+}
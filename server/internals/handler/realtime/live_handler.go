@@ -0,0 +1,86 @@
+package realtime_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/realtime"
+	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
+)
+
+// heartbeatInterval keeps proxies from closing an idle stream.
+const heartbeatInterval = 25 * time.Second
+
+type LiveHandler struct {
+	Hub               *realtime.Hub
+	AttendanceService *attendence_service.AttendanceService
+	Calendar          *calendar.Calendar
+}
+
+func NewLiveHandler(hub *realtime.Hub, as *attendence_service.AttendanceService, cal *calendar.Calendar) *LiveHandler {
+	return &LiveHandler{
+		Hub:               hub,
+		AttendanceService: as,
+		Calendar:          cal,
+	}
+}
+
+// LiveAttendanceHandler streams attendance events for one subject as
+// Server-Sent Events. A capture is streamed when it falls in a logged
+// session of the subject, or once it is assigned to it; until then nothing
+// says whose class it belongs to.
+func (h *LiveHandler) LiveAttendanceHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+	subjectCode := c.QueryParam("subjectCode")
+
+	if err := h.AttendanceService.AuthorizeSubjectFeed(facultyID, subjectCode, h.Calendar.Today()); err != nil {
+		return err
+	}
+
+	sub := h.Hub.Subscribe(subjectCode)
+	defer sub.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprint(res, ": connected\n\n"); err != nil {
+		return nil
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event := <-sub.Events():
+			data, err := json.Marshal(event)
+			if err != nil {
+				c.Logger().Errorf("live feed: encode %s: %v", event.Type, err)
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package realtime_handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/httperror"
+	realtime_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/realtime"
+	facultymiddlerware "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/faculty_middlerware.go"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/realtime"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+var classDay = time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	srv *httptest.Server
+	hub *realtime.Hub
+	svc *attendence_service.AttendanceService
+	// Bearer tokens of CS501's faculty, of its substitute on classDay and
+	// of another faculty, who substitutes the day after.
+	owner, substitute, other string
+	// returned receives once per stream that has ended.
+	returned chan struct{}
+}
+
+// newFixture serves the live feed of a CS501 class with a session logged
+// from 09:00 to 10:00 on classDay, which is today for the handler.
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	utils.ConfigureJWT("test-secret", "test", time.Hour)
+	repo := memory.NewMemoryRepo(nil)
+
+	facultyToken := func(name, email string) (int64, string) {
+		id, err := repo.CreateFaculty(domain.FacultyRegisterPayload{Name: name, Email: email, Password: "secret123", Department: "CSE"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := repo.AuthenticateFaculty(domain.FacultyLoginPayload{Email: email, Password: "secret123"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id, token
+	}
+
+	f := fixture{hub: realtime.NewHub(), returned: make(chan struct{}, 1)}
+	ownerID, ownerToken := facultyToken("Ravi", "ravi@college.edu")
+	substituteID, substituteToken := facultyToken("Sita", "sita@college.edu")
+	otherID, otherToken := facultyToken("Meera", "meera@college.edu")
+	f.owner, f.substitute, f.other = ownerToken, substituteToken, otherToken
+	if _, err := repo.AddSubject(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ownerID, Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	for id, date := range map[int64]time.Time{substituteID: classDay, otherID: classDay.AddDate(0, 0, 1)} {
+		if _, err := repo.CreateSubstitution(ownerID, domain.Substitution{SubjectCode: "CS501", Department: "CSE", Date: date, SubstituteID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repo.StudentRegister(domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice", Password: "secret123", Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.LogTeachingSession(ownerID, domain.TeachingSession{
+		SubjectCode: "CS501", Date: classDay, StartTime: "09:00", DurationMinutes: 60, Topic: "Parsing",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	f.svc = attendence_service.NewAttendanceService(repo, repo, repo, f.hub, nil)
+	cal := calendar.New(nil).WithClock(func() time.Time { return at(9, 30) })
+	h := realtime_handler.NewLiveHandler(f.hub, f.svc, cal)
+	e := echo.New()
+	e.HTTPErrorHandler = httperror.Handler
+	e.GET("/attendance/live", func(c echo.Context) error {
+		defer func() { f.returned <- struct{}{} }()
+		return h.LiveAttendanceHandler(c)
+	}, facultymiddlerware.FacultyStreamJWTMiddleware)
+	f.srv = httptest.NewServer(e)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f fixture) open(ctx context.Context, t *testing.T, token string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.srv.URL+"/attendance/live?subjectCode=CS501&access_token="+token, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+// at is a capture time on classDay.
+func at(hour, minute int) time.Time {
+	return classDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestLiveFeedStreamsCapturesInASession(t *testing.T) {
+	f := newFixture(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res := f.open(ctx, t, f.owner)
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get(echo.HeaderContentType) != "text/event-stream" {
+		t.Fatalf("status %d, content type %q", res.StatusCode, res.Header.Get(echo.HeaderContentType))
+	}
+	stream := bufio.NewReader(res.Body)
+	if line, err := stream.ReadString('\n'); err != nil || line != ": connected\n" {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+	if n := f.hub.Subscribers("CS501"); n != 1 {
+		t.Fatalf("subscribers = %d, want 1", n)
+	}
+
+	// The first capture is outside the session and reaches no feed; the
+	// second falls in it and is streamed though nobody has assigned it.
+	for _, recordedAt := range []time.Time{at(11, 30), at(9, 10)} {
+		if _, err := f.svc.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS001", Status: "Present", RecordedAt: recordedAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var eventType string
+	var event domain.AttendanceEvent
+	for event.Type == "" {
+		line, err := stream.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+				t.Fatalf("decode %q: %v", line, err)
+			}
+		}
+	}
+	if eventType != domain.EventAttendanceRecorded || event.SubjectCode != "CS501" || event.USN != "1RV21CS001" ||
+		!event.RecordedAt.Equal(at(9, 10)) {
+		t.Errorf("streamed %s %+v, want the 09:10 capture", eventType, event)
+	}
+
+	// Disconnecting ends the stream and drops the subscription.
+	cancel()
+	select {
+	case <-f.returned:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream kept running after the client disconnected")
+	}
+	if n := f.hub.Subscribers("CS501"); n != 0 {
+		t.Errorf("subscribers after disconnect = %d, want 0", n)
+	}
+}
+
+func TestLiveFeedRefusesOtherFaculty(t *testing.T) {
+	f := newFixture(t)
+
+	res := f.open(context.Background(), t, f.other)
+	res.Body.Close()
+	if res.StatusCode != http.StatusForbidden {
+		t.Errorf("status %d, want 403", res.StatusCode)
+	}
+	if n := f.hub.Subscribers("CS501"); n != 0 {
+		t.Errorf("subscribers = %d, want none", n)
+	}
+}
+
+func TestLiveFeedAdmitsTodaysSubstitute(t *testing.T) {
+	f := newFixture(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res := f.open(ctx, t, f.substitute)
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status %d, want 200", res.StatusCode)
+	}
+	if line, err := bufio.NewReader(res.Body).ReadString('\n'); err != nil || line != ": connected\n" {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+}
+
+func TestLiveFeedEndsWhenTheHubCloses(t *testing.T) {
+	f := newFixture(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res := f.open(ctx, t, f.owner)
+	defer res.Body.Close()
+	if line, err := bufio.NewReader(res.Body).ReadString('\n'); err != nil || line != ": connected\n" {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+
+	f.hub.Close()
+	select {
+	case <-f.returned:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream kept running after the hub closed")
+	}
+	if n := f.hub.Subscribers("CS501"); n != 0 {
+		t.Errorf("subscribers after shutdown = %d, want 0", n)
+	}
+}
@@ -0,0 +1,106 @@
+package report_handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	report_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/report"
+)
+
+type ReportHandler struct {
+	ReportService *report_service.ReportService
+}
+
+func NewReportHandler(rs *report_service.ReportService) *ReportHandler {
+	return &ReportHandler{
+		ReportService: rs,
+	}
+}
+
+// GetFacultyDefaulterReportHandler lists the defaulters of the subjects the
+// faculty teaches.
+func (h *ReportHandler) GetFacultyDefaulterReportHandler(c echo.Context) error {
+	return h.defaulterReport(c, c.Get("faculty_id").(int64))
+}
+
+// GetDefaulterReportHandler lists students below the eligibility threshold,
+// optionally narrowed by department, sem and subjectCode.
+func (h *ReportHandler) GetDefaulterReportHandler(c echo.Context) error {
+	return h.defaulterReport(c, 0)
+}
+
+func (h *ReportHandler) defaulterReport(c echo.Context, facultyID int64) error {
+	query := domain.DefaulterQuery{
+		FacultyID:   facultyID,
+		Department:  c.QueryParam("department"),
+		SubjectCode: c.QueryParam("subjectCode"),
+	}
+
+	if v := c.QueryParam("sem"); v != "" {
+		sem, err := strconv.Atoi(v)
+		if err != nil {
+			return domain.BadRequest("invalid sem parameter")
+		}
+		query.Sem = sem
+	}
+
+	if v := c.QueryParam("threshold"); v != "" {
+		threshold, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return domain.BadRequest("invalid threshold parameter")
+		}
+		query.Threshold = threshold
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	query.Filter = filter
+
+	report, err := h.ReportService.GetDefaulterReport(query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Defaulter report fetched successfully",
+		Data:    report,
+	})
+}
+
+func (h *ReportHandler) SetCondonationHandler(c echo.Context) error {
+	var req domain.CondonationPayload
+	adminID := c.Get("admin_id").(int64)
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.ReportService.SetCondonation(adminID, req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Condonation recorded successfully",
+	})
+}
+
+func (h *ReportHandler) RemoveCondonationHandler(c echo.Context) error {
+	usn := c.QueryParam("usn")
+	subjectCode := c.QueryParam("subjectCode")
+
+	if err := h.ReportService.RemoveCondonation(usn, subjectCode); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Condonation removed successfully",
+	})
+}
@@ -6,6 +6,7 @@ import (
 	
 	"github.com/labstack/echo/v4"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
 	student_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/student"
 )
 
@@ -22,19 +23,13 @@ func (h *StudentHandler)StudentRegisterHandler(c echo.Context)error{
 
 	var req domain.StudentRegisterPayload
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Invalid request payload: " + err.Error(),
-		})
+	if err := params.Bind(c, &req); err != nil {
+		return err
 	}
 
 	id, err := h.StudentService.RegisterStudent(req)
  	if err != nil {
- 		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
- 			Status: "error",
-		Error:  "Failed to register student: " + err.Error(),
- 		})
+ 		return err
  	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -47,20 +42,14 @@ func (h *StudentHandler)StudentRegisterHandler(c echo.Context)error{
 
 func (h *StudentHandler) LoginStudentHandler(c echo.Context) error {
 	var req domain.StudentLoginPayload
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Invalid request payload: " + err.Error(),
-		})
+	if err := params.Bind(c, &req); err != nil {
+		return err
 	}
 
 	// Authenticate student
 	token, err := h.StudentService.LoginStudent(req.USN, req.Password)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Authentication failed: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -0,0 +1,104 @@
+package student_handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/httperror"
+	student_handler "github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/student"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	student_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/student"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+func newServer(t *testing.T) *echo.Echo {
+	t.Helper()
+	utils.ConfigureJWT("test-secret", "test", time.Hour)
+
+	h := student_handler.NewStudentHandler(student_service.NewStudentService(memory.NewMemoryRepo(nil)))
+	e := echo.New()
+	e.HTTPErrorHandler = httperror.Handler
+	e.POST("/students/register", h.StudentRegisterHandler)
+	e.POST("/students/login", h.LoginStudentHandler)
+	return e
+}
+
+func post(e *echo.Echo, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+const register = `{"usn":"1RV21CS001","username":"Alice","password":"secret123","department":"CSE","sem":5}`
+
+func TestRegisterAndLogin(t *testing.T) {
+	e := newServer(t)
+
+	rec := post(e, "/students/register", register)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("register: %d %s", rec.Code, rec.Body)
+	}
+	var registered struct {
+		Data struct {
+			StudentID int64 `json:"student_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &registered); err != nil || registered.Data.StudentID == 0 {
+		t.Fatalf("register body %s (%v)", rec.Body, err)
+	}
+
+	rec = post(e, "/students/login", `{"usn":"1RV21CS001","password":"secret123"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login: %d %s", rec.Code, rec.Body)
+	}
+	var login struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &login); err != nil || login.Data.Token == "" {
+		t.Fatalf("login body %s (%v)", rec.Body, err)
+	}
+}
+
+func TestRegisterDuplicateUSN(t *testing.T) {
+	e := newServer(t)
+
+	if rec := post(e, "/students/register", register); rec.Code != http.StatusOK {
+		t.Fatalf("register: %d %s", rec.Code, rec.Body)
+	}
+	rec := post(e, "/students/register", register)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), `"code":"conflict"`) {
+		t.Fatalf("duplicate register: %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestLoginRejectsBadCredentials(t *testing.T) {
+	e := newServer(t)
+	post(e, "/students/register", register)
+
+	for _, body := range []string{
+		`{"usn":"1RV21CS001","password":"wrong"}`,
+		`{"usn":"1RV21CS999","password":"secret123"}`,
+	} {
+		if rec := post(e, "/students/login", body); rec.Code != http.StatusUnauthorized {
+			t.Errorf("login %s: %d %s", body, rec.Code, rec.Body)
+		}
+	}
+
+	rec := post(e, "/students/login", `{"usn":"1RV21CS001"}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"field":"password"`) {
+		t.Errorf("login without password: %d %s", rec.Code, rec.Body)
+	}
+
+	if rec := post(e, "/students/login", `{"usn":`); rec.Code != http.StatusBadRequest {
+		t.Errorf("malformed login: %d %s", rec.Code, rec.Body)
+	}
+}
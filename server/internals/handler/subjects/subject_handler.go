@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/subject"
 )
 
@@ -23,19 +24,13 @@ func (h *SubjectHandler) AddSubjectHandler(c echo.Context) error {
 
 	var req domain.SubjectPayload
 
-     if err := c.Bind(&req); err != nil {
-        return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-            Status: "error",
-            Error:  "invalid request payload"+err.Error(),
-        })
+     if err := params.Bind(c, &req); err != nil {
+        return err
 	}
 	id, err := h.SubjectService.AddSubject(req)
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to add the subjects" + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -50,32 +45,29 @@ func (h *SubjectHandler) GetSubjectsByDeptAndSemHandler(c echo.Context) error {
 	semParam := c.QueryParam("sem")
 
 	if department == "" || semParam == "" {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "department and sem query parameters are required",
-		})
+		return domain.BadRequest("department and sem query parameters are required")
 	}
 
 	sem, err := strconv.Atoi(semParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "invalid sem parameter",
-		})
+		return domain.BadRequest("invalid sem parameter")
 	}
 
-	subjects, err := h.SubjectService.GetSubjectsByDeptAndSem(department, sem)
+	page, err := params.Page(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to fetch subjects" + err.Error(),
-		})
+		return err
+	}
+
+	subjects, total, err := h.SubjectService.GetSubjectsByDeptAndSem(department, sem, page)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
 		Status:  "success",
 		Message: "Fetched subjects successfully",
 		Data:    subjects,
+		Meta:    domain.NewPagination(page, total),
 	})
 }
 
@@ -83,19 +75,13 @@ func (h *SubjectHandler) GetSubjectsByFacultyIDHandler(c echo.Context) error {
 	facultyIDFromJWT,ok := c.Get("faculty_id").(int64)
 
 	if !ok {
-		return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Status: "error",
-			Error:  "facultyID is not getting from jwt",
-		})
+		return domain.BadRequest("facultyID is not getting from jwt")
 	}
 
 
 	subjects, err := h.SubjectService.GetSubjectsByFacultyID(facultyIDFromJWT)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to fetch subjects" + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -111,10 +97,7 @@ func (h *SubjectHandler) GetSubjectsByStudentIDHandler(c echo.Context) error {
 	studentIDFromJWT , ok := c.Get("student_id").(int64)
 
 	if !ok {
-		return c.JSON(http.StatusBadRequest,domain.ErrorResponse{
-			Status: "error",
-			Error: "student id is not geting",
-		})
+		return domain.BadRequest("student id is not geting")
 	}
 	// if studentIDParam == "" {
 	// 	return c.JSON(http.StatusBadRequest, domain.ErrorResponse{
@@ -133,10 +116,7 @@ func (h *SubjectHandler) GetSubjectsByStudentIDHandler(c echo.Context) error {
 
 	subjects, err := h.SubjectService.GetSubjectsByStudentID(studentIDFromJWT)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Status: "error",
-			Error:  "Failed to fetch subjects" + err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, domain.SuccessResponse{
@@ -145,3 +125,20 @@ func (h *SubjectHandler) GetSubjectsByStudentIDHandler(c echo.Context) error {
 		Data:    subjects,
 	})
 }
+
+func (h *SubjectHandler) SetPlannedClassesHandler(c echo.Context) error {
+	var req domain.PlannedClassesPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.SubjectService.SetPlannedClasses(c.Param("code"), req.PlannedClasses); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Planned classes updated successfully",
+	})
+}
@@ -0,0 +1,173 @@
+package substitution_handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	substitution_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/substitution"
+)
+
+type SubstitutionHandler struct {
+	SubstitutionService *substitution_service.SubstitutionService
+}
+
+func NewSubstitutionHandler(ss *substitution_service.SubstitutionService) *SubstitutionHandler {
+	return &SubstitutionHandler{
+		SubstitutionService: ss,
+	}
+}
+
+// hod returns the logged in faculty member once they are known to head the
+// department in the path.
+func (h *SubstitutionHandler) hod(c echo.Context) (int64, error) {
+	facultyID := c.Get("faculty_id").(int64)
+	if err := h.SubstitutionService.AuthorizeHOD(facultyID, c.Param("code")); err != nil {
+		return 0, err
+	}
+	return facultyID, nil
+}
+
+func (h *SubstitutionHandler) AssignSubstituteHandler(c echo.Context) error {
+	hodID, err := h.hod(c)
+	if err != nil {
+		return err
+	}
+
+	var req domain.SubstitutionPayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	id, err := h.SubstitutionService.AssignSubstitute(hodID, c.Param("code"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Substitute assigned successfully",
+		Data:    map[string]int64{"substitution_id": id},
+	})
+}
+
+func (h *SubstitutionHandler) RemoveSubstituteHandler(c echo.Context) error {
+	if _, err := h.hod(c); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid substitution id")
+	}
+
+	if err := h.SubstitutionService.RemoveSubstitute(c.Param("code"), id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Substitution removed successfully",
+	})
+}
+
+// GetSubstitutionsHandler lists the department's substitutions over the
+// optional date range.
+func (h *SubstitutionHandler) GetSubstitutionsHandler(c echo.Context) error {
+	if _, err := h.hod(c); err != nil {
+		return err
+	}
+	return h.substitutions(c, domain.SubstitutionQuery{Department: c.Param("code")})
+}
+
+// GetFacultySubstitutionsHandler lists the substitutions the logged in
+// faculty member covers or hands over.
+func (h *SubstitutionHandler) GetFacultySubstitutionsHandler(c echo.Context) error {
+	return h.substitutions(c, domain.SubstitutionQuery{FacultyID: c.Get("faculty_id").(int64)})
+}
+
+func (h *SubstitutionHandler) substitutions(c echo.Context, query domain.SubstitutionQuery) error {
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	query.Filter = filter
+
+	list, err := h.SubstitutionService.GetSubstitutions(query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Substitutions fetched successfully",
+		Data:    list,
+	})
+}
+
+func (h *SubstitutionHandler) RecordSwapHandler(c echo.Context) error {
+	hodID, err := h.hod(c)
+	if err != nil {
+		return err
+	}
+
+	var req domain.ClassSwapPayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	id, err := h.SubstitutionService.RecordSwap(hodID, c.Param("code"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Class swap recorded successfully",
+		Data:    map[string]int64{"swap_id": id},
+	})
+}
+
+func (h *SubstitutionHandler) RemoveSwapHandler(c echo.Context) error {
+	if _, err := h.hod(c); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid swap id")
+	}
+
+	if err := h.SubstitutionService.RemoveSwap(c.Param("code"), id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Class swap removed successfully",
+	})
+}
+
+func (h *SubstitutionHandler) GetSwapsHandler(c echo.Context) error {
+	if _, err := h.hod(c); err != nil {
+		return err
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+
+	list, err := h.SubstitutionService.GetClassSwaps(domain.SubstitutionQuery{Department: c.Param("code"), Filter: filter})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Class swaps fetched successfully",
+		Data:    list,
+	})
+}
@@ -0,0 +1,123 @@
+package teaching_handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	teaching_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/teaching"
+)
+
+type TeachingHandler struct {
+	TeachingService *teaching_service.TeachingService
+}
+
+func NewTeachingHandler(ts *teaching_service.TeachingService) *TeachingHandler {
+	return &TeachingHandler{
+		TeachingService: ts,
+	}
+}
+
+func (h *TeachingHandler) LogSessionHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	var req domain.TeachingSessionPayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	id, err := h.TeachingService.LogSession(facultyID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Teaching session logged successfully",
+		Data:    map[string]int64{"session_id": id},
+	})
+}
+
+func (h *TeachingHandler) UpdateSessionHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid session id")
+	}
+
+	var req domain.TeachingSessionUpdatePayload
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.TeachingService.UpdateSession(facultyID, id, req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Teaching session updated successfully",
+	})
+}
+
+func (h *TeachingHandler) DeleteSessionHandler(c echo.Context) error {
+	facultyID := c.Get("faculty_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid session id")
+	}
+
+	if err := h.TeachingService.DeleteSession(facultyID, id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Teaching session deleted successfully",
+	})
+}
+
+// GetFacultyTeachingLogHandler returns the logged in faculty member's own
+// log, optionally narrowed by ?subjectCode= and the date range.
+func (h *TeachingHandler) GetFacultyTeachingLogHandler(c echo.Context) error {
+	query := domain.TeachingLogQuery{FacultyID: c.Get("faculty_id").(int64)}
+	return h.teachingLog(c, query)
+}
+
+// GetTeachingLogHandler returns the log of every faculty member, or the one
+// given by ?faculty_id=.
+func (h *TeachingHandler) GetTeachingLogHandler(c echo.Context) error {
+	var query domain.TeachingLogQuery
+	if v := c.QueryParam("faculty_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return domain.BadRequest("invalid faculty_id parameter")
+		}
+		query.FacultyID = id
+	}
+	return h.teachingLog(c, query)
+}
+
+func (h *TeachingHandler) teachingLog(c echo.Context, query domain.TeachingLogQuery) error {
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+	query.SubjectCode = c.QueryParam("subjectCode")
+	query.Filter = filter
+
+	report, err := h.TeachingService.GetTeachingLog(query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Teaching log fetched successfully",
+		Data:    report,
+	})
+}
@@ -0,0 +1,52 @@
+package term_handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	term_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/term"
+)
+
+type TermHandler struct {
+	TermService *term_service.TermService
+}
+
+func NewTermHandler(ts *term_service.TermService) *TermHandler {
+	return &TermHandler{
+		TermService: ts,
+	}
+}
+
+func (h *TermHandler) CreateTermHandler(c echo.Context) error {
+	var req domain.TermPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	id, err := h.TermService.CreateTerm(req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Term created successfully",
+		Data:    map[string]int64{"term_id": id},
+	})
+}
+
+func (h *TermHandler) GetTermsHandler(c echo.Context) error {
+	terms, err := h.TermService.GetTerms()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Terms fetched successfully",
+		Data:    terms,
+	})
+}
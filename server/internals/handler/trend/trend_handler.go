@@ -0,0 +1,94 @@
+package trend_handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	trend_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/trend"
+)
+
+type TrendHandler struct {
+	TrendService *trend_service.TrendService
+}
+
+func NewTrendHandler(ts *trend_service.TrendService) *TrendHandler {
+	return &TrendHandler{
+		TrendService: ts,
+	}
+}
+
+func studentUSN(c echo.Context) (string, error) {
+	usn, ok := c.Get("usn").(string)
+	if !ok || usn == "" {
+		return "", domain.BadRequest("Invalid or missing usn in token")
+	}
+	return usn, nil
+}
+
+// GetAttendanceCalendarHandler returns every day of ?month=YYYY-MM with the
+// logged in student's status in each subject.
+func (h *TrendHandler) GetAttendanceCalendarHandler(c echo.Context) error {
+	usn, err := studentUSN(c)
+	if err != nil {
+		return err
+	}
+
+	cal, err := h.TrendService.GetAttendanceCalendar(usn, c.QueryParam("month"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Attendance calendar retrieved successfully",
+		Data:    cal,
+	})
+}
+
+func (h *TrendHandler) GetAttendanceTrendHandler(c echo.Context) error {
+	usn, err := studentUSN(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+
+	trend, err := h.TrendService.GetAttendanceTrend(usn, filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Attendance trend retrieved successfully",
+		Data:    trend,
+	})
+}
+
+func (h *TrendHandler) GetAttendanceProjectionHandler(c echo.Context) error {
+	usn, err := studentUSN(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := params.AttendanceFilter(c)
+	if err != nil {
+		return err
+	}
+
+	projection, err := h.TrendService.GetAttendanceProjection(usn, filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Attendance projection retrieved successfully",
+		Data:    projection,
+	})
+}
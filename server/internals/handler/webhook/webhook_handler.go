@@ -0,0 +1,133 @@
+package webhook_handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/handler/params"
+	webhook_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/webhook"
+)
+
+type WebhookHandler struct {
+	WebhookService *webhook_service.WebhookService
+}
+
+func NewWebhookHandler(ws *webhook_service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		WebhookService: ws,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhookHandler(c echo.Context) error {
+	var req domain.WebhookPayload
+
+	if err := params.Bind(c, &req); err != nil {
+		return err
+	}
+
+	id, secret, err := h.WebhookService.CreateWebhook(req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Webhook created; store the secret now, it is not shown again",
+		Data:    map[string]any{"webhook_id": id, "secret": secret},
+	})
+}
+
+func (h *WebhookHandler) GetWebhooksHandler(c echo.Context) error {
+	hooks, err := h.WebhookService.GetWebhooks()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Webhooks fetched successfully",
+		Data:    hooks,
+	})
+}
+
+func (h *WebhookHandler) DeleteWebhookHandler(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid webhook id")
+	}
+
+	if err := h.WebhookService.DeleteWebhook(id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// GetDeliveriesHandler lists deliveries, optionally narrowed by ?webhook_id=
+// and ?status=pending|delivered|dead (dead being the dead-letter log).
+func (h *WebhookHandler) GetDeliveriesHandler(c echo.Context) error {
+	filter := domain.DeliveryFilter{Status: c.QueryParam("status")}
+	if v := c.QueryParam("webhook_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return domain.BadRequest("invalid webhook_id parameter")
+		}
+		filter.WebhookID = id
+	}
+
+	page, err := params.Page(c)
+	if err != nil {
+		return err
+	}
+
+	deliveries, total, err := h.WebhookService.GetDeliveries(filter, page)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Deliveries fetched successfully",
+		Data:    deliveries,
+		Meta:    domain.NewPagination(page, total),
+	})
+}
+
+func (h *WebhookHandler) GetDeliveryHandler(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid delivery id")
+	}
+
+	delivery, err := h.WebhookService.GetDelivery(id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Delivery fetched successfully",
+		Data:    delivery,
+	})
+}
+
+func (h *WebhookHandler) ReplayDeliveryHandler(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return domain.BadRequest("invalid delivery id")
+	}
+
+	if err := h.WebhookService.ReplayDelivery(id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SuccessResponse{
+		Status:  "success",
+		Message: "Delivery queued for replay",
+	})
+}
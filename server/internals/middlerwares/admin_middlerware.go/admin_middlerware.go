@@ -0,0 +1,48 @@
+package adminmiddlerware
+
+import (
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+type AdminClaims struct {
+	AdminID int64  `json:"admin_id"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func AdminJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authHeader := c.Request().Header.Get("Authorization")
+		if authHeader == "" {
+			return domain.Unauthorized("Missing Authorization header")
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return domain.Unauthorized("Invalid token format")
+		}
+
+		tokenStr := parts[1]
+
+		claims := &AdminClaims{}
+
+		err := utils.ParseToken(tokenStr, claims)
+
+		// Faculty and student tokens share the signing key, so the role claim
+		// is what keeps them out of admin routes.
+		if err != nil || claims.Role != "admin" || claims.AdminID == 0 {
+			return domain.Unauthorized("Invalid or expired token")
+		}
+
+		c.Set("admin_id", claims.AdminID)
+		c.Set("email", claims.Email)
+
+		return next(c)
+	}
+}
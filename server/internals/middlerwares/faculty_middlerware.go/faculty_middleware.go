@@ -1,15 +1,14 @@
 package facultymiddlerware
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
 )
 
-var jwtKey = []byte("KALI_LINUX")
-
 type FacultyClaims struct {
 	FacultyID int64  `json:"faculty_id"`
 	Email     string `json:"email"`
@@ -21,24 +20,24 @@ func FacultyJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		authHeader := c.Request().Header.Get("Authorization")
 		if authHeader == "" {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Missing Authorization header"})
+			return domain.Unauthorized("Missing Authorization header")
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid token format"})
+			return domain.Unauthorized("Invalid token format")
 		}
 
 		tokenStr := parts[1]
 
 		claims := &FacultyClaims{}
 
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-			return jwtKey, nil
-		})
+		err := utils.ParseToken(tokenStr, claims)
 
-		if err != nil || !token.Valid {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid or expired token"})
+		// Student, admin and guardian tokens share the signing key but carry
+		// no faculty_id.
+		if err != nil || claims.FacultyID == 0 {
+			return domain.Unauthorized("Invalid or expired token")
 		}
 
 		c.Set("faculty_id", claims.FacultyID)
@@ -46,4 +45,18 @@ func FacultyJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 
 		return next(c)
 	}
-}
\ No newline at end of file
+}
+
+// FacultyStreamJWTMiddleware also accepts the token as ?access_token=, since
+// EventSource cannot set headers. Query strings end up in access logs and
+// Referer headers, so use it on live feeds only.
+func FacultyStreamJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	authenticate := FacultyJWTMiddleware(next)
+	return func(c echo.Context) error {
+		req := c.Request()
+		if token := c.QueryParam("access_token"); token != "" && req.Header.Get("Authorization") == "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return authenticate(c)
+	}
+}
@@ -0,0 +1,64 @@
+package facultymiddlerware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	facultymiddlerware "github.com/suhas-developer07/Smart-Attendence-System/server/internals/middlerwares/faculty_middlerware.go"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+// authenticate runs FacultyJWTMiddleware with token and returns the faculty
+// id the handler saw.
+func authenticate(t *testing.T, token string) (int64, error) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	var facultyID int64
+	err := facultymiddlerware.FacultyJWTMiddleware(func(c echo.Context) error {
+		facultyID = c.Get("faculty_id").(int64)
+		return nil
+	})(c)
+	return facultyID, err
+}
+
+func TestFacultyTokenAccepted(t *testing.T) {
+	utils.ConfigureJWT("test-secret", "test", time.Hour)
+	token, err := utils.GenerateTokenForFaculty(7, "ravi@college.edu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := authenticate(t, token)
+	if err != nil || id != 7 {
+		t.Fatalf("faculty token = (%d, %v), want (7, nil)", id, err)
+	}
+}
+
+func TestAdminTokenRejected(t *testing.T) {
+	utils.ConfigureJWT("test-secret", "test", time.Hour)
+	token, err := utils.GenerateTokenForAdmin(1, "admin@college.edu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authenticate(t, token); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("admin token = %v, want unauthorized", err)
+	}
+}
+
+func TestGuardianTokenRejected(t *testing.T) {
+	utils.ConfigureJWT("test-secret", "test", time.Hour)
+	token, err := utils.GenerateTokenForGuardian(1, "parent@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authenticate(t, token); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("guardian token = %v, want unauthorized", err)
+	}
+}
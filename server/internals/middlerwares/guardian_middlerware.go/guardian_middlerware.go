@@ -0,0 +1,46 @@
+package guardianmiddlerware
+
+import (
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+type GuardianClaims struct {
+	GuardianID int64  `json:"guardian_id"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func GuardianJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authHeader := c.Request().Header.Get("Authorization")
+		if authHeader == "" {
+			return domain.Unauthorized("Missing Authorization header")
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return domain.Unauthorized("Invalid token format")
+		}
+
+		tokenStr := parts[1]
+
+		claims := &GuardianClaims{}
+
+		err := utils.ParseToken(tokenStr, claims)
+
+		if err != nil || claims.Role != "guardian" || claims.GuardianID == 0 {
+			return domain.Unauthorized("Invalid or expired token")
+		}
+
+		c.Set("guardian_id", claims.GuardianID)
+		c.Set("email", claims.Email)
+
+		return next(c)
+	}
+}
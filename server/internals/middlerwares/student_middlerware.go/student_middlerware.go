@@ -2,15 +2,14 @@ package studentmiddlerwarego
 
 import (
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
 )
 
-var jwtKey = []byte("KALI_LINUX")
-
 type Claims struct {
 	StudentID int64  `json:"student_id"`
 	USN       string `json:"usn"`
@@ -21,24 +20,23 @@ func JWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		
 		authHeader := c.Request().Header.Get("Authorization")
 		if authHeader == "" {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Missing Authorization header"})
+			return domain.Unauthorized("Missing Authorization header")
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid token format"})
+			return domain.Unauthorized("Invalid token format")
 		}
 
 		tokenStr := parts[1]
 
 		claims := &Claims{}
 
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-			return jwtKey, nil
-		})
+		err := utils.ParseToken(tokenStr, claims)
 
-		if err != nil || !token.Valid {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid or expired token"})
+		// Guardian tokens share the signing key but carry no usn.
+		if err != nil || claims.USN == "" {
+			return domain.Unauthorized("Invalid or expired token")
 		}
 
 		fmt.Println("Authenticated Student ID:", claims.StudentID)
@@ -0,0 +1,348 @@
+// Package openapi builds the OpenAPI 3 document of the HTTP API from a route
+// table and the domain types, serves it with a Swagger UI page and validates
+// incoming requests against it.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+	Tags       []Tag                `json:"tags,omitempty"`
+
+	// operations is keyed by method and Echo path, e.g. "GET /attendance/:id".
+	operations map[string]*Operation
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem holds the operations of one path keyed by lower case method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// Route describes one endpoint registered in cmd.SetupRoutes.
+type Route struct {
+	Method  string
+	Path    string // Echo path, e.g. /attendance/:id/status
+	Tag     string
+	Summary string
+	// Auth names the security scheme guarding the route; empty is public.
+	Auth string
+	// Params lists query parameters and types path parameters; path
+	// parameters not listed are plain strings.
+	Params []Param
+	// Body is a zero value of the JSON request body, nil for none.
+	Body any
+	// Upload names the form field of a multipart file upload.
+	Upload string
+	// Data is a zero value of SuccessResponse.Data, nil when it is omitted.
+	Data any
+	// Paged routes return a meta block alongside data.
+	Paged bool
+	// Produces replaces the JSON envelope for file downloads and streams.
+	Produces []string
+}
+
+// Param is a query or path parameter. Type is an OpenAPI primitive type
+// (string, integer, number or boolean) and defaults to string.
+type Param struct {
+	Name        string
+	In          string // query (default) or path
+	Type        string
+	Format      string // date (YYYY-MM-DD) or time (HH:MM) for strings
+	Enum        []string
+	Required    bool
+	Description string
+}
+
+// New builds a document for info with the given bearer token schemes, keyed
+// by name with a description of who holds them.
+func New(info Info, schemes map[string]string) *Document {
+	d := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas:         map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{},
+		},
+		operations: map[string]*Operation{},
+	}
+	for name, desc := range schemes {
+		d.Components.SecuritySchemes[name] = SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: desc}
+	}
+	d.SchemaOf(domain.ErrorResponse{})
+	d.SchemaOf(domain.Pagination{})
+	return d
+}
+
+// Add documents the routes; adding a method and path twice panics since it
+// means the route table has a copy-paste mistake.
+func (d *Document) Add(routes ...Route) {
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		if _, dup := d.operations[key]; dup {
+			panic("openapi: route documented twice: " + key)
+		}
+		op := d.operation(r)
+		d.operations[key] = op
+
+		path := openAPIPath(r.Path)
+		item := d.Paths[path]
+		if item == nil {
+			item = &PathItem{}
+			d.Paths[path] = item
+		}
+		(*item)[strings.ToLower(r.Method)] = op
+		d.addTag(r.Tag)
+	}
+}
+
+func (d *Document) addTag(name string) {
+	if name == "" {
+		return
+	}
+	for _, t := range d.Tags {
+		if t.Name == name {
+			return
+		}
+	}
+	d.Tags = append(d.Tags, Tag{Name: name})
+}
+
+// Operation returns the operation documented for method and Echo path.
+func (d *Document) Operation(method, path string) (*Operation, bool) {
+	op, ok := d.operations[method+" "+path]
+	return op, ok
+}
+
+// Undocumented lists the registered routes the document does not describe.
+func (d *Document) Undocumented(routes []*echo.Route) []string {
+	var missing []string
+	for _, r := range routes {
+		if r.Method == echo.RouteNotFound {
+			continue
+		}
+		if _, ok := d.operations[r.Method+" "+r.Path]; !ok {
+			missing = append(missing, r.Method+" "+r.Path)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func openAPIPath(echoPath string) string {
+	return pathParam.ReplaceAllString(echoPath, "{$1}")
+}
+
+func (d *Document) operation(r Route) *Operation {
+	op := &Operation{
+		Summary:     r.Summary,
+		OperationID: operationID(r.Method, r.Path),
+		Responses:   map[string]Response{},
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	if r.Auth != "" {
+		op.Security = []map[string][]string{{r.Auth: {}}}
+	}
+
+	typed := map[string]Param{}
+	for _, p := range r.Params {
+		if p.In == "path" {
+			typed[p.Name] = p
+		}
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(r.Path, -1) {
+		p, ok := typed[m[1]]
+		if !ok {
+			p = Param{Name: m[1]}
+		}
+		p.In, p.Required = "path", true
+		op.Parameters = append(op.Parameters, p.parameter())
+	}
+	for _, p := range r.Params {
+		if p.In != "path" {
+			p.In = "query"
+			op.Parameters = append(op.Parameters, p.parameter())
+		}
+	}
+
+	switch {
+	case r.Body != nil:
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+			echo.MIMEApplicationJSON: {Schema: d.SchemaOf(r.Body)},
+		}}
+	case r.Upload != "":
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+			echo.MIMEMultipartForm: {Schema: &Schema{
+				Type:       "object",
+				Required:   []string{r.Upload},
+				Properties: map[string]*Schema{r.Upload: {Type: "string", Format: "binary"}},
+			}},
+		}}
+	}
+
+	if len(r.Produces) > 0 {
+		content := map[string]MediaType{}
+		for _, mime := range r.Produces {
+			content[mime] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+		op.Responses["200"] = Response{Description: "OK", Content: content}
+	} else {
+		op.Responses["200"] = Response{Description: "OK", Content: jsonContent(d.envelope(r))}
+	}
+
+	errorBody := jsonContent(&Schema{Ref: "#/components/schemas/ErrorResponse"})
+	if len(op.Parameters) > 0 || op.RequestBody != nil {
+		op.Responses["400"] = Response{Description: "Invalid request", Content: errorBody}
+	}
+	if r.Auth != "" {
+		op.Responses["401"] = Response{Description: "Missing, invalid or expired token", Content: errorBody}
+	}
+	op.Responses["500"] = Response{Description: "Internal error", Content: errorBody}
+	op.Responses["default"] = Response{Description: "Error; code is forbidden, not_found or conflict", Content: errorBody}
+	return op
+}
+
+// envelope is the domain.SuccessResponse wrapper around r.Data.
+func (d *Document) envelope(r Route) *Schema {
+	s := &Schema{
+		Type:     "object",
+		Required: []string{"status", "message"},
+		Properties: map[string]*Schema{
+			"status":  {Type: "string", Enum: []any{"success"}},
+			"message": {Type: "string"},
+		},
+	}
+	if r.Data != nil {
+		s.Properties["data"] = d.SchemaOf(r.Data)
+	}
+	if r.Paged {
+		s.Properties["meta"] = &Schema{Ref: "#/components/schemas/Pagination"}
+	}
+	return s
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{echo.MIMEApplicationJSON: {Schema: s}}
+}
+
+func (p Param) parameter() Parameter {
+	s := &Schema{Type: p.Type, Format: p.Format}
+	if s.Type == "" {
+		s.Type = "string"
+	}
+	for _, v := range p.Enum {
+		s.Enum = append(s.Enum, v)
+	}
+	return Parameter{Name: p.Name, In: p.In, Description: p.Description, Required: p.Required, Schema: s}
+}
+
+// operationID turns "GET /attendance/:id/status" into getAttendanceIdStatus.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == ':' || r == '-' || r == '_'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// JSONHandler serves the document.
+func (d *Document) JSONHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, d)
+}
+
+// UIHandler serves a Swagger UI page for the document at specURL.
+func UIHandler(specURL string) echo.HandlerFunc {
+	page := fmt.Sprintf(swaggerUI, specURL)
+	return func(c echo.Context) error {
+		return c.HTML(http.StatusOK, page)
+	}
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Smart Attendance API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
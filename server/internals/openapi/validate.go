@@ -0,0 +1,239 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// Validator rejects requests whose parameters or JSON body do not match the
+// documented operation with 400. Routes missing from the document pass
+// through untouched. Register it with e.Use so it runs after routing.
+func (d *Document) Validator() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			op, ok := d.Operation(c.Request().Method, c.Path())
+			if !ok {
+				return next(c)
+			}
+			if err := d.validate(c, op); err != nil {
+				return domain.BadRequest("invalid request: %v", err)
+			}
+			return next(c)
+		}
+	}
+}
+
+func (d *Document) validate(c echo.Context, op *Operation) error {
+	for _, p := range op.Parameters {
+		var value string
+		if p.In == "path" {
+			value = c.Param(p.Name)
+		} else {
+			value = c.QueryParam(p.Name)
+		}
+		if value == "" {
+			if p.Required {
+				return fmt.Errorf("%s parameter %s is required", p.In, p.Name)
+			}
+			continue
+		}
+		if err := checkParam(p.Schema, value); err != nil {
+			return fmt.Errorf("%s parameter %s %w", p.In, p.Name, err)
+		}
+	}
+
+	if op.RequestBody == nil {
+		return nil
+	}
+	media, ok := op.RequestBody.Content[echo.MIMEApplicationJSON]
+	if !ok {
+		return nil
+	}
+	return d.validateBody(c.Request(), media.Schema)
+}
+
+// validateBody decodes the body against s and puts it back for the handler.
+func (d *Document) validateBody(req *http.Request, s *Schema) error {
+	if req.Body == nil {
+		return fmt.Errorf("request body is required")
+	}
+	raw, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(raw))
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return fmt.Errorf("request body is required")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var body any
+	if err := dec.Decode(&body); err != nil {
+		return fmt.Errorf("body is not valid JSON: %w", err)
+	}
+	return d.check(s, body, "body")
+}
+
+func checkParam(s *Schema, value string) error {
+	switch s.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("must be a number")
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+	}
+	switch s.Format {
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("must be a date in YYYY-MM-DD format")
+		}
+	case "time":
+		if _, err := time.Parse("15:04", value); err != nil {
+			return fmt.Errorf("must be a time in HH:MM format")
+		}
+	}
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, any(value)) {
+		return fmt.Errorf("must be one of %s", enumList(s.Enum))
+	}
+	return nil
+}
+
+// check validates a decoded JSON value against s; at names the value in
+// error messages, e.g. body.usns[2].
+func (d *Document) check(s *Schema, v any, at string) error {
+	if s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	if v == nil {
+		if s.Type != "" && !s.Nullable {
+			return fmt.Errorf("%s must not be null", at)
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s must be an object", at)
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s.%s is required", at, name)
+			}
+		}
+		for name, prop := range s.Properties {
+			if pv, ok := obj[name]; ok {
+				if err := d.check(prop, pv, at+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+		if s.AdditionalProperties != nil {
+			for name, pv := range obj {
+				if err := d.check(s.AdditionalProperties, pv, at+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s must be an array", at)
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			return fmt.Errorf("%s must have at least %d items", at, *s.MinItems)
+		}
+		for i, item := range arr {
+			if err := d.check(s.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", at)
+		}
+		return checkString(s, str, at)
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s must be an integer", at)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return fmt.Errorf("%s must be an integer", at)
+		}
+		return checkRange(s, float64(i), at)
+	case "number":
+		n, ok := v.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s must be a number", at)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return fmt.Errorf("%s must be a number", at)
+		}
+		return checkRange(s, f, at)
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s must be true or false", at)
+		}
+	}
+	return nil
+}
+
+func checkString(s *Schema, str, at string) error {
+	if s.Format == "date-time" {
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			return fmt.Errorf("%s must be an RFC 3339 timestamp", at)
+		}
+	}
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, any(str)) {
+		return fmt.Errorf("%s must be one of %s", at, enumList(s.Enum))
+	}
+	if s.MinLength != nil && len(str) < *s.MinLength {
+		return fmt.Errorf("%s must be at least %d characters", at, *s.MinLength)
+	}
+	if s.MaxLength != nil && len(str) > *s.MaxLength {
+		return fmt.Errorf("%s must be at most %d characters", at, *s.MaxLength)
+	}
+	return nil
+}
+
+func checkRange(s *Schema, n float64, at string) error {
+	if s.Minimum != nil && n < *s.Minimum {
+		return fmt.Errorf("%s must be at least %v", at, *s.Minimum)
+	}
+	if s.Maximum != nil && n > *s.Maximum {
+		return fmt.Errorf("%s must be at most %v", at, *s.Maximum)
+	}
+	return nil
+}
+
+func enumList(enum []any) string {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		values[i] = fmt.Sprint(v)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,133 @@
+// Package realtime fans attendance events out to live subscribers.
+package realtime
+
+import (
+	"log"
+	"sync"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// subscriberBuffer bounds how far a slow client may fall behind before
+// events to it are dropped.
+const subscriberBuffer = 64
+
+// Forwarder relays locally published events to other replicas, e.g. through
+// a RabbitMQ fanout exchange. Replicas hand what they receive to Deliver.
+type Forwarder interface {
+	Forward(event domain.AttendanceEvent) error
+}
+
+// Hub is an in-process pub/sub keyed by subject code. It implements
+// domain.EventPublisher.
+type Hub struct {
+	mu        sync.RWMutex
+	topics    map[string]map[*Subscription]struct{}
+	forwarder Forwarder
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{topics: map[string]map[*Subscription]struct{}{}, closed: make(chan struct{})}
+}
+
+// Close ends every subscription's Done channel so long-lived streams return
+// and let the HTTP server drain.
+func (h *Hub) Close() {
+	h.closeOnce.Do(func() { close(h.closed) })
+}
+
+// SetForwarder makes Publish also relay events beyond this process.
+func (h *Hub) SetForwarder(f Forwarder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.forwarder = f
+}
+
+// Publish delivers the event to local subscribers and to the forwarder.
+func (h *Hub) Publish(event domain.AttendanceEvent) {
+	h.Deliver(event)
+
+	h.mu.RLock()
+	f := h.forwarder
+	h.mu.RUnlock()
+	if f != nil {
+		if err := f.Forward(event); err != nil {
+			log.Printf("realtime: forward %s: %v", event.Type, err)
+		}
+	}
+}
+
+// Deliver hands the event to local subscribers only.
+func (h *Hub) Deliver(event domain.AttendanceEvent) {
+	// Captures outside every logged session belong to no feed until they
+	// are assigned.
+	topic := event.SubjectCode
+	if topic == "" {
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.topics[topic] {
+		select {
+		case sub.events <- event:
+		default:
+			// Never let one stalled client hold up attendance writes.
+			log.Printf("realtime: dropping %s for slow subscriber on %s", event.Type, topic)
+		}
+	}
+}
+
+// Subscribe registers for events on the given topics. Close the subscription
+// when done.
+func (h *Hub) Subscribe(topics ...string) *Subscription {
+	sub := &Subscription{hub: h, topics: topics, events: make(chan domain.AttendanceEvent, subscriberBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, t := range topics {
+		if h.topics[t] == nil {
+			h.topics[t] = map[*Subscription]struct{}{}
+		}
+		h.topics[t][sub] = struct{}{}
+	}
+	return sub
+}
+
+// Subscribers reports how many subscriptions are open on topic.
+func (h *Hub) Subscribers(topic string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.topics[topic])
+}
+
+type Subscription struct {
+	hub    *Hub
+	topics []string
+	events chan domain.AttendanceEvent
+	once   sync.Once
+}
+
+func (s *Subscription) Events() <-chan domain.AttendanceEvent {
+	return s.events
+}
+
+// Done is closed when the hub shuts down.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.hub.closed
+}
+
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+		for _, t := range s.topics {
+			delete(s.hub.topics[t], s)
+			if len(s.hub.topics[t]) == 0 {
+				delete(s.hub.topics, t)
+			}
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package realtime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/realtime"
+)
+
+// forwarder records the events the hub relays to other replicas.
+type forwarder struct {
+	events []domain.AttendanceEvent
+}
+
+func (f *forwarder) Forward(event domain.AttendanceEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+// receive waits briefly for the next event, reporting false when none comes.
+func receive(sub *realtime.Subscription) (domain.AttendanceEvent, bool) {
+	select {
+	case event := <-sub.Events():
+		return event, true
+	case <-time.After(50 * time.Millisecond):
+		return domain.AttendanceEvent{}, false
+	}
+}
+
+func TestSubscribersReceiveTheirSubjectOnly(t *testing.T) {
+	hub := realtime.NewHub()
+	fwd := &forwarder{}
+	hub.SetForwarder(fwd)
+
+	compilers := hub.Subscribe("CS501")
+	defer compilers.Close()
+	networks := hub.Subscribe("CS502")
+	defer networks.Close()
+
+	hub.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceRecorded, USN: "1RV21CS001", SubjectCode: "CS501"})
+	hub.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceRecorded, USN: "1RV21CS002"})
+
+	event, ok := receive(compilers)
+	if !ok || event.USN != "1RV21CS001" {
+		t.Fatalf("CS501 subscriber got %+v, %v", event, ok)
+	}
+	if event, ok := receive(compilers); ok {
+		t.Errorf("CS501 subscriber also got %+v, want nothing for a capture outside every session", event)
+	}
+	if event, ok := receive(networks); ok {
+		t.Errorf("CS502 subscriber got %+v", event)
+	}
+	if len(fwd.events) != 2 {
+		t.Errorf("forwarded %d events, want both", len(fwd.events))
+	}
+
+	// Deliver is for events from other replicas and is not forwarded again.
+	hub.Deliver(domain.AttendanceEvent{Type: domain.EventAttendanceAssigned, SubjectCode: "CS502", UpdatedCount: 3})
+	if event, ok := receive(networks); !ok || event.UpdatedCount != 3 {
+		t.Errorf("CS502 subscriber got %+v, %v", event, ok)
+	}
+	if len(fwd.events) != 2 {
+		t.Errorf("forwarded %d events after Deliver, want 2", len(fwd.events))
+	}
+}
+
+func TestCloseUnsubscribes(t *testing.T) {
+	hub := realtime.NewHub()
+	first, second := hub.Subscribe("CS501"), hub.Subscribe("CS501")
+	if n := hub.Subscribers("CS501"); n != 2 {
+		t.Fatalf("subscribers = %d, want 2", n)
+	}
+
+	first.Close()
+	first.Close()
+	if n := hub.Subscribers("CS501"); n != 1 {
+		t.Fatalf("subscribers after one close = %d, want 1", n)
+	}
+	hub.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceRecorded, SubjectCode: "CS501"})
+	if event, ok := receive(first); ok {
+		t.Errorf("closed subscription got %+v", event)
+	}
+	if _, ok := receive(second); !ok {
+		t.Error("open subscription got nothing")
+	}
+
+	second.Close()
+	if n := hub.Subscribers("CS501"); n != 0 {
+		t.Errorf("subscribers after both closed = %d, want 0", n)
+	}
+}
+
+// A subscriber that stops reading loses events instead of blocking Publish.
+func TestSlowSubscriberDoesNotBlockPublish(t *testing.T) {
+	hub := realtime.NewHub()
+	sub := hub.Subscribe("CS501")
+	defer sub.Close()
+
+	done := make(chan struct{})
+	go func() {
+		for range 200 {
+			hub.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceRecorded, SubjectCode: "CS501"})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a subscriber that is not reading")
+	}
+	if n := len(sub.Events()); n == 0 || n >= 200 {
+		t.Errorf("buffered %d events, want some dropped", n)
+	}
+}
+
+func TestHubCloseEndsSubscriptions(t *testing.T) {
+	hub := realtime.NewHub()
+	sub := hub.Subscribe("CS501")
+	defer sub.Close()
+
+	hub.Close()
+	hub.Close()
+	select {
+	case <-sub.Done():
+	default:
+		t.Fatal("Done is still open after the hub closed")
+	}
+}
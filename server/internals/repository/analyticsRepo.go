@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// GetDepartmentAnalytics runs one aggregate query per section of the
+// dashboard; none of them returns more than a row per sem, subject, week,
+// faculty member or absentee.
+func (p *SQLRepo) GetDepartmentAnalytics(query domain.AnalyticsQuery) (domain.DepartmentAnalytics, error) {
+	a := domain.DepartmentAnalytics{Department: query.Department}
+	var err error
+	if a.Semesters, err = p.semesterAttendance(query); err != nil {
+		return a, err
+	}
+	if a.Subjects, err = p.subjectAttendance(query); err != nil {
+		return a, err
+	}
+	if a.Weekly, err = p.weeklyAttendance(query); err != nil {
+		return a, err
+	}
+	if a.Faculty, err = p.facultySessions(query); err != nil {
+		return a, err
+	}
+	if a.TopAbsentees, err = p.topAbsentees(query); err != nil {
+		return a, err
+	}
+	if a.Unassigned, err = p.unassignedAttendance(query); err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+func (p *SQLRepo) semesterAttendance(query domain.AnalyticsQuery) ([]domain.SemesterAttendance, error) {
+	cond, args := dateRange("a.date", query.Filter, []any{query.Department})
+	rows, err := p.db.Query(`
+	SELECT sub.sem, COUNT(DISTINCT sub.subject_id), COUNT(DISTINCT a.usn),
+	       COALESCE(SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END), 0),
+	       COUNT(a.attendance_id)
+	FROM subjects sub
+	LEFT JOIN attendance a ON a.subject_id = sub.subject_id`+cond+`
+	WHERE sub.department = $1
+	GROUP BY sub.sem
+	ORDER BY sub.sem;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("semester attendance: %w", err)
+	}
+	defer rows.Close()
+
+	list := []domain.SemesterAttendance{}
+	for rows.Next() {
+		var s domain.SemesterAttendance
+		if err := rows.Scan(&s.Sem, &s.Subjects, &s.Students, &s.Present, &s.Total); err != nil {
+			return nil, fmt.Errorf("scan semester attendance: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) subjectAttendance(query domain.AnalyticsQuery) ([]domain.SubjectAttendance, error) {
+	cond, args := dateRange("a.date", query.Filter, []any{query.Department})
+	logged, args := loggedSessions(query.Filter, args)
+	rows, err := p.db.Query(`
+	WITH`+logged+`
+	SELECT sub.subject_code, sub.subject_name, sub.sem, f.faculty_name,
+	       COALESCE(MAX(lc.classes), COUNT(DISTINCT a.date)),
+	       COALESCE(SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END), 0),
+	       COUNT(a.attendance_id)
+	FROM subjects sub
+	JOIN faculty f ON f.faculty_id = sub.faculty_id
+	LEFT JOIN attendance a ON a.subject_id = sub.subject_id`+cond+`
+	LEFT JOIN logged lc ON lc.subject_id = sub.subject_id
+	WHERE sub.department = $1
+	GROUP BY sub.subject_id, sub.subject_code, sub.subject_name, sub.sem, f.faculty_name
+	ORDER BY sub.sem, sub.subject_code;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("subject attendance: %w", err)
+	}
+	defer rows.Close()
+
+	list := []domain.SubjectAttendance{}
+	for rows.Next() {
+		var s domain.SubjectAttendance
+		if err := rows.Scan(&s.SubjectCode, &s.SubjectName, &s.Sem, &s.FacultyName, &s.ClassesHeld, &s.Present, &s.Total); err != nil {
+			return nil, fmt.Errorf("scan subject attendance: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) weeklyAttendance(query domain.AnalyticsQuery) ([]domain.WeeklyAttendance, error) {
+	cond, args := dateRange("a.date", query.Filter, []any{query.Department})
+	rows, err := p.db.Query(`
+	SELECT `+p.dialect.WeekStart("a.date")+` AS week_start,
+	       SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END),
+	       COUNT(*)
+	FROM attendance a
+	JOIN subjects sub ON sub.subject_id = a.subject_id
+	WHERE sub.department = $1`+cond+`
+	GROUP BY week_start
+	ORDER BY week_start;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("weekly attendance: %w", err)
+	}
+	defer rows.Close()
+
+	list := []domain.WeeklyAttendance{}
+	for rows.Next() {
+		var w domain.WeeklyAttendance
+		var week string
+		if err := rows.Scan(&week, &w.Present, &w.Total); err != nil {
+			return nil, fmt.Errorf("scan weekly attendance: %w", err)
+		}
+		if w.WeekStart, err = time.Parse("2006-01-02", week); err != nil {
+			return nil, fmt.Errorf("parse week start %q: %w", week, err)
+		}
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) facultySessions(query domain.AnalyticsQuery) ([]domain.FacultySessions, error) {
+	cond, args := dateRange("date", query.Filter, []any{query.Department})
+	logged, args := loggedSessions(query.Filter, args)
+	rows, err := p.db.Query(`
+	WITH sessions AS (
+	    SELECT subject_id, COUNT(DISTINCT date) AS held
+	    FROM attendance
+	    WHERE subject_id IS NOT NULL`+cond+`
+	    GROUP BY subject_id
+	),`+logged+`
+	SELECT f.faculty_id, f.faculty_name, COUNT(sub.subject_id),
+	       COALESCE(SUM(COALESCE(lc.classes, s.held)), 0) AS sessions
+	FROM subjects sub
+	JOIN faculty f ON f.faculty_id = sub.faculty_id
+	LEFT JOIN sessions s ON s.subject_id = sub.subject_id
+	LEFT JOIN logged lc ON lc.subject_id = sub.subject_id
+	WHERE sub.department = $1
+	GROUP BY f.faculty_id, f.faculty_name
+	ORDER BY sessions DESC, f.faculty_name;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("faculty sessions: %w", err)
+	}
+	defer rows.Close()
+
+	list := []domain.FacultySessions{}
+	for rows.Next() {
+		var f domain.FacultySessions
+		if err := rows.Scan(&f.FacultyID, &f.FacultyName, &f.Subjects, &f.Sessions); err != nil {
+			return nil, fmt.Errorf("scan faculty sessions: %w", err)
+		}
+		list = append(list, f)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) topAbsentees(query domain.AnalyticsQuery) ([]domain.AbsenteeStat, error) {
+	cond, args := dateRange("a.date", query.Filter, []any{query.Department, query.TopAbsentees})
+	rows, err := p.db.Query(`
+	SELECT st.usn, st.username, st.sem,
+	       SUM(CASE WHEN a.status = 'Absent' THEN 1 ELSE 0 END) AS absent,
+	       COUNT(*)
+	FROM attendance a
+	JOIN subjects sub ON sub.subject_id = a.subject_id
+	JOIN students st ON st.usn = a.usn
+	WHERE sub.department = $1`+cond+`
+	GROUP BY st.usn, st.username, st.sem
+	HAVING SUM(CASE WHEN a.status = 'Absent' THEN 1 ELSE 0 END) > 0
+	ORDER BY absent DESC, st.usn
+	LIMIT $2;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("top absentees: %w", err)
+	}
+	defer rows.Close()
+
+	list := []domain.AbsenteeStat{}
+	for rows.Next() {
+		var s domain.AbsenteeStat
+		if err := rows.Scan(&s.USN, &s.StudentName, &s.Sem, &s.Absent, &s.Total); err != nil {
+			return nil, fmt.Errorf("scan absentee: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) unassignedAttendance(query domain.AnalyticsQuery) ([]domain.UnassignedAttendance, error) {
+	cond, args := dateRange("a.date", query.Filter, []any{query.Department})
+	rows, err := p.db.Query(`
+	SELECT st.sem, a.date, COUNT(*)
+	FROM attendance a
+	JOIN students st ON st.usn = a.usn
+	WHERE a.subject_id IS NULL AND st.department = $1`+cond+`
+	GROUP BY st.sem, a.date
+	ORDER BY a.date, st.sem;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unassigned attendance: %w", err)
+	}
+	defer rows.Close()
+
+	list := []domain.UnassignedAttendance{}
+	for rows.Next() {
+		var u domain.UnassignedAttendance
+		if err := rows.Scan(&u.Sem, &u.Date, &u.Rows); err != nil {
+			return nil, fmt.Errorf("scan unassigned attendance: %w", err)
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// eventColumns are the recognition_events columns eventFields scans, with
+// the table aliased as e.
+const eventColumns = `e.event_id, e.usn, e.status, e.room, e.confidence, e.recorded_at,
+	       e.device_id, e.bbox_x, e.bbox_y, e.bbox_width, e.bbox_height, e.face_crop`
+
+// eventFields receives eventColumns; the nullable evidence columns land in
+// box and crop until done copies them into the event.
+type eventFields struct {
+	box  [4]*int
+	crop sql.NullString
+}
+
+func (f *eventFields) dest(e *domain.RecognitionEvent) []any {
+	return []any{&e.ID, &e.USN, &e.Status, &e.Room, &e.Confidence, &e.RecordedAt,
+		&e.DeviceID, &f.box[0], &f.box[1], &f.box[2], &f.box[3], &f.crop}
+}
+
+func (f *eventFields) done(e *domain.RecognitionEvent) {
+	if f.box[0] != nil && f.box[1] != nil && f.box[2] != nil && f.box[3] != nil {
+		e.BoundingBox = &domain.BoundingBox{X: *f.box[0], Y: *f.box[1], Width: *f.box[2], Height: *f.box[3]}
+	}
+	e.FaceCropKey = f.crop.String
+}
+
+// boundingBoxArgs spreads b over the bbox_* columns, all NULL without one.
+func boundingBoxArgs(b *domain.BoundingBox) []any {
+	if b == nil {
+		return []any{nil, nil, nil, nil}
+	}
+	return []any{b.X, b.Y, b.Width, b.Height}
+}
+
+func (p *SQLRepo) RecordRecognitionEvent(e domain.RecognitionEvent) (int64, error) {
+	args := append([]any{e.USN, e.Status, p.cal.DateOf(e.RecordedAt).Format("2006-01-02"), e.Room, e.Confidence, p.dialect.Stamp(e.RecordedAt),
+		e.DeviceID, sql.NullString{String: e.FaceCropKey, Valid: e.FaceCropKey != ""}}, boundingBoxArgs(e.BoundingBox)...)
+
+	var id int64
+	err := p.db.QueryRow(`
+	INSERT INTO recognition_events (usn, status, date, room, confidence, recorded_at,
+	                                device_id, face_crop, bbox_x, bbox_y, bbox_width, bbox_height)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING event_id;`, args...).Scan(&id)
+	if err != nil {
+		if p.isForeignKeyViolation(err) {
+			return 0, domain.NotFound("student not found for usn: %s", e.USN).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert recognition event: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) GetRecognitionEvents(usn string, from, to time.Time) ([]domain.RecognitionEvent, error) {
+	rows, err := p.db.Query(`
+	SELECT `+eventColumns+`
+	FROM recognition_events e
+	WHERE e.usn = $1 AND e.recorded_at BETWEEN $2 AND $3
+	ORDER BY e.recorded_at, e.event_id;`, usn, p.dialect.Stamp(from), p.dialect.Stamp(to))
+	if err != nil {
+		return nil, fmt.Errorf("get recognition events: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.RecognitionEvent
+	for rows.Next() {
+		var e domain.RecognitionEvent
+		var fields eventFields
+		if err := rows.Scan(fields.dest(&e)...); err != nil {
+			return nil, fmt.Errorf("scan recognition event: %w", err)
+		}
+		fields.done(&e)
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) GetStudentSessions(usn string, date time.Time) ([]domain.TeachingSession, error) {
+	rows, err := p.db.Query(`
+	SELECT ts.session_id, sub.subject_code, sub.subject_name, ts.faculty_id, ts.date,
+	       ts.start_time, ts.duration_minutes, ts.topic
+	FROM teaching_sessions ts
+	JOIN subjects sub ON sub.subject_id = ts.subject_id
+	JOIN students st ON st.department = sub.department AND st.sem = sub.sem
+	WHERE st.usn = $1 AND ts.date = $2
+	ORDER BY ts.start_time;`, usn, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("get student sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.TeachingSession
+	for rows.Next() {
+		var s domain.TeachingSession
+		if err := rows.Scan(&s.ID, &s.SubjectCode, &s.SubjectName, &s.FacultyID, &s.Date,
+			&s.StartTime, &s.DurationMinutes, &s.Topic); err != nil {
+			return nil, fmt.Errorf("scan teaching session: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) CreateAnomalyFlag(eventID int64, reasons []string, detail string) (int64, error) {
+	var id int64
+	err := p.db.QueryRow(`
+	INSERT INTO attendance_flags (event_id, reasons, detail)
+	VALUES ($1, $2, $3)
+	RETURNING flag_id;`, eventID, strings.Join(reasons, ","), detail).Scan(&id)
+	if err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("recognition event %d is already flagged", eventID).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert anomaly flag: %w", err)
+	}
+	return id, nil
+}
+
+const anomalyFlagSelect = `
+	SELECT ` + eventColumns + `, f.flag_id,
+	       st.username, st.department, f.reasons, f.detail, f.review,
+	       f.reviewed_by, f.reviewed_at, f.attendance_id, f.created_at
+	FROM attendance_flags f
+	JOIN recognition_events e ON e.event_id = f.event_id
+	JOIN students st ON st.usn = e.usn`
+
+func scanAnomalyFlag(row interface{ Scan(...any) error }) (domain.AnomalyFlag, error) {
+	var f domain.AnomalyFlag
+	var fields eventFields
+	var reasons string
+	err := row.Scan(append(fields.dest(&f.Event), &f.ID,
+		&f.StudentName, &f.Department, &reasons, &f.Detail, &f.Review,
+		&f.ReviewedBy, &f.ReviewedAt, &f.AttendanceID, &f.CreatedAt)...)
+	fields.done(&f.Event)
+	f.Reasons = strings.Split(reasons, ",")
+	return f, err
+}
+
+func (p *SQLRepo) GetAnomalyFlags(query domain.FlagQuery) ([]domain.AnomalyFlag, error) {
+	cond, args := dateRange("e.date", query.Filter, []any{query.Department, query.Review})
+	rows, err := p.db.Query(anomalyFlagSelect+`
+	WHERE (st.department = $1 OR $1 = '')
+	  AND (f.review = $2 OR $2 = '')`+cond+`
+	ORDER BY e.recorded_at DESC, f.flag_id DESC;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get anomaly flags: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.AnomalyFlag
+	for rows.Next() {
+		f, err := scanAnomalyFlag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan anomaly flag: %w", err)
+		}
+		list = append(list, f)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) GetAnomalyFlag(id int64) (domain.AnomalyFlag, error) {
+	f, err := scanAnomalyFlag(p.db.QueryRow(anomalyFlagSelect+`
+	WHERE f.flag_id = $1;`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.AnomalyFlag{}, domain.NotFound("flag not found: %d", id)
+		}
+		return domain.AnomalyFlag{}, fmt.Errorf("get anomaly flag: %w", err)
+	}
+	return f, nil
+}
+
+func (p *SQLRepo) ResolveAnomalyFlag(id, facultyID int64, review string, attendanceID *int64) error {
+	res, err := p.db.Exec(`
+	UPDATE attendance_flags
+	SET review = $2, reviewed_by = $3, reviewed_at = `+p.dialect.Now+`, attendance_id = $4
+	WHERE flag_id = $1 AND review = 'open';`, id, review, facultyID, attendanceID)
+	if err != nil {
+		return fmt.Errorf("resolve anomaly flag: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		var exists bool
+		if err := p.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM attendance_flags WHERE flag_id = $1)`, id).Scan(&exists); err != nil {
+			return fmt.Errorf("check anomaly flag: %w", err)
+		}
+		if !exists {
+			return domain.NotFound("flag not found: %d", id)
+		}
+		return domain.Conflict("flag %d has already been reviewed", id)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+const departmentColumns = `
+	SELECT d.code, d.name, d.hod_faculty_id, COALESCE(f.faculty_name, ''), d.semesters, d.created_at
+	FROM departments d
+	LEFT JOIN faculty f ON f.faculty_id = d.hod_faculty_id`
+
+func (p *SQLRepo) CreateDepartment(req domain.DepartmentPayload) error {
+	_, err := p.db.Exec(`INSERT INTO departments (code, name, hod_faculty_id, semesters) VALUES ($1, $2, $3, $4);`,
+		req.Code, req.Name, req.HODFacultyID, req.Semesters)
+	if err != nil {
+		if p.isUniqueViolation(err) {
+			return domain.Conflict("department %s already exists", req.Code).Wrap(err)
+		}
+		if p.isForeignKeyViolation(err) {
+			return domain.NotFound("faculty not found: %d", *req.HODFacultyID).Wrap(err)
+		}
+		return fmt.Errorf("insert department: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLRepo) GetDepartments() ([]domain.Department, error) {
+	rows, err := p.db.Query(departmentColumns + ` ORDER BY d.code;`)
+	if err != nil {
+		return nil, fmt.Errorf("get departments: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Department
+	for rows.Next() {
+		d, err := scanDepartment(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) GetDepartment(code string) (domain.Department, error) {
+	d, err := scanDepartment(p.db.QueryRow(departmentColumns+` WHERE d.code = $1;`, code))
+	if err == sql.ErrNoRows {
+		return d, domain.NotFound("department not found: %s", code)
+	}
+	return d, err
+}
+
+func (p *SQLRepo) UpdateDepartment(code string, req domain.DepartmentUpdatePayload) error {
+	res, err := p.db.Exec(`UPDATE departments SET name = $2, hod_faculty_id = $3, semesters = $4 WHERE code = $1;`,
+		code, req.Name, req.HODFacultyID, req.Semesters)
+	if err != nil {
+		if p.isForeignKeyViolation(err) {
+			return domain.NotFound("faculty not found: %d", *req.HODFacultyID).Wrap(err)
+		}
+		return fmt.Errorf("update department: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("department not found: %s", code)
+	}
+	return nil
+}
+
+func (p *SQLRepo) DeleteDepartment(code string) error {
+	res, err := p.db.Exec(`DELETE FROM departments WHERE code = $1;`, code)
+	if err != nil {
+		if p.isForeignKeyViolation(err) {
+			return domain.Conflict("department %s still has faculty, students or subjects", code).Wrap(err)
+		}
+		return fmt.Errorf("delete department: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("department not found: %s", code)
+	}
+	return nil
+}
+
+func scanDepartment(row interface{ Scan(...any) error }) (domain.Department, error) {
+	var d domain.Department
+	var hod sql.NullInt64
+	if err := row.Scan(&d.Code, &d.Name, &hod, &d.HODName, &d.Semesters, &d.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return d, err
+		}
+		return d, fmt.Errorf("scan department: %w", err)
+	}
+	if hod.Valid {
+		d.HODFacultyID = &hod.Int64
+	}
+	return d, nil
+}
+
+// checkDepartment rejects a department missing from the departments table
+// and a sem past the department's last semester; sem 0 skips that check.
+// The foreign keys would catch the first, but not with a field to point at.
+func checkDepartment(q interface {
+	QueryRow(string, ...any) *sql.Row
+}, code string, sem int) error {
+	var semesters int
+	err := q.QueryRow(`SELECT semesters FROM departments WHERE code = $1;`, code).Scan(&semesters)
+	if err == sql.ErrNoRows {
+		return domain.Invalid("department", "department", "department %q is not a known department", code)
+	}
+	if err != nil {
+		return fmt.Errorf("query department: %w", err)
+	}
+	if sem > semesters {
+		return domain.Invalid("sem", "max", "sem must be at most %d in department %s", semesters, code)
+	}
+	return nil
+}
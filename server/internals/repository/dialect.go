@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+)
+
+// Dialect is what a database needs from SQLRepo beyond the SQL every
+// backend shares: the few expressions written differently, how instants are
+// passed, and how constraint violations are recognised.
+type Dialect struct {
+	// Now is the SQL for the current time as timestamp columns store it.
+	Now string
+	// Stamp renders an instant as a timestamp argument.
+	Stamp func(time.Time) any
+	// WeekStart renders the Monday of the week of a date column as
+	// "2006-01-02" text.
+	WeekStart func(column string) string
+	// ForUpdate locks the rows a SELECT reads from alias (every table when
+	// blank) until the transaction ends. Backends that lock the whole
+	// database for a write transaction return "".
+	ForUpdate func(alias string) string
+
+	UniqueViolation     func(error) bool
+	ForeignKeyViolation func(error) bool
+}
+
+// SQLRepo implements every repository the services need on top of
+// database/sql. The queries are written once, with $n placeholders, and run
+// on PostgreSQL and SQLite alike; dialect covers where the two differ.
+type SQLRepo struct {
+	db *sql.DB
+	// cal decides the class date of a capture and reads class times.
+	cal     *calendar.Calendar
+	dialect Dialect
+}
+
+// NewSQLRepo builds the repository; a nil cal means UTC.
+func NewSQLRepo(db *sql.DB, cal *calendar.Calendar, dialect Dialect) *SQLRepo {
+	if cal == nil {
+		cal = calendar.New(nil)
+	}
+	return &SQLRepo{db: db, cal: cal, dialect: dialect}
+}
+
+func (p *SQLRepo) isUniqueViolation(err error) bool { return p.dialect.UniqueViolation(err) }
+
+func (p *SQLRepo) isForeignKeyViolation(err error) bool { return p.dialect.ForeignKeyViolation(err) }
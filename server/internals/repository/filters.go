@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// historySortKeys are the sort keys of the per-student attendance lists.
+var historySortKeys = sortKeys{"date": "a.date", "status": "a.status", "recorded_at": "a.recorded_at"}
+
+// dateRange renders the conditions limiting column to the filter's dates,
+// numbering the new placeholders after the arguments already in args.
+func dateRange(column string, f domain.AttendanceFilter, args []any) (string, []any) {
+	var cond string
+	if !f.From.IsZero() {
+		args = append(args, f.From.Format("2006-01-02"))
+		cond += fmt.Sprintf(" AND %s >= $%d", column, len(args))
+	}
+	if !f.To.IsZero() {
+		args = append(args, f.To.Format("2006-01-02"))
+		cond += fmt.Sprintf(" AND %s <= $%d", column, len(args))
+	}
+	return cond, args
+}
+
+// attendanceConditions applies the whole filter to the attendance table
+// aliased as alias.
+func attendanceConditions(alias string, f domain.AttendanceFilter, args []any) (string, []any) {
+	cond, args := dateRange(alias+".date", f, args)
+	if f.Status != "" {
+		args = append(args, f.Status)
+		cond += fmt.Sprintf(" AND %s.status = $%d", alias, len(args))
+	}
+	return cond, args
+}
+
+// loggedSessions is a CTE counting the teaching sessions logged per subject
+// in f's dates. Once a subject has any, they are its classes held: queries
+// over attendance a add sessionJoins and count with classesExpr and
+// attendedExpr, so rows off a logged date stop counting, a row on a date
+// with two sessions counts for both, and a student's missed sessions count
+// as absent. A student with no rows at all only shows
+// up in queries that start from student_subjects and count with
+// rosterClassesExpr.
+func loggedSessions(f domain.AttendanceFilter, args []any) (string, []any) {
+	cond, args := dateRange("date", f, args)
+	return `
+	logged AS (
+	    SELECT subject_id, COUNT(*) AS classes
+	    FROM teaching_sessions
+	    WHERE 1 = 1` + cond + `
+	    GROUP BY subject_id
+	)`, args
+}
+
+const (
+	sessionJoins = `
+	LEFT JOIN logged lc ON lc.subject_id = a.subject_id
+	LEFT JOIN teaching_sessions ts ON ts.subject_id = a.subject_id AND ts.date = a.date`
+	classesExpr = `COALESCE(MAX(lc.classes), COUNT(*))`
+	// rosterClassesExpr is classesExpr over attendance left-joined to
+	// enrollments, where a student never marked has a single all-NULL row.
+	rosterClassesExpr = `COALESCE(MAX(lc.classes), COUNT(a.attendance_id))`
+	attendedExpr      = `SUM(CASE WHEN a.status = 'Present' AND (lc.classes IS NULL OR ts.session_id IS NOT NULL) THEN 1 ELSE 0 END)`
+)
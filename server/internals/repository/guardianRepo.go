@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+// CreateGuardian inserts the guardian and links every ward in one transaction.
+func (p *SQLRepo) CreateGuardian(req domain.GuardianPayload) (int64, error) {
+	pwHash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return 0, fmt.Errorf("hash password: %w", err)
+	}
+
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id int64
+	err = tx.QueryRow(`
+	INSERT INTO guardians (name, relation, phone, email, password_hash)
+	VALUES ($1, $2, $3, $4, $5) RETURNING guardian_id;`,
+		req.Name, req.Relation, req.Phone, req.Email, pwHash).Scan(&id)
+	if err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("guardian with email %s already exists", req.Email).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert guardian: %w", err)
+	}
+
+	for _, usn := range req.USNs {
+		if err := p.linkGuardianStudent(tx, id, usn); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) LinkGuardianStudent(guardianID int64, usn string) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := p.linkGuardianStudent(tx, guardianID, usn); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLRepo) linkGuardianStudent(tx *sql.Tx, guardianID int64, usn string) error {
+	res, err := tx.Exec(`
+	INSERT INTO guardian_students (guardian_id, usn)
+	SELECT $1, usn FROM students WHERE usn = $2
+	ON CONFLICT DO NOTHING;`, guardianID, usn)
+	if err != nil {
+		if p.isForeignKeyViolation(err) {
+			return domain.NotFound("guardian not found: %d", guardianID).Wrap(err)
+		}
+		return fmt.Errorf("link guardian to %s: %w", usn, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM students WHERE usn = $1)`, usn).Scan(&exists); err != nil {
+			return fmt.Errorf("lookup student %s: %w", usn, err)
+		}
+		if !exists {
+			return domain.NotFound("student not found for usn: %s", usn)
+		}
+	}
+	return nil
+}
+
+func (p *SQLRepo) AuthenticateGuardian(req domain.GuardianLoginPayload) (string, error) {
+	var id int64
+	var pwHash string
+	q := `SELECT guardian_id, password_hash FROM guardians WHERE email = $1;`
+	if err := p.db.QueryRow(q, req.Email).Scan(&id, &pwHash); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.Unauthorized("invalid credentials")
+		}
+		return "", fmt.Errorf("query guardian: %w", err)
+	}
+
+	if err := utils.ComparePassword(pwHash, req.Password); err != nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+
+	token, err := utils.GenerateTokenForGuardian(id, req.Email)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return token, nil
+}
+
+func (p *SQLRepo) GetGuardianWards(guardianID int64) ([]domain.Ward, error) {
+	rows, err := p.db.Query(`
+	SELECT s.usn, s.username, s.department, s.sem
+	FROM guardian_students gs
+	JOIN students s ON s.usn = gs.usn
+	WHERE gs.guardian_id = $1
+	ORDER BY s.usn;`, guardianID)
+	if err != nil {
+		return nil, fmt.Errorf("get guardian wards: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Ward
+	for rows.Next() {
+		var w domain.Ward
+		if err := rows.Scan(&w.USN, &w.Name, &w.Department, &w.Sem); err != nil {
+			return nil, fmt.Errorf("scan ward: %w", err)
+		}
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) IsGuardianOf(guardianID int64, usn string) (bool, error) {
+	var ok bool
+	err := p.db.QueryRow(`
+	SELECT EXISTS (SELECT 1 FROM guardian_students WHERE guardian_id = $1 AND usn = $2);`,
+		guardianID, usn).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("check guardian ward: %w", err)
+	}
+	return ok, nil
+}
+
+func (p *SQLRepo) GetGuardiansByStudent(usn string) ([]domain.Guardian, error) {
+	rows, err := p.db.Query(`
+	SELECT g.guardian_id, g.name, g.relation, g.phone, g.email
+	FROM guardian_students gs
+	JOIN guardians g ON g.guardian_id = gs.guardian_id
+	WHERE gs.usn = $1
+	ORDER BY g.guardian_id;`, usn)
+	if err != nil {
+		return nil, fmt.Errorf("get guardians: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Guardian
+	for rows.Next() {
+		var g domain.Guardian
+		if err := rows.Scan(&g.ID, &g.Name, &g.Relation, &g.Phone, &g.Email); err != nil {
+			return nil, fmt.Errorf("scan guardian: %w", err)
+		}
+		list = append(list, g)
+	}
+	return list, rows.Err()
+}
+
+// GetGuardianContacts groups every guardian by ward USN for alerting.
+func (p *SQLRepo) GetGuardianContacts() (map[string][]domain.GuardianContact, error) {
+	out := map[string][]domain.GuardianContact{}
+	rows, err := p.db.Query(`
+	SELECT g.guardian_id, g.name, g.email, gs.usn
+	FROM guardian_students gs
+	JOIN guardians g ON g.guardian_id = gs.guardian_id;`)
+	if err != nil {
+		return out, fmt.Errorf("get guardian contacts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var g domain.GuardianContact
+		if err := rows.Scan(&g.GuardianID, &g.Name, &g.Email, &g.USN); err != nil {
+			return out, fmt.Errorf("scan guardian contact: %w", err)
+		}
+		out[g.USN] = append(out[g.USN], g)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+func (p *SQLRepo) GetImportLookups() (domain.ImportLookups, error) {
+	lookups := domain.ImportLookups{
+		USNs:          map[string]bool{},
+		FacultyEmails: map[string]bool{},
+		FacultyIDs:    map[int64]bool{},
+		SubjectCodes:  map[string]bool{},
+		Departments:   map[string]int{},
+	}
+
+	if err := collectStrings(p.db, `SELECT usn FROM students;`, lookups.USNs); err != nil {
+		return lookups, fmt.Errorf("load usns: %w", err)
+	}
+	if err := collectStrings(p.db, `SELECT email FROM faculty;`, lookups.FacultyEmails); err != nil {
+		return lookups, fmt.Errorf("load faculty emails: %w", err)
+	}
+	if err := collectStrings(p.db, `SELECT subject_code FROM subjects;`, lookups.SubjectCodes); err != nil {
+		return lookups, fmt.Errorf("load subject codes: %w", err)
+	}
+	if err := collectDepartments(p.db, lookups.Departments); err != nil {
+		return lookups, fmt.Errorf("load departments: %w", err)
+	}
+
+	rows, err := p.db.Query(`SELECT faculty_id FROM faculty;`)
+	if err != nil {
+		return lookups, fmt.Errorf("load faculty ids: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return lookups, fmt.Errorf("scan faculty id: %w", err)
+		}
+		lookups.FacultyIDs[id] = true
+	}
+	return lookups, rows.Err()
+}
+
+func collectDepartments(db *sql.DB, into map[string]int) error {
+	rows, err := db.Query(`SELECT code, semesters FROM departments;`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code string
+		var semesters int
+		if err := rows.Scan(&code, &semesters); err != nil {
+			return err
+		}
+		into[code] = semesters
+	}
+	return rows.Err()
+}
+
+func collectStrings(db *sql.DB, query string, into map[string]bool) error {
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return err
+		}
+		into[v] = true
+	}
+	return rows.Err()
+}
+
+// ImportStudents registers every student in a single transaction; one failing
+// row rolls back the whole batch.
+func (p *SQLRepo) ImportStudents(students []domain.StudentRegisterPayload) (int, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, s := range students {
+		if _, err := p.insertStudent(tx, s); err != nil {
+			return 0, fmt.Errorf("import student (usn=%s): %w", s.USN, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return len(students), nil
+}
+
+func (p *SQLRepo) ImportFaculty(faculty []domain.FacultyRegisterPayload) (int, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, f := range faculty {
+		if _, err := p.insertFaculty(tx, f); err != nil {
+			return 0, fmt.Errorf("import faculty (email=%s): %w", f.Email, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return len(faculty), nil
+}
+
+func (p *SQLRepo) ImportSubjects(subjects []domain.SubjectPayload) (int, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, s := range subjects {
+		if _, err := p.insertSubject(tx, s); err != nil {
+			return 0, fmt.Errorf("import subject (code=%s): %w", s.Code, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return len(subjects), nil
+}
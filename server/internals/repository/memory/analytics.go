@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+func (m *MemoryRepo) GetDepartmentAnalytics(query domain.AnalyticsQuery) (domain.DepartmentAnalytics, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	a := domain.DepartmentAnalytics{
+		Department:   query.Department,
+		Semesters:    []domain.SemesterAttendance{},
+		Subjects:     []domain.SubjectAttendance{},
+		Weekly:       []domain.WeeklyAttendance{},
+		Faculty:      []domain.FacultySessions{},
+		TopAbsentees: []domain.AbsenteeStat{},
+		Unassigned:   []domain.UnassignedAttendance{},
+	}
+
+	type semStats struct {
+		row      domain.SemesterAttendance
+		students map[string]bool
+	}
+	sems := map[int]*semStats{}
+	subjects := map[int64]*domain.SubjectAttendance{}
+	held := map[int64]map[string]bool{}
+	for _, id := range sortedKeys(m.subjects) {
+		s := m.subjects[id]
+		if s.department != query.Department {
+			continue
+		}
+		if sems[s.sem] == nil {
+			sems[s.sem] = &semStats{row: domain.SemesterAttendance{Sem: s.sem}, students: map[string]bool{}}
+		}
+		sems[s.sem].row.Subjects++
+		subjects[id] = &domain.SubjectAttendance{SubjectCode: s.code, SubjectName: s.name, Sem: s.sem}
+		if f, ok := m.faculty[s.facultyID]; ok {
+			subjects[id].FacultyName = f.Name
+		}
+		held[id] = map[string]bool{}
+	}
+
+	weeks := map[time.Time]*domain.WeeklyAttendance{}
+	absentees := map[string]*domain.AbsenteeStat{}
+	type unassignedKey struct {
+		sem  int
+		date string
+	}
+	unassigned := map[unassignedKey]*domain.UnassignedAttendance{}
+	for _, id := range sortedKeys(m.attendance) {
+		r := m.attendance[id]
+		if !inRange(r.date, query.Filter) {
+			continue
+		}
+		st, hasStudent := m.students[m.studentByUSN[r.usn]]
+		if r.subjectID == 0 {
+			if !hasStudent || st.Department != query.Department {
+				continue
+			}
+			k := unassignedKey{st.Sem, day(r.date)}
+			if unassigned[k] == nil {
+				unassigned[k] = &domain.UnassignedAttendance{Sem: st.Sem, Date: r.date}
+			}
+			unassigned[k].Rows++
+			continue
+		}
+		sub, ok := subjects[r.subjectID]
+		if !ok {
+			continue
+		}
+		present := 0
+		if r.status == "Present" {
+			present = 1
+		}
+		sem := sems[sub.Sem]
+		sem.students[r.usn] = true
+		sem.row.Present += present
+		sem.row.Total++
+		sub.Present += present
+		sub.Total++
+		held[r.subjectID][day(r.date)] = true
+
+		w := calendar.WeekStart(r.date)
+		if weeks[w] == nil {
+			weeks[w] = &domain.WeeklyAttendance{WeekStart: w}
+		}
+		weeks[w].Present += present
+		weeks[w].Total++
+
+		if hasStudent {
+			if absentees[r.usn] == nil {
+				absentees[r.usn] = &domain.AbsenteeStat{USN: r.usn, StudentName: st.Username, Sem: st.Sem}
+			}
+			absentees[r.usn].Absent += 1 - present
+			absentees[r.usn].Total++
+		}
+	}
+
+	for _, s := range sems {
+		s.row.Students = len(s.students)
+		a.Semesters = append(a.Semesters, s.row)
+	}
+	sort.Slice(a.Semesters, func(i, j int) bool { return a.Semesters[i].Sem < a.Semesters[j].Sem })
+
+	logged := m.loggedSessions(query.Filter)
+	faculty := map[int64]*domain.FacultySessions{}
+	for id, sub := range subjects {
+		sub.ClassesHeld = len(held[id])
+		if dates, ok := logged[id]; ok {
+			sub.ClassesHeld = sessionCount(dates)
+		}
+		a.Subjects = append(a.Subjects, *sub)
+
+		fid := m.subjects[id].facultyID
+		f, ok := m.faculty[fid]
+		if !ok {
+			continue
+		}
+		if faculty[fid] == nil {
+			faculty[fid] = &domain.FacultySessions{FacultyID: fid, FacultyName: f.Name}
+		}
+		faculty[fid].Subjects++
+		faculty[fid].Sessions += sub.ClassesHeld
+	}
+	sort.Slice(a.Subjects, func(i, j int) bool {
+		if a.Subjects[i].Sem != a.Subjects[j].Sem {
+			return a.Subjects[i].Sem < a.Subjects[j].Sem
+		}
+		return a.Subjects[i].SubjectCode < a.Subjects[j].SubjectCode
+	})
+
+	for _, f := range faculty {
+		a.Faculty = append(a.Faculty, *f)
+	}
+	sort.Slice(a.Faculty, func(i, j int) bool {
+		if a.Faculty[i].Sessions != a.Faculty[j].Sessions {
+			return a.Faculty[i].Sessions > a.Faculty[j].Sessions
+		}
+		return a.Faculty[i].FacultyName < a.Faculty[j].FacultyName
+	})
+
+	for _, w := range weeks {
+		a.Weekly = append(a.Weekly, *w)
+	}
+	sort.Slice(a.Weekly, func(i, j int) bool { return a.Weekly[i].WeekStart.Before(a.Weekly[j].WeekStart) })
+
+	for _, s := range absentees {
+		if s.Absent > 0 {
+			a.TopAbsentees = append(a.TopAbsentees, *s)
+		}
+	}
+	sort.Slice(a.TopAbsentees, func(i, j int) bool {
+		if a.TopAbsentees[i].Absent != a.TopAbsentees[j].Absent {
+			return a.TopAbsentees[i].Absent > a.TopAbsentees[j].Absent
+		}
+		return a.TopAbsentees[i].USN < a.TopAbsentees[j].USN
+	})
+	if len(a.TopAbsentees) > query.TopAbsentees {
+		a.TopAbsentees = a.TopAbsentees[:query.TopAbsentees]
+	}
+
+	for _, u := range unassigned {
+		a.Unassigned = append(a.Unassigned, *u)
+	}
+	sort.Slice(a.Unassigned, func(i, j int) bool {
+		if !a.Unassigned[i].Date.Equal(a.Unassigned[j].Date) {
+			return a.Unassigned[i].Date.Before(a.Unassigned[j].Date)
+		}
+		return a.Unassigned[i].Sem < a.Unassigned[j].Sem
+	})
+	return a, nil
+}
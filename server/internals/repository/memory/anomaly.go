@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// recognitionEvent is a row of the recognition_events table.
+type recognitionEvent struct {
+	domain.RecognitionEvent
+	date time.Time
+}
+
+// anomalyFlag is a row of the attendance_flags table.
+type anomalyFlag struct {
+	id           int64
+	eventID      int64
+	reasons      []string
+	detail       string
+	review       string
+	reviewedBy   *int64
+	reviewedAt   *time.Time
+	attendanceID *int64
+	createdAt    time.Time
+}
+
+func (m *MemoryRepo) RecordRecognitionEvent(e domain.RecognitionEvent) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.studentByUSN[e.USN]; !ok {
+		return 0, domain.NotFound("student not found for usn: %s", e.USN)
+	}
+	e.ID = m.next("recognition_events")
+	e.RecordedAt = e.RecordedAt.UTC()
+	e.FaceCropURL = ""
+	if e.Confidence != nil {
+		confidence := *e.Confidence
+		e.Confidence = &confidence
+	}
+	if e.BoundingBox != nil {
+		box := *e.BoundingBox
+		e.BoundingBox = &box
+	}
+	m.events[e.ID] = &recognitionEvent{RecognitionEvent: e, date: m.cal.DateOf(e.RecordedAt)}
+	return e.ID, nil
+}
+
+func (m *MemoryRepo) GetRecognitionEvents(usn string, from, to time.Time) ([]domain.RecognitionEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.RecognitionEvent
+	for _, id := range sortedKeys(m.events) {
+		e := m.events[id]
+		if e.USN == usn && between(e.RecordedAt, from, to) {
+			list = append(list, e.RecognitionEvent)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].RecordedAt.Before(list[j].RecordedAt) })
+	return list, nil
+}
+
+func (m *MemoryRepo) GetStudentSessions(usn string, date time.Time) ([]domain.TeachingSession, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	st, ok := m.students[m.studentByUSN[usn]]
+	if !ok {
+		return nil, nil
+	}
+	var list []domain.TeachingSession
+	for _, session := range m.sessions {
+		sub := m.subjects[session.subjectID]
+		if sub.department != st.Department || sub.sem != st.Sem || day(session.date) != day(date) {
+			continue
+		}
+		list = append(list, domain.TeachingSession{
+			ID:              session.id,
+			SubjectCode:     sub.code,
+			SubjectName:     sub.name,
+			FacultyID:       session.facultyID,
+			Date:            session.date,
+			StartTime:       session.startTime,
+			DurationMinutes: session.durationMinutes,
+			Topic:           session.topic,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].StartTime < list[j].StartTime })
+	return list, nil
+}
+
+func (m *MemoryRepo) CreateAnomalyFlag(eventID int64, reasons []string, detail string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, f := range m.flags {
+		if f.eventID == eventID {
+			return 0, domain.Conflict("recognition event %d is already flagged", eventID)
+		}
+	}
+	id := m.next("attendance_flags")
+	m.flags[id] = &anomalyFlag{
+		id:        id,
+		eventID:   eventID,
+		reasons:   append([]string(nil), reasons...),
+		detail:    detail,
+		review:    domain.FlagOpen,
+		createdAt: time.Now(),
+	}
+	return id, nil
+}
+
+// flag joins f with its event and student, as anomalyFlagSelect does.
+func (m *MemoryRepo) flag(f *anomalyFlag) domain.AnomalyFlag {
+	e := m.events[f.eventID]
+	row := domain.AnomalyFlag{
+		ID:           f.id,
+		Event:        e.RecognitionEvent,
+		Reasons:      append([]string(nil), f.reasons...),
+		Detail:       f.detail,
+		Review:       f.review,
+		ReviewedBy:   f.reviewedBy,
+		ReviewedAt:   f.reviewedAt,
+		AttendanceID: f.attendanceID,
+		CreatedAt:    f.createdAt,
+	}
+	if st, ok := m.students[m.studentByUSN[e.USN]]; ok {
+		row.StudentName = st.Username
+		row.Department = st.Department
+	}
+	return row
+}
+
+func (m *MemoryRepo) GetAnomalyFlags(query domain.FlagQuery) ([]domain.AnomalyFlag, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.AnomalyFlag
+	for _, f := range m.flags {
+		row := m.flag(f)
+		if query.Department != "" && row.Department != query.Department {
+			continue
+		}
+		if query.Review != "" && f.review != query.Review {
+			continue
+		}
+		if !inRange(m.events[f.eventID].date, query.Filter) {
+			continue
+		}
+		list = append(list, row)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Event.RecordedAt.Equal(list[j].Event.RecordedAt) {
+			return list[i].Event.RecordedAt.After(list[j].Event.RecordedAt)
+		}
+		return list[i].ID > list[j].ID
+	})
+	return list, nil
+}
+
+func (m *MemoryRepo) GetAnomalyFlag(id int64) (domain.AnomalyFlag, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	f, ok := m.flags[id]
+	if !ok {
+		return domain.AnomalyFlag{}, domain.NotFound("flag not found: %d", id)
+	}
+	return m.flag(f), nil
+}
+
+func (m *MemoryRepo) ResolveAnomalyFlag(id, facultyID int64, review string, attendanceID *int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.flags[id]
+	if !ok {
+		return domain.NotFound("flag not found: %d", id)
+	}
+	if f.review != domain.FlagOpen {
+		return domain.Conflict("flag %d has already been reviewed", id)
+	}
+	now := time.Now()
+	f.review = review
+	f.reviewedBy = &facultyID
+	f.reviewedAt = &now
+	f.attendanceID = attendanceID
+	return nil
+}
@@ -0,0 +1,434 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// historySortKeys are the sort keys of the per-student attendance lists.
+func historySortKeys[T any](date, status, recordedAt func(T) string) sortKeys[T] {
+	by := func(field func(T) string) order[T] {
+		return func(a, b T) int { return strings.Compare(field(a), field(b)) }
+	}
+	return sortKeys[T]{"date": by(date), "status": by(status), "recorded_at": by(recordedAt)}
+}
+
+func matchesFilter(a *attendance, f domain.AttendanceFilter) bool {
+	return inRange(a.date, f) && (f.Status == "" || a.status == f.Status)
+}
+
+func (m *MemoryRepo) withNames(a *attendance) domain.AttendanceWithNames {
+	row := domain.AttendanceWithNames{
+		ID:         a.id,
+		USN:        a.usn,
+		SubjectID:  a.subjectID,
+		Date:       a.date,
+		Status:     a.status,
+		RecordedAt: a.recordedAt,
+		CreatedAt:  a.createdAt,
+	}
+	if st, ok := m.students[m.studentByUSN[a.usn]]; ok {
+		row.StudentName = st.Username
+	}
+	if sub, ok := m.subjects[a.subjectID]; ok {
+		row.SubjectName = sub.name
+	}
+	return row
+}
+
+func (m *MemoryRepo) MarkAttendance(req *domain.AttendancePayload) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id, err := m.upsertUnassigned(*req)
+	if err != nil {
+		return 0, fmt.Errorf("mark attendance: %w", err)
+	}
+	return id, nil
+}
+
+// BulkMarkAttendance is all-or-nothing, like the transaction it mirrors.
+func (m *MemoryRepo) BulkMarkAttendance(attendances []domain.AttendancePayload) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, a := range attendances {
+		if _, ok := m.studentByUSN[a.USN]; !ok {
+			return 0, domain.NotFound("student not found for usn: %s", a.USN)
+		}
+	}
+	for _, a := range attendances {
+		if _, err := m.upsertUnassigned(a); err != nil {
+			return 0, fmt.Errorf("insert attendance (usn=%s): %w", a.USN, err)
+		}
+	}
+	return len(attendances), nil
+}
+
+// upsertUnassigned inserts a capture without a subject or, as the partial
+// unique index on (usn, date) does, overwrites that day's unassigned row.
+func (m *MemoryRepo) upsertUnassigned(req domain.AttendancePayload) (int64, error) {
+	if _, ok := m.studentByUSN[req.USN]; !ok {
+		return 0, domain.NotFound("student not found for usn: %s", req.USN)
+	}
+
+	classDate := m.cal.DateOf(req.RecordedAt)
+	now := time.Now()
+	for _, a := range m.attendance {
+		if a.usn == req.USN && a.subjectID == 0 && a.date.Equal(classDate) {
+			a.status = req.Status
+			a.recordedAt = req.RecordedAt.UTC()
+			return a.id, nil
+		}
+	}
+
+	id := m.next("attendance")
+	m.attendance[id] = &attendance{
+		id:         id,
+		usn:        req.USN,
+		date:       classDate,
+		status:     req.Status,
+		recordedAt: req.RecordedAt.UTC(),
+		createdAt:  now,
+		updatedAt:  now,
+	}
+	return id, nil
+}
+
+func (m *MemoryRepo) AssignSubjectToTimeRange(facultyID int64, subjectCode string, classDate time.Time, startTime, endTime time.Time) (int64, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		return 0, 0, domain.NotFound("subject not found")
+	}
+	if sub.facultyID != facultyID && !m.substitutes(sub.id, facultyID, classDate) {
+		return 0, 0, domain.Forbidden("not authorized to assign this subject")
+	}
+
+	startDT, endDT := m.cal.Window(classDate, startTime, endTime)
+	date := day(classDate)
+
+	candidate := func(a *attendance) bool {
+		return a.subjectID == 0 && day(a.date) == date && between(a.recordedAt, startDT, endDT)
+	}
+
+	// Decide against the state before the update, as a single UPDATE does.
+	var assign []*attendance
+	for _, a := range m.attendance {
+		if !candidate(a) {
+			continue
+		}
+		taken := false
+		for _, existing := range m.attendance {
+			if existing.usn == a.usn && existing.subjectID == sub.id && existing.date.Equal(a.date) {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			assign = append(assign, a)
+		}
+	}
+
+	now := time.Now()
+	for _, a := range assign {
+		a.subjectID = sub.id
+		a.updatedAt = now
+	}
+	updated := int64(len(assign))
+
+	var remaining int64
+	for _, a := range m.attendance {
+		if candidate(a) {
+			remaining++
+		}
+	}
+	skipped := max(remaining-updated, 0)
+	return updated, skipped, nil
+}
+
+func (m *MemoryRepo) GetAttendanceByStudentAndSubject(usn string, subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.AttendanceWithNames, int, error) {
+	m.mu.RLock()
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		m.mu.RUnlock()
+		return nil, 0, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	var list []domain.AttendanceWithNames
+	for _, id := range sortedKeys(m.attendance) {
+		a := m.attendance[id]
+		if a.usn == usn && a.subjectID == sub.id && matchesFilter(a, filter) {
+			list = append(list, m.withNames(a))
+		}
+	}
+	m.mu.RUnlock()
+
+	keys := historySortKeys(
+		func(a domain.AttendanceWithNames) string { return day(a.Date) },
+		func(a domain.AttendanceWithNames) string { return a.Status },
+		func(a domain.AttendanceWithNames) string { return a.RecordedAt.Format(time.RFC3339Nano) },
+	)
+	list, total, err := paginate(list, page, keys, "date", func(a, b domain.AttendanceWithNames) int { return cmp.Compare(a.ID, b.ID) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("query attendance: %w", err)
+	}
+	return list, total, nil
+}
+
+func (m *MemoryRepo) GetAttendanceBySubjectAndDate(subjectCode string, date time.Time) ([]domain.AttendanceWithNames, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		return nil, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	start, end := m.cal.DayBounds(date)
+
+	var list []domain.AttendanceWithNames
+	for _, id := range sortedKeys(m.attendance) {
+		a := m.attendance[id]
+		if a.subjectID == sub.id && between(a.recordedAt, start, end) {
+			list = append(list, m.withNames(a))
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].RecordedAt.Before(list[j].RecordedAt) })
+	return list, nil
+}
+
+// percentage rounds like ROUND(100.0 * attended / total, 2).
+func percentage(attended, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(10000*float64(attended)/float64(total)) / 100
+}
+
+func (m *MemoryRepo) GetAttendanceSummaryByStudent(usn string, filter domain.AttendanceFilter) ([]domain.SubjectSummary, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	studentID, ok := m.studentByUSN[usn]
+	if !ok {
+		return nil, nil
+	}
+
+	// Every enrolled subject gets a row, so one the student was never
+	// marked in reads 0 of its logged sessions rather than being left out.
+	logged := m.loggedSessions(filter)
+	bySubject := map[int64]*domain.SubjectSummary{}
+	for e := range m.enrollments {
+		if e.studentID == studentID {
+			bySubject[e.subjectID] = &domain.SubjectSummary{SubjectID: e.subjectID, SubjectName: m.subjects[e.subjectID].name}
+		}
+	}
+	for _, a := range m.attendance {
+		if a.usn != usn || !inRange(a.date, filter) {
+			continue
+		}
+		s := bySubject[a.subjectID]
+		if s == nil {
+			continue
+		}
+		s.TotalClasses++
+		if a.status == "Present" {
+			s.Attended += classesOn(logged, a.subjectID, a.date)
+		}
+	}
+
+	var list []domain.SubjectSummary
+	for _, id := range sortedKeys(bySubject) {
+		s := bySubject[id]
+		if dates, ok := logged[id]; ok {
+			s.TotalClasses = sessionCount(dates)
+		}
+		s.Percentage = percentage(s.Attended, s.TotalClasses)
+		list = append(list, *s)
+	}
+	return list, nil
+}
+
+func (m *MemoryRepo) GetAttendanceSummaryBySubject(subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.StudentSummary, int, error) {
+	m.mu.RLock()
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		m.mu.RUnlock()
+		return nil, 0, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+
+	logged := m.loggedSessions(filter)
+	dates, sessionsLogged := logged[sub.id]
+	byUSN := map[string]*domain.StudentSummary{}
+	for e := range m.enrollments {
+		if e.subjectID == sub.id {
+			st := m.students[e.studentID]
+			byUSN[st.USN] = &domain.StudentSummary{USN: st.USN, StudentName: st.Username}
+		}
+	}
+	for _, a := range m.attendance {
+		if a.subjectID != sub.id || !inRange(a.date, filter) {
+			continue
+		}
+		s := byUSN[a.usn]
+		if s == nil {
+			continue
+		}
+		s.TotalClasses++
+		if a.status == "Present" {
+			s.Attended += classesOn(logged, sub.id, a.date)
+		}
+	}
+	m.mu.RUnlock()
+
+	list := make([]domain.StudentSummary, 0, len(byUSN))
+	for _, s := range byUSN {
+		if sessionsLogged {
+			s.TotalClasses = sessionCount(dates)
+		}
+		s.Percentage = percentage(s.Attended, s.TotalClasses)
+		list = append(list, *s)
+	}
+
+	keys := sortKeys[domain.StudentSummary]{
+		"name":          func(a, b domain.StudentSummary) int { return strings.Compare(a.StudentName, b.StudentName) },
+		"usn":           func(a, b domain.StudentSummary) int { return strings.Compare(a.USN, b.USN) },
+		"percentage":    func(a, b domain.StudentSummary) int { return cmp.Compare(a.Percentage, b.Percentage) },
+		"attended":      func(a, b domain.StudentSummary) int { return cmp.Compare(a.Attended, b.Attended) },
+		"total_classes": func(a, b domain.StudentSummary) int { return cmp.Compare(a.TotalClasses, b.TotalClasses) },
+	}
+	list, total, err := paginate(list, page, keys, "name", func(a, b domain.StudentSummary) int { return strings.Compare(a.USN, b.USN) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("get subject summary: %w", err)
+	}
+	return list, total, nil
+}
+
+func (m *MemoryRepo) GetClassAttendance(subjectCode string, date time.Time) ([]domain.ClassAttendance, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		return nil, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	start, end := m.cal.DayBounds(date)
+
+	var list []domain.ClassAttendance
+	for _, id := range sortedKeys(m.attendance) {
+		a := m.attendance[id]
+		if a.subjectID != sub.id || !between(a.recordedAt, start, end) {
+			continue
+		}
+		list = append(list, domain.ClassAttendance{
+			USN:         a.usn,
+			StudentName: m.students[m.studentByUSN[a.usn]].Username,
+			Date:        a.date,
+			Status:      a.status,
+		})
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].StudentName < list[j].StudentName })
+	return list, nil
+}
+
+func (m *MemoryRepo) GetStudentAttendanceHistory(usn string, subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.StudentHistory, int, error) {
+	m.mu.RLock()
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		m.mu.RUnlock()
+		return nil, 0, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	var list []domain.StudentHistory
+	for _, id := range sortedKeys(m.attendance) {
+		a := m.attendance[id]
+		if a.usn != usn || a.subjectID != sub.id || !matchesFilter(a, filter) {
+			continue
+		}
+		list = append(list, domain.StudentHistory{
+			ID:          a.id,
+			Date:        a.date,
+			Status:      a.status,
+			SubjectID:   a.subjectID,
+			SubjectName: sub.name,
+			RecordedAt:  a.recordedAt,
+		})
+	}
+	m.mu.RUnlock()
+
+	keys := historySortKeys(
+		func(h domain.StudentHistory) string { return day(h.Date) },
+		func(h domain.StudentHistory) string { return h.Status },
+		func(h domain.StudentHistory) string { return h.RecordedAt.Format(time.RFC3339Nano) },
+	)
+	list, total, err := paginate(list, page, keys, "date", func(a, b domain.StudentHistory) int { return cmp.Compare(a.ID, b.ID) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("get student history: %w", err)
+	}
+	return list, total, nil
+}
+
+func (m *MemoryRepo) CorrectAttendance(facultyID int64, attendanceID int64, status string) (domain.AttendanceCorrection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := domain.AttendanceCorrection{AttendanceID: attendanceID, Status: status}
+	a, ok := m.attendance[attendanceID]
+	if !ok || a.subjectID == 0 {
+		return c, domain.NotFound("attendance not found or not assigned to a subject")
+	}
+	sub := m.subjects[a.subjectID]
+	c.USN, c.SubjectCode, c.OldStatus, c.RecordedAt = a.usn, sub.code, a.status, a.recordedAt
+
+	if sub.facultyID != facultyID && !m.substitutes(sub.id, facultyID, a.date) {
+		return c, domain.Forbidden("not authorized to correct this attendance")
+	}
+
+	a.status = status
+	a.updatedAt = time.Now()
+	return c, nil
+}
+
+func (m *MemoryRepo) GetSubjectOwner(subjectCode string) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		return 0, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	return sub.facultyID, nil
+}
+
+func (m *MemoryRepo) GetCaptureSubject(usn string, recordedAt time.Time) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	studentID, ok := m.studentByUSN[usn]
+	if !ok {
+		return "", nil
+	}
+	date := m.cal.DateOf(recordedAt)
+	var covering []*teachingSession
+	for _, s := range m.sessions {
+		if day(s.date) != day(date) || !m.enrollments[enrollment{studentID, s.subjectID}] {
+			continue
+		}
+		from, to, err := m.cal.Session(date, s.startTime, s.durationMinutes)
+		if err == nil && between(recordedAt, from, to) {
+			covering = append(covering, s)
+		}
+	}
+	if len(covering) == 0 {
+		return "", nil
+	}
+	// The earliest session wins, as in the SQL backends.
+	sort.Slice(covering, func(i, j int) bool { return covering[i].startTime < covering[j].startTime })
+	return m.subjects[covering[0].subjectID].code, nil
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// department is a row of the departments table; hodFacultyID 0 stands for
+// NULL.
+type department struct {
+	code         string
+	name         string
+	hodFacultyID int64
+	semesters    int
+	createdAt    time.Time
+}
+
+// seedDepartments returns the departments migration 0008 creates.
+func seedDepartments() map[string]*department {
+	now := time.Now()
+	seed := map[string]string{
+		"CSE":  "Computer Science and Engineering",
+		"ISE":  "Information Science and Engineering",
+		"ECE":  "Electronics and Communication Engineering",
+		"EEE":  "Electrical and Electronics Engineering",
+		"ME":   "Mechanical Engineering",
+		"CV":   "Civil Engineering",
+		"AIML": "Artificial Intelligence and Machine Learning",
+	}
+	rows := map[string]*department{}
+	for code, name := range seed {
+		rows[code] = &department{code: code, name: name, semesters: 8, createdAt: now}
+	}
+	return rows
+}
+
+// checkDepartment mirrors the SQL repositories' check before an insert or
+// update that sets a department; sem 0 skips the semester bound.
+func (m *MemoryRepo) checkDepartment(code string, sem int) error {
+	d, ok := m.departments[code]
+	if !ok {
+		return domain.Invalid("department", "department", "department %q is not a known department", code)
+	}
+	if sem > d.semesters {
+		return domain.Invalid("sem", "max", "sem must be at most %d in department %s", d.semesters, code)
+	}
+	return nil
+}
+
+func (m *MemoryRepo) checkHOD(facultyID *int64) error {
+	if facultyID == nil {
+		return nil
+	}
+	if _, ok := m.faculty[*facultyID]; !ok {
+		return domain.NotFound("faculty not found: %d", *facultyID)
+	}
+	return nil
+}
+
+func hodID(facultyID *int64) int64 {
+	if facultyID == nil {
+		return 0
+	}
+	return *facultyID
+}
+
+func (m *MemoryRepo) CreateDepartment(req domain.DepartmentPayload) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.departments[req.Code]; ok {
+		return domain.Conflict("department %s already exists", req.Code)
+	}
+	if err := m.checkHOD(req.HODFacultyID); err != nil {
+		return err
+	}
+	m.departments[req.Code] = &department{
+		code:         req.Code,
+		name:         req.Name,
+		hodFacultyID: hodID(req.HODFacultyID),
+		semesters:    req.Semesters,
+		createdAt:    time.Now(),
+	}
+	return nil
+}
+
+func (m *MemoryRepo) departmentRow(d *department) domain.Department {
+	row := domain.Department{Code: d.code, Name: d.name, Semesters: d.semesters, CreatedAt: d.createdAt}
+	if f, ok := m.faculty[d.hodFacultyID]; ok {
+		id := d.hodFacultyID
+		row.HODFacultyID, row.HODName = &id, f.Name
+	}
+	return row
+}
+
+func (m *MemoryRepo) GetDepartments() ([]domain.Department, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Department
+	for _, d := range m.departments {
+		list = append(list, m.departmentRow(d))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list, nil
+}
+
+func (m *MemoryRepo) GetDepartment(code string) (domain.Department, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d, ok := m.departments[code]
+	if !ok {
+		return domain.Department{}, domain.NotFound("department not found: %s", code)
+	}
+	return m.departmentRow(d), nil
+}
+
+func (m *MemoryRepo) UpdateDepartment(code string, req domain.DepartmentUpdatePayload) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkHOD(req.HODFacultyID); err != nil {
+		return err
+	}
+	d, ok := m.departments[code]
+	if !ok {
+		return domain.NotFound("department not found: %s", code)
+	}
+	d.name, d.hodFacultyID, d.semesters = req.Name, hodID(req.HODFacultyID), req.Semesters
+	return nil
+}
+
+func (m *MemoryRepo) DeleteDepartment(code string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.departments[code]; !ok {
+		return domain.NotFound("department not found: %s", code)
+	}
+	inUse := false
+	for _, f := range m.faculty {
+		inUse = inUse || f.Department == code
+	}
+	for _, s := range m.students {
+		inUse = inUse || s.Department == code
+	}
+	for _, s := range m.subjects {
+		inUse = inUse || s.department == code
+	}
+	if inUse {
+		return domain.Conflict("department %s still has faculty, students or subjects", code)
+	}
+	delete(m.departments, code)
+	return nil
+}
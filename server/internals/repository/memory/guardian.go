@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+type guardian struct {
+	domain.Guardian
+	passwordHash string
+}
+
+type guardianLink struct {
+	guardianID int64
+	usn        string
+}
+
+// CreateGuardian inserts the guardian and links every ward, or nothing at
+// all when a ward does not exist.
+func (m *MemoryRepo) CreateGuardian(req domain.GuardianPayload) (int64, error) {
+	pwHash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return 0, fmt.Errorf("hash password: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, g := range m.guardians {
+		if g.Email == req.Email {
+			return 0, domain.Conflict("guardian with email %s already exists", req.Email)
+		}
+	}
+	for _, usn := range req.USNs {
+		if _, ok := m.studentByUSN[usn]; !ok {
+			return 0, domain.NotFound("student not found for usn: %s", usn)
+		}
+	}
+
+	id := m.next("guardians")
+	m.guardians[id] = &guardian{
+		Guardian: domain.Guardian{
+			ID:       id,
+			Name:     req.Name,
+			Relation: req.Relation,
+			Phone:    req.Phone,
+			Email:    req.Email,
+		},
+		passwordHash: pwHash,
+	}
+	for _, usn := range req.USNs {
+		m.guardianLinks[guardianLink{id, usn}] = true
+	}
+	return id, nil
+}
+
+// LinkGuardianStudent is idempotent, like ON CONFLICT DO NOTHING.
+func (m *MemoryRepo) LinkGuardianStudent(guardianID int64, usn string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.studentByUSN[usn]; !ok {
+		return domain.NotFound("student not found for usn: %s", usn)
+	}
+	if _, ok := m.guardians[guardianID]; !ok {
+		return domain.NotFound("guardian not found: %d", guardianID)
+	}
+	m.guardianLinks[guardianLink{guardianID, usn}] = true
+	return nil
+}
+
+func (m *MemoryRepo) AuthenticateGuardian(req domain.GuardianLoginPayload) (string, error) {
+	m.mu.RLock()
+	var found *guardian
+	for _, g := range m.guardians {
+		if g.Email == req.Email {
+			c := *g
+			found = &c
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if found == nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+	if err := utils.ComparePassword(found.passwordHash, req.Password); err != nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+
+	token, err := utils.GenerateTokenForGuardian(found.ID, req.Email)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return token, nil
+}
+
+func (m *MemoryRepo) GetGuardianWards(guardianID int64) ([]domain.Ward, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Ward
+	for link := range m.guardianLinks {
+		if link.guardianID != guardianID {
+			continue
+		}
+		st := m.students[m.studentByUSN[link.usn]]
+		list = append(list, domain.Ward{USN: st.USN, Name: st.Username, Department: st.Department, Sem: st.Sem})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].USN < list[j].USN })
+	return list, nil
+}
+
+func (m *MemoryRepo) IsGuardianOf(guardianID int64, usn string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.guardianLinks[guardianLink{guardianID, usn}], nil
+}
+
+func (m *MemoryRepo) GetGuardiansByStudent(usn string) ([]domain.Guardian, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Guardian
+	for _, id := range sortedKeys(m.guardians) {
+		if m.guardianLinks[guardianLink{id, usn}] {
+			list = append(list, m.guardians[id].Guardian)
+		}
+	}
+	return list, nil
+}
+
+// GetGuardianContacts groups every guardian by ward USN for alerting.
+func (m *MemoryRepo) GetGuardianContacts() (map[string][]domain.GuardianContact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := map[string][]domain.GuardianContact{}
+	for _, id := range sortedKeys(m.guardians) {
+		g := m.guardians[id]
+		for link := range m.guardianLinks {
+			if link.guardianID == id {
+				out[link.usn] = append(out[link.usn], domain.GuardianContact{GuardianID: id, Name: g.Name, Email: g.Email, USN: link.usn})
+			}
+		}
+	}
+	return out, nil
+}
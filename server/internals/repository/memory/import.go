@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+func (m *MemoryRepo) GetImportLookups() (domain.ImportLookups, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lookups := domain.ImportLookups{
+		USNs:          map[string]bool{},
+		FacultyEmails: map[string]bool{},
+		FacultyIDs:    map[int64]bool{},
+		SubjectCodes:  map[string]bool{},
+		Departments:   map[string]int{},
+	}
+	for usn := range m.studentByUSN {
+		lookups.USNs[usn] = true
+	}
+	for id, f := range m.faculty {
+		lookups.FacultyIDs[id] = true
+		lookups.FacultyEmails[f.Email] = true
+	}
+	for code, d := range m.departments {
+		lookups.Departments[code] = d.semesters
+	}
+	for _, s := range m.subjects {
+		lookups.SubjectCodes[s.code] = true
+	}
+	return lookups, nil
+}
+
+// ImportStudents checks the whole batch before inserting anything, so one
+// failing row leaves the repository untouched, as the transaction would.
+func (m *MemoryRepo) ImportStudents(students []domain.StudentRegisterPayload) (int, error) {
+	hashes := make([]string, len(students))
+	for i, s := range students {
+		h, err := hashOptional(s.Password)
+		if err != nil {
+			return 0, fmt.Errorf("import student (usn=%s): %w", s.USN, err)
+		}
+		hashes[i] = h
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending := map[string]bool{}
+	for _, s := range students {
+		if err := m.checkStudent(s, pending); err != nil {
+			return 0, fmt.Errorf("import student (usn=%s): %w", s.USN, err)
+		}
+		pending[s.USN] = true
+	}
+	for i, s := range students {
+		m.insertStudent(s, hashes[i])
+	}
+	return len(students), nil
+}
+
+func (m *MemoryRepo) ImportFaculty(faculty []domain.FacultyRegisterPayload) (int, error) {
+	hashes := make([]string, len(faculty))
+	for i, f := range faculty {
+		h, err := utils.HashPassword(f.Password)
+		if err != nil {
+			return 0, fmt.Errorf("import faculty (email=%s): hash password: %w", f.Email, err)
+		}
+		hashes[i] = h
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending := map[string]bool{}
+	for _, f := range faculty {
+		if err := m.checkFaculty(f, pending); err != nil {
+			return 0, fmt.Errorf("import faculty (email=%s): %w", f.Email, err)
+		}
+		pending[f.Email] = true
+	}
+	for i, f := range faculty {
+		m.insertFaculty(f, hashes[i])
+	}
+	return len(faculty), nil
+}
+
+func (m *MemoryRepo) ImportSubjects(subjects []domain.SubjectPayload) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending := map[string]bool{}
+	for _, s := range subjects {
+		if err := m.checkSubject(s, pending); err != nil {
+			return 0, fmt.Errorf("import subject (code=%s): %w", s.Code, err)
+		}
+		pending[s.Code] = true
+	}
+	for _, s := range subjects {
+		m.insertSubject(s)
+	}
+	return len(subjects), nil
+}
@@ -0,0 +1,654 @@
+// Package memory is an in-process implementation of every repository
+// interface in domain. It mirrors PostgresRepo's semantics (unique keys,
+// upserts, ownership checks and error messages) so services and handlers can
+// be exercised in tests and demos without a database.
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+// Compile-time checks that MemoryRepo stays a drop-in for PostgresRepo.
+var (
+	_ domain.StudentRepo          = (*MemoryRepo)(nil)
+	_ domain.SubjectRepo          = (*MemoryRepo)(nil)
+	_ domain.FacultyRepo          = (*MemoryRepo)(nil)
+	_ domain.AdminRepo            = (*MemoryRepo)(nil)
+	_ domain.AttendanceRepository = (*MemoryRepo)(nil)
+	_ domain.TermRepo             = (*MemoryRepo)(nil)
+	_ domain.ReportRepo           = (*MemoryRepo)(nil)
+	_ domain.DefaulterRepo        = (*MemoryRepo)(nil)
+	_ domain.ImportRepo           = (*MemoryRepo)(nil)
+	_ domain.NotificationRepo     = (*MemoryRepo)(nil)
+	_ domain.GuardianRepo         = (*MemoryRepo)(nil)
+	_ domain.WebhookRepo          = (*MemoryRepo)(nil)
+	_ domain.DepartmentRepo       = (*MemoryRepo)(nil)
+	_ domain.AnalyticsRepo        = (*MemoryRepo)(nil)
+	_ domain.StudentTrendRepo     = (*MemoryRepo)(nil)
+	_ domain.TeachingSessionRepo  = (*MemoryRepo)(nil)
+	_ domain.SubstitutionRepo     = (*MemoryRepo)(nil)
+	_ domain.AnomalyRepo          = (*MemoryRepo)(nil)
+)
+
+type student struct {
+	domain.Student
+	passwordHash string
+}
+
+type faculty struct {
+	domain.Faculty
+	passwordHash string
+	createdAt    time.Time
+}
+
+type subject struct {
+	id             int64
+	code           string
+	name           string
+	facultyID      int64
+	department     string
+	sem            int
+	plannedClasses int
+}
+
+type admin struct {
+	domain.Admin
+	passwordHash string
+}
+
+// attendance is a row of the attendance table; subjectID 0 stands for NULL.
+type attendance struct {
+	id         int64
+	usn        string
+	subjectID  int64
+	date       time.Time
+	status     string
+	recordedAt time.Time
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+type enrollment struct{ studentID, subjectID int64 }
+
+// MemoryRepo keeps every table in maps guarded by one lock. Reads return
+// copies, so callers never share state with the repository.
+type MemoryRepo struct {
+	mu  sync.RWMutex
+	cal *calendar.Calendar
+	seq map[string]int64
+
+	students     map[int64]*student
+	studentByUSN map[string]int64
+	faculty      map[int64]*faculty
+	subjects     map[int64]*subject
+	enrollments  map[enrollment]bool
+	admins       map[int64]*admin
+	attendance   map[int64]*attendance
+	condonations map[condonationKey]condonation
+	terms        map[int64]domain.Term
+	departments  map[string]*department
+	sessions     map[int64]*teachingSession
+
+	substitutions map[int64]*substitution
+	swaps         map[int64]*classSwap
+
+	events map[int64]*recognitionEvent
+	flags  map[int64]*anomalyFlag
+
+	advisors      map[advisorKey]int64
+	preferences   map[preferenceKey]bool
+	notifications []domain.NotificationLogEntry
+
+	guardians     map[int64]*guardian
+	guardianLinks map[guardianLink]bool
+
+	webhooks   map[int64]*webhook
+	deliveries map[int64]*domain.WebhookDelivery
+}
+
+// NewMemoryRepo returns an empty repository; like NewPostgresRepo, a nil cal
+// means UTC.
+func NewMemoryRepo(cal *calendar.Calendar) *MemoryRepo {
+	if cal == nil {
+		cal = calendar.New(nil)
+	}
+	return &MemoryRepo{
+		cal:           cal,
+		seq:           map[string]int64{},
+		students:      map[int64]*student{},
+		studentByUSN:  map[string]int64{},
+		faculty:       map[int64]*faculty{},
+		subjects:      map[int64]*subject{},
+		enrollments:   map[enrollment]bool{},
+		admins:        map[int64]*admin{},
+		attendance:    map[int64]*attendance{},
+		condonations:  map[condonationKey]condonation{},
+		terms:         map[int64]domain.Term{},
+		departments:   seedDepartments(),
+		sessions:      map[int64]*teachingSession{},
+		substitutions: map[int64]*substitution{},
+		swaps:         map[int64]*classSwap{},
+		events:        map[int64]*recognitionEvent{},
+		flags:         map[int64]*anomalyFlag{},
+		advisors:      map[advisorKey]int64{},
+		preferences:   map[preferenceKey]bool{},
+		guardians:     map[int64]*guardian{},
+		guardianLinks: map[guardianLink]bool{},
+		webhooks:      map[int64]*webhook{},
+		deliveries:    map[int64]*domain.WebhookDelivery{},
+	}
+}
+
+// next hands out SERIAL-style ids per table, starting at 1.
+func (m *MemoryRepo) next(table string) int64 {
+	m.seq[table]++
+	return m.seq[table]
+}
+
+// sortedKeys returns a map's int64 keys in ascending order, which stands in
+// for the primary-key order Postgres tends to return unordered rows in.
+func sortedKeys[V any](rows map[int64]V) []int64 {
+	ids := make([]int64, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// order compares two rows on one sort key, like a SQL ORDER BY column.
+type order[T any] func(a, b T) int
+
+// sortKeys maps the sort keys an endpoint accepts to comparisons.
+type sortKeys[T any] map[string]order[T]
+
+// paginate sorts rows by the requested key, breaking ties with tiebreak in
+// ascending order, and returns the requested page and the total row count.
+func paginate[T any](rows []T, page domain.PageRequest, keys sortKeys[T], defaultKey string, tiebreak order[T]) ([]T, int, error) {
+	key := page.Sort
+	if key == "" {
+		key = defaultKey
+	}
+	compare, ok := keys[key]
+	if !ok {
+		return nil, 0, domain.Invalid("sort", "oneof", "unsupported sort key %q", key)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		c := compare(rows[i], rows[j])
+		if page.Desc {
+			c = -c
+		}
+		if c == 0 {
+			c = tiebreak(rows[i], rows[j])
+		}
+		return c < 0
+	})
+
+	total := len(rows)
+	start := min(max(page.Offset, 0), total)
+	end := min(start+max(page.Limit, 0), total)
+	if start == end {
+		return nil, total, nil
+	}
+	return rows[start:end], total, nil
+}
+
+func day(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// inRange applies the date bounds of an AttendanceFilter to a class date.
+func inRange(date time.Time, f domain.AttendanceFilter) bool {
+	d := day(date)
+	if !f.From.IsZero() && d < day(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && d > day(f.To) {
+		return false
+	}
+	return true
+}
+
+func between(t, start, end time.Time) bool {
+	return !t.Before(start) && !t.After(end)
+}
+
+func (m *MemoryRepo) subjectByCode(code string) *subject {
+	for _, s := range m.subjects {
+		if s.code == code {
+			return s
+		}
+	}
+	return nil
+}
+
+//student
+
+func (m *MemoryRepo) StudentRegister(req domain.StudentRegisterPayload) (int64, error) {
+	pwHash, err := hashOptional(req.Password)
+	if err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkStudent(req, nil); err != nil {
+		return 0, err
+	}
+	return m.insertStudent(req, pwHash), nil
+}
+
+// hashOptional hashes a password unless it is empty; imported students may
+// have none yet.
+func hashOptional(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	pwHash, err := utils.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	return pwHash, nil
+}
+
+// checkStudent reports why req cannot be inserted; pending holds USNs
+// claimed earlier in the same batch.
+func (m *MemoryRepo) checkStudent(req domain.StudentRegisterPayload, pending map[string]bool) error {
+	if err := m.checkDepartment(req.Department, req.Sem); err != nil {
+		return err
+	}
+	if _, ok := m.studentByUSN[req.USN]; ok || pending[req.USN] {
+		return domain.Conflict("student with usn %s already exists", req.USN)
+	}
+	return nil
+}
+
+// insertStudent registers a student and enrolls them in the existing
+// subjects of their department and sem. Passwords are hashed beforehand so
+// nothing can fail once a batch starts writing.
+func (m *MemoryRepo) insertStudent(req domain.StudentRegisterPayload, pwHash string) int64 {
+	id := m.next("students")
+	m.students[id] = &student{
+		Student: domain.Student{
+			ID:         id,
+			USN:        req.USN,
+			Username:   req.Username,
+			Department: req.Department,
+			Sem:        req.Sem,
+			Email:      req.Email,
+		},
+		passwordHash: pwHash,
+	}
+	m.studentByUSN[req.USN] = id
+
+	for _, sub := range m.subjects {
+		if sub.department == req.Department && sub.sem == req.Sem {
+			m.enrollments[enrollment{id, sub.id}] = true
+		}
+	}
+	return id
+}
+
+func (m *MemoryRepo) LoginStudent(usn, password string) (string, error) {
+	m.mu.RLock()
+	id, ok := m.studentByUSN[usn]
+	var st student
+	if ok {
+		st = *m.students[id]
+	}
+	m.mu.RUnlock()
+
+	if !ok {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+	if err := utils.ComparePassword(st.passwordHash, password); err != nil {
+		return "", domain.Unauthorized("invalid credentials").Wrap(err)
+	}
+
+	token, err := utils.GenerateTokenForStudent(st.ID, usn)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return token, nil
+}
+
+// UpdateStudentInfo, like its SQL counterpart, silently ignores unknown ids.
+func (m *MemoryRepo) UpdateStudentInfo(studentID int, payload domain.StudentUpdatePayload) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkDepartment(payload.Department, payload.Sem); err != nil {
+		return err
+	}
+	if st, ok := m.students[int64(studentID)]; ok {
+		st.Username = payload.Username
+		st.Department = payload.Department
+		st.Sem = payload.Sem
+		st.Email = payload.Email
+	}
+	return nil
+}
+
+//subjects
+
+func (m *MemoryRepo) AddSubject(req domain.SubjectPayload) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkSubject(req, nil); err != nil {
+		return 0, err
+	}
+	return m.insertSubject(req), nil
+}
+
+func (m *MemoryRepo) checkSubject(req domain.SubjectPayload, pending map[string]bool) error {
+	if err := m.checkDepartment(req.Department, req.Sem); err != nil {
+		return err
+	}
+	if m.subjectByCode(req.Code) != nil || pending[req.Code] {
+		return domain.Conflict("subject %s already exists", req.Code)
+	}
+	if _, ok := m.faculty[req.FacultyID]; !ok {
+		return domain.NotFound("faculty not found: %d", req.FacultyID)
+	}
+	return nil
+}
+
+// insertSubject creates a subject and enrolls every student of its
+// department and sem.
+func (m *MemoryRepo) insertSubject(req domain.SubjectPayload) int64 {
+	id := m.next("subjects")
+	m.subjects[id] = &subject{
+		id:             id,
+		code:           req.Code,
+		name:           req.Name,
+		facultyID:      req.FacultyID,
+		department:     req.Department,
+		sem:            req.Sem,
+		plannedClasses: req.PlannedClasses,
+	}
+
+	for _, st := range m.students {
+		if st.Department == req.Department && st.Sem == req.Sem {
+			m.enrollments[enrollment{st.ID, id}] = true
+		}
+	}
+	return id
+}
+
+func (m *MemoryRepo) SetPlannedClasses(subjectCode string, planned int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		return domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	sub.plannedClasses = planned
+	return nil
+}
+
+func (m *MemoryRepo) subjectRow(sub *subject) domain.Subject {
+	s := domain.Subject{
+		ID:         sub.id,
+		Code:       sub.code,
+		Name:       sub.name,
+		Department: sub.department,
+		Sem:        sub.sem,
+	}
+	if f, ok := m.faculty[sub.facultyID]; ok {
+		s.Faculty = f.Name
+	}
+	return s
+}
+
+func (m *MemoryRepo) GetSubjectsByDeptAndSem(department string, sem int, page domain.PageRequest) ([]domain.Subject, int, error) {
+	m.mu.RLock()
+	var list []domain.Subject
+	for _, id := range sortedKeys(m.subjects) {
+		sub := m.subjects[id]
+		if sub.department == department && sub.sem == sem {
+			list = append(list, m.subjectRow(sub))
+		}
+	}
+	m.mu.RUnlock()
+
+	keys := sortKeys[domain.Subject]{
+		"code":    func(a, b domain.Subject) int { return strings.Compare(a.Code, b.Code) },
+		"name":    func(a, b domain.Subject) int { return strings.Compare(a.Name, b.Name) },
+		"faculty": func(a, b domain.Subject) int { return strings.Compare(a.Faculty, b.Faculty) },
+	}
+	list, total, err := paginate(list, page, keys, "code", func(a, b domain.Subject) int { return cmp.Compare(a.ID, b.ID) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("query subjects: %w", err)
+	}
+	return list, total, nil
+}
+
+func (m *MemoryRepo) GetSubjectsByStudentID(studentID int64) ([]domain.SubjectPayload, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []domain.SubjectPayload
+	for _, id := range sortedKeys(m.subjects) {
+		if !m.enrollments[enrollment{studentID, id}] {
+			continue
+		}
+		sub := m.subjects[id]
+		out = append(out, domain.SubjectPayload{
+			Code:           sub.code,
+			Name:           sub.name,
+			FacultyID:      sub.facultyID,
+			Department:     sub.department,
+			Sem:            sub.sem,
+			PlannedClasses: sub.plannedClasses,
+		})
+	}
+	return out, nil
+}
+
+func (m *MemoryRepo) GetSubjectsByFacultyID(facultyID int64) ([]domain.Subject, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Subject
+	for _, id := range sortedKeys(m.subjects) {
+		if sub := m.subjects[id]; sub.facultyID == facultyID {
+			list = append(list, m.subjectRow(sub))
+		}
+	}
+	return list, nil
+}
+
+//faculty
+
+func (m *MemoryRepo) CreateFaculty(req domain.FacultyRegisterPayload) (int64, error) {
+	pwHash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return 0, fmt.Errorf("hash password: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkFaculty(req, nil); err != nil {
+		return 0, err
+	}
+	return m.insertFaculty(req, pwHash), nil
+}
+
+func (m *MemoryRepo) checkFaculty(req domain.FacultyRegisterPayload, pending map[string]bool) error {
+	if err := m.checkDepartment(req.Department, 0); err != nil {
+		return err
+	}
+	for _, f := range m.faculty {
+		if f.Email == req.Email {
+			return domain.Conflict("faculty with email %s already exists", req.Email)
+		}
+	}
+	if pending[req.Email] {
+		return domain.Conflict("faculty with email %s already exists", req.Email)
+	}
+	return nil
+}
+
+func (m *MemoryRepo) insertFaculty(req domain.FacultyRegisterPayload, pwHash string) int64 {
+	id := m.next("faculty")
+	now := time.Now()
+	m.faculty[id] = &faculty{
+		Faculty: domain.Faculty{
+			ID:         id,
+			Name:       req.Name,
+			Email:      req.Email,
+			Department: req.Department,
+			CreatedAt:  now.Format(time.RFC3339),
+		},
+		passwordHash: pwHash,
+		createdAt:    now,
+	}
+	return id
+}
+
+func (m *MemoryRepo) AuthenticateFaculty(req domain.FacultyLoginPayload) (string, error) {
+	m.mu.RLock()
+	var found *faculty
+	for _, f := range m.faculty {
+		if f.Email == req.Email {
+			c := *f
+			found = &c
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if found == nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+	if err := utils.ComparePassword(found.passwordHash, req.Password); err != nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+
+	token, err := utils.GenerateTokenForFaculty(found.ID, req.Email)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return token, nil
+}
+
+func (m *MemoryRepo) GetAllFaculty(filter domain.FacultyFilter, page domain.PageRequest) ([]domain.Faculty, int, error) {
+	search := strings.ToLower(filter.Search)
+
+	m.mu.RLock()
+	type row struct {
+		domain.Faculty
+		createdAt time.Time
+	}
+	var rows []row
+	for _, id := range sortedKeys(m.faculty) {
+		f := m.faculty[id]
+		if filter.Department != "" && f.Department != filter.Department {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(f.Name), search) && !strings.Contains(strings.ToLower(f.Email), search) {
+			continue
+		}
+		// The list endpoint leaves created_at out, as the SQL does.
+		rf := f.Faculty
+		rf.CreatedAt = ""
+		rows = append(rows, row{rf, f.createdAt})
+	}
+	m.mu.RUnlock()
+
+	keys := sortKeys[row]{
+		"id":         func(a, b row) int { return cmp.Compare(a.ID, b.ID) },
+		"name":       func(a, b row) int { return strings.Compare(a.Name, b.Name) },
+		"email":      func(a, b row) int { return strings.Compare(a.Email, b.Email) },
+		"department": func(a, b row) int { return strings.Compare(a.Department, b.Department) },
+		"created_at": func(a, b row) int { return a.createdAt.Compare(b.createdAt) },
+	}
+	rows, total, err := paginate(rows, page, keys, "name", func(a, b row) int { return cmp.Compare(a.ID, b.ID) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("get faculty: %w", err)
+	}
+
+	var list []domain.Faculty
+	for _, r := range rows {
+		list = append(list, r.Faculty)
+	}
+	return list, total, nil
+}
+
+func (m *MemoryRepo) GetFacultyByID(facultyID int64) (domain.Faculty, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	f, ok := m.faculty[facultyID]
+	if !ok {
+		return domain.Faculty{}, domain.NotFound("faculty not found")
+	}
+	return f.Faculty, nil
+}
+
+//admins
+
+func (m *MemoryRepo) CreateAdmin(username, email, password string) (int64, error) {
+	pwHash, err := utils.HashPassword(password)
+	if err != nil {
+		return 0, fmt.Errorf("hash password: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, a := range m.admins {
+		if a.Email == email {
+			return 0, domain.Conflict("admin with email %s already exists", email)
+		}
+	}
+	id := m.next("admins")
+	m.admins[id] = &admin{Admin: domain.Admin{ID: id, Username: username, Email: email}, passwordHash: pwHash}
+	return id, nil
+}
+
+func (m *MemoryRepo) AuthenticateAdmin(req domain.AdminLoginPayload) (string, error) {
+	m.mu.RLock()
+	var found *admin
+	for _, a := range m.admins {
+		if a.Email == req.Email {
+			c := *a
+			found = &c
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if found == nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+	if err := utils.ComparePassword(found.passwordHash, req.Password); err != nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+
+	token, err := utils.GenerateTokenForAdmin(found.ID, req.Email)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return token, nil
+}
+
+func (m *MemoryRepo) CountAdmins() (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.admins), nil
+}
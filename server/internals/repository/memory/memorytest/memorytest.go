@@ -0,0 +1,119 @@
+// Package memorytest seeds memory repositories for service and handler
+// tests, so each test states only the faculty, subjects, students and classes
+// it depends on.
+package memorytest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+)
+
+// Password is the password of every faculty member the builder registers.
+const Password = "secret123"
+
+// Builder wraps a fresh memory repository. Its helpers fail the test on any
+// error, and fill in CSE and semester 5 wherever a department or semester is
+// left empty.
+type Builder struct {
+	Repo *memory.MemoryRepo
+
+	t      testing.TB
+	owners map[string]int64
+}
+
+func New(t testing.TB) *Builder {
+	return &Builder{Repo: memory.NewMemoryRepo(nil), t: t, owners: map[string]int64{}}
+}
+
+// Faculty registers a faculty member as <name>@college.edu and returns their
+// id.
+func (b *Builder) Faculty(name, department string) int64 {
+	b.t.Helper()
+	if department == "" {
+		department = "CSE"
+	}
+	id, err := b.Repo.CreateFaculty(domain.FacultyRegisterPayload{
+		Name: name, Email: strings.ToLower(name) + "@college.edu", Password: Password, Department: department,
+	})
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	return id
+}
+
+// HOD makes facultyID the head of department.
+func (b *Builder) HOD(department string, facultyID int64) {
+	b.t.Helper()
+	if err := b.Repo.UpdateDepartment(department, domain.DepartmentUpdatePayload{Name: department, HODFacultyID: &facultyID, Semesters: 8}); err != nil {
+		b.t.Fatal(err)
+	}
+}
+
+// Subjects adds each subject, remembering its faculty for Class.
+func (b *Builder) Subjects(subjects ...domain.SubjectPayload) {
+	b.t.Helper()
+	for _, s := range subjects {
+		if s.Department == "" {
+			s.Department = "CSE"
+		}
+		if s.Sem == 0 {
+			s.Sem = 5
+		}
+		if _, err := b.Repo.AddSubject(s); err != nil {
+			b.t.Fatal(err)
+		}
+		b.owners[s.Code] = s.FacultyID
+	}
+}
+
+// Students imports the students, which enrolls them in their semester's
+// subjects.
+func (b *Builder) Students(students ...domain.StudentRegisterPayload) {
+	b.t.Helper()
+	for i := range students {
+		if students[i].Department == "" {
+			students[i].Department = "CSE"
+		}
+		if students[i].Sem == 0 {
+			students[i].Sem = 5
+		}
+	}
+	if _, err := b.Repo.ImportStudents(students); err != nil {
+		b.t.Fatal(err)
+	}
+}
+
+// Capture records each student's status, keyed by USN, at 09:00 on date
+// without assigning it to a subject.
+func (b *Builder) Capture(date time.Time, statuses map[string]string) {
+	b.t.Helper()
+	usns := make([]string, 0, len(statuses))
+	for usn := range statuses {
+		usns = append(usns, usn)
+	}
+	sort.Strings(usns)
+
+	captures := make([]domain.AttendancePayload, 0, len(usns))
+	for _, usn := range usns {
+		captures = append(captures, domain.AttendancePayload{USN: usn, Status: statuses[usn], RecordedAt: date.Add(9 * time.Hour)})
+	}
+	if _, err := b.Repo.BulkMarkAttendance(captures); err != nil {
+		b.t.Fatal(err)
+	}
+}
+
+// Class captures like Capture, then has the subject's faculty assign the
+// 08:00-10:00 window on date to it.
+func (b *Builder) Class(subjectCode string, date time.Time, statuses map[string]string) {
+	b.t.Helper()
+	b.Capture(date, statuses)
+	start := date.Add(8 * time.Hour)
+	if _, _, err := b.Repo.AssignSubjectToTimeRange(b.owners[subjectCode], subjectCode, date, start, start.Add(2*time.Hour)); err != nil {
+		b.t.Fatal(err)
+	}
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+type advisorKey struct {
+	department string
+	sem        int
+}
+
+type preferenceKey struct {
+	recipientType string
+	recipientID   string
+	kind          string
+}
+
+func (m *MemoryRepo) GetStudentContacts() ([]domain.StudentContact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.StudentContact
+	for _, st := range m.students {
+		list = append(list, domain.StudentContact{
+			USN:        st.USN,
+			Name:       st.Username,
+			Email:      st.Email,
+			Department: st.Department,
+			Sem:        st.Sem,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].USN < list[j].USN })
+	return list, nil
+}
+
+func (m *MemoryRepo) GetClassAdvisors() ([]domain.ClassAdvisor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.ClassAdvisor
+	for key, facultyID := range m.advisors {
+		f, ok := m.faculty[facultyID]
+		if !ok {
+			continue
+		}
+		list = append(list, domain.ClassAdvisor{
+			Department: key.department,
+			Sem:        key.sem,
+			FacultyID:  facultyID,
+			Name:       f.Name,
+			Email:      f.Email,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if c := strings.Compare(list[i].Department, list[j].Department); c != 0 {
+			return c < 0
+		}
+		return list[i].Sem < list[j].Sem
+	})
+	return list, nil
+}
+
+func (m *MemoryRepo) SetClassAdvisor(req domain.ClassAdvisorPayload) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.faculty[req.FacultyID]; !ok {
+		return domain.NotFound("faculty not found: %d", req.FacultyID)
+	}
+	m.advisors[advisorKey{req.Department, req.Sem}] = req.FacultyID
+	return nil
+}
+
+func (m *MemoryRepo) GetAbsentees(department string, sem int, date time.Time) ([]domain.Absentee, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Absentee
+	for _, a := range m.attendance {
+		if a.status != "Absent" || a.subjectID == 0 || day(a.date) != day(date) {
+			continue
+		}
+		sub := m.subjects[a.subjectID]
+		if sub.department != department || sub.sem != sem {
+			continue
+		}
+		list = append(list, domain.Absentee{
+			USN:         a.usn,
+			StudentName: m.students[m.studentByUSN[a.usn]].Username,
+			SubjectCode: sub.code,
+			SubjectName: sub.name,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].USN != list[j].USN {
+			return list[i].USN < list[j].USN
+		}
+		return list[i].SubjectCode < list[j].SubjectCode
+	})
+	return list, nil
+}
+
+func (m *MemoryRepo) GetNotificationPreferences(recipientType, recipientID string) ([]domain.NotificationPreference, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.NotificationPreference
+	for key, enabled := range m.preferences {
+		if key.recipientType == recipientType && key.recipientID == recipientID {
+			list = append(list, domain.NotificationPreference{Kind: key.kind, Enabled: enabled})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Kind < list[j].Kind })
+	return list, nil
+}
+
+func (m *MemoryRepo) SetNotificationPreference(recipientType, recipientID, kind string, enabled bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.preferences[preferenceKey{recipientType, recipientID, kind}] = enabled
+	return nil
+}
+
+func (m *MemoryRepo) GetOptedOut(recipientType, kind string) (map[string]bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := map[string]bool{}
+	for key, enabled := range m.preferences {
+		if key.recipientType == recipientType && key.kind == kind && !enabled {
+			out[key.recipientID] = true
+		}
+	}
+	return out, nil
+}
+
+func (m *MemoryRepo) WasNotified(kind, recipientType, recipientID, dedupeKey string, since time.Time) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, e := range m.notifications {
+		if e.Kind == kind && e.RecipientType == recipientType && e.RecipientID == recipientID &&
+			e.DedupeKey == dedupeKey && e.Status == "sent" && !e.SentAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MemoryRepo) LogNotification(entry domain.NotificationLogEntry) error {
+	if entry.SentAt.IsZero() {
+		entry.SentAt = time.Now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.notifications = append(m.notifications, entry)
+	return nil
+}
+
+// NotificationLog returns every logged delivery attempt in insertion order.
+// It has no SQL counterpart; tests use it to see what a job sent.
+func (m *MemoryRepo) NotificationLog() []domain.NotificationLogEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]domain.NotificationLogEntry(nil), m.notifications...)
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+func (m *MemoryRepo) RepairAttendanceDates(dryRun bool) ([]domain.DateRepair, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]int64, 0, len(m.attendance))
+	for id := range m.attendance {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	all := make([]domain.DateRepair, 0, len(ids))
+	for _, id := range ids {
+		a := m.attendance[id]
+		all = append(all, domain.DateRepair{
+			AttendanceID: a.id,
+			USN:          a.usn,
+			SubjectID:    a.subjectID,
+			RecordedAt:   a.recordedAt,
+			OldDate:      a.date,
+			NewDate:      m.cal.DateOf(a.recordedAt),
+		})
+	}
+
+	repairs := domain.PlanDateRepairs(all)
+	if dryRun {
+		return repairs, nil
+	}
+	for _, r := range repairs {
+		if r.Conflict {
+			continue
+		}
+		a := m.attendance[r.AttendanceID]
+		a.date = r.NewDate
+		a.updatedAt = time.Now()
+	}
+	return repairs, nil
+}
@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+type condonationKey struct {
+	usn       string
+	subjectID int64
+}
+
+type condonation struct {
+	reason    string
+	grantedBy int64
+	createdAt time.Time
+}
+
+func (m *MemoryRepo) GetAttendanceRegister(subjectCode string, from, to time.Time) (domain.AttendanceRegister, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reg := domain.AttendanceRegister{From: from, To: to}
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		return reg, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	f, ok := m.faculty[sub.facultyID]
+	if !ok {
+		return reg, domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	reg.SubjectID, reg.SubjectCode, reg.SubjectName = sub.id, sub.code, sub.name
+	reg.Department, reg.Sem, reg.FacultyName = sub.department, sub.sem, f.Name
+
+	filter := domain.AttendanceFilter{From: from, To: to}
+	var sessions []*teachingSession
+	for _, s := range m.sessions {
+		if s.subjectID == sub.id && inRange(s.date, filter) {
+			sessions = append(sessions, s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].date.Equal(sessions[j].date) {
+			return sessions[i].date.Before(sessions[j].date)
+		}
+		return sessions[i].startTime < sessions[j].startTime
+	})
+	for _, s := range sessions {
+		reg.SessionsLogged = true
+		reg.Dates = append(reg.Dates, s.date)
+		reg.StartTimes = append(reg.StartTimes, s.startTime)
+	}
+	if !reg.SessionsLogged {
+		seen := map[string]bool{}
+		for _, a := range m.attendance {
+			if a.subjectID == sub.id && inRange(a.date, filter) && !seen[day(a.date)] {
+				seen[day(a.date)] = true
+				reg.Dates = append(reg.Dates, a.date)
+			}
+		}
+		sort.Slice(reg.Dates, func(i, j int) bool { return reg.Dates[i].Before(reg.Dates[j]) })
+	}
+	return reg, nil
+}
+
+func (m *MemoryRepo) StreamAttendanceRegisterRows(reg domain.AttendanceRegister, fn func(row domain.AttendanceRegisterRow) error) error {
+	columns := make(map[string][]int, len(reg.Dates))
+	for i, d := range reg.Dates {
+		columns[day(d)] = append(columns[day(d)], i)
+	}
+	filter := domain.AttendanceFilter{From: reg.From, To: reg.To}
+
+	// Build the rows under the lock and call fn after releasing it, so a slow
+	// writer cannot hold up other requests.
+	m.mu.RLock()
+	var rows []domain.AttendanceRegisterRow
+	for _, st := range m.students {
+		if !m.enrollments[enrollment{st.ID, reg.SubjectID}] {
+			continue
+		}
+		row := domain.AttendanceRegisterRow{USN: st.USN, StudentName: st.Username, Cells: make([]string, len(reg.Dates))}
+		for _, a := range m.attendance {
+			if a.usn != st.USN || a.subjectID != reg.SubjectID || !inRange(a.date, filter) {
+				continue
+			}
+			for _, i := range columns[day(a.date)] {
+				row.Total++
+				if a.status == "Present" {
+					row.Attended++
+					row.Cells[i] = "P"
+				} else {
+					row.Cells[i] = "A"
+				}
+			}
+		}
+		if reg.SessionsLogged {
+			row.Total = len(reg.Dates)
+		}
+		row.Percentage = percentage(row.Attended, row.Total)
+		rows = append(rows, row)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].USN < rows[j].USN })
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MemoryRepo) GetDefaulters(query domain.DefaulterQuery) ([]domain.Defaulter, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	held := map[int64]map[string]bool{}
+	for _, a := range m.attendance {
+		if a.subjectID == 0 || !inRange(a.date, query.Filter) {
+			continue
+		}
+		if held[a.subjectID] == nil {
+			held[a.subjectID] = map[string]bool{}
+		}
+		held[a.subjectID][day(a.date)] = true
+	}
+	logged := m.loggedSessions(query.Filter)
+
+	var list []domain.Defaulter
+	for e := range m.enrollments {
+		sub, st := m.subjects[e.subjectID], m.students[e.studentID]
+		if query.Department != "" && sub.department != query.Department {
+			continue
+		}
+		if query.Sem != 0 && sub.sem != query.Sem {
+			continue
+		}
+		if query.SubjectCode != "" && sub.code != query.SubjectCode {
+			continue
+		}
+		if query.FacultyID != 0 && sub.facultyID != query.FacultyID {
+			continue
+		}
+
+		d := domain.Defaulter{
+			USN:            st.USN,
+			StudentName:    st.Username,
+			Department:     st.Department,
+			Sem:            st.Sem,
+			SubjectCode:    sub.code,
+			SubjectName:    sub.name,
+			ClassesHeld:    len(held[sub.id]),
+			PlannedClasses: sub.plannedClasses,
+		}
+		if dates, ok := logged[sub.id]; ok {
+			d.ClassesHeld = sessionCount(dates)
+		}
+		// Every class held counts, marked or not.
+		d.TotalClasses = d.ClassesHeld
+		for _, a := range m.attendance {
+			if a.usn == st.USN && a.subjectID == sub.id && inRange(a.date, query.Filter) && a.status == "Present" {
+				d.Attended += classesOn(logged, sub.id, a.date)
+			}
+		}
+		if d.TotalClasses == 0 || 100*float64(d.Attended)/float64(d.TotalClasses) >= query.Threshold {
+			continue
+		}
+		if c, ok := m.condonations[condonationKey{st.USN, sub.id}]; ok {
+			d.Condoned, d.CondonationReason = true, c.reason
+		}
+		list = append(list, d)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if c := strings.Compare(list[i].SubjectCode, list[j].SubjectCode); c != 0 {
+			return c < 0
+		}
+		return list[i].USN < list[j].USN
+	})
+	return list, nil
+}
+
+func (m *MemoryRepo) SetCondonation(adminID int64, req domain.CondonationPayload) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := m.subjectByCode(req.SubjectCode)
+	if sub == nil {
+		return domain.NotFound("subject not found for code: %s", req.SubjectCode)
+	}
+	studentID, ok := m.studentByUSN[req.USN]
+	if !ok {
+		return domain.NotFound("student not found: %s", req.USN)
+	}
+	if !m.enrollments[enrollment{studentID, sub.id}] {
+		return domain.Invalid("subjectCode", "enrolled", "%s is not enrolled in %s", req.USN, req.SubjectCode)
+	}
+	m.condonations[condonationKey{req.USN, sub.id}] = condonation{reason: req.Reason, grantedBy: adminID, createdAt: time.Now()}
+	return nil
+}
+
+func (m *MemoryRepo) RemoveCondonation(usn, subjectCode string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := m.subjectByCode(subjectCode)
+	if sub == nil {
+		return domain.NotFound("condonation not found")
+	}
+	key := condonationKey{usn, sub.id}
+	if _, ok := m.condonations[key]; !ok {
+		return domain.NotFound("condonation not found")
+	}
+	delete(m.condonations, key)
+	return nil
+}
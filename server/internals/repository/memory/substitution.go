@@ -0,0 +1,226 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// substitution is a row of the substitutions table.
+type substitution struct {
+	id           int64
+	subjectID    int64
+	date         time.Time
+	substituteID int64
+	reason       string
+	assignedBy   int64
+	createdAt    time.Time
+}
+
+// classSwap is a row of the class_swaps table.
+type classSwap struct {
+	id                   int64
+	date                 time.Time
+	subjectID, otherID   int64
+	start, end           string
+	otherStart, otherEnd string
+	reason               string
+	assignedBy           int64
+	createdAt            time.Time
+}
+
+// substitutes reports whether facultyID covers subjectID's classes on date.
+func (m *MemoryRepo) substitutes(subjectID, facultyID int64, date time.Time) bool {
+	for _, s := range m.substitutions {
+		if s.subjectID == subjectID && s.substituteID == facultyID && day(s.date) == day(date) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryRepo) departmentSubject(department, code string) (*subject, error) {
+	sub := m.subjectByCode(code)
+	if sub == nil {
+		return nil, domain.NotFound("subject not found for code: %s", code)
+	}
+	if sub.department != department {
+		return nil, domain.Forbidden("subject %s is not in department %s", code, department)
+	}
+	return sub, nil
+}
+
+func (m *MemoryRepo) CreateSubstitution(assignedBy int64, s domain.Substitution) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub, err := m.departmentSubject(s.Department, s.SubjectCode)
+	if err != nil {
+		return 0, err
+	}
+	if sub.facultyID == s.SubstituteID {
+		return 0, domain.Invalid("substitute_faculty_id", "nefield", "the substitute already teaches %s", s.SubjectCode)
+	}
+	for _, existing := range m.substitutions {
+		if existing.subjectID == sub.id && day(existing.date) == day(s.Date) {
+			return 0, domain.Conflict("%s already has a substitute on %s", s.SubjectCode, day(s.Date))
+		}
+	}
+	if _, ok := m.faculty[s.SubstituteID]; !ok {
+		return 0, domain.NotFound("faculty not found: %d", s.SubstituteID)
+	}
+
+	id := m.next("substitutions")
+	m.substitutions[id] = &substitution{
+		id:           id,
+		subjectID:    sub.id,
+		date:         s.Date,
+		substituteID: s.SubstituteID,
+		reason:       s.Reason,
+		assignedBy:   assignedBy,
+		createdAt:    time.Now(),
+	}
+	return id, nil
+}
+
+func (m *MemoryRepo) DeleteSubstitution(department string, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.substitutions[id]
+	if !ok || m.subjects[s.subjectID].department != department {
+		return domain.NotFound("substitution not found: %d", id)
+	}
+	delete(m.substitutions, id)
+	return nil
+}
+
+func (m *MemoryRepo) GetSubstitutions(query domain.SubstitutionQuery) ([]domain.Substitution, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Substitution
+	for _, s := range m.substitutions {
+		sub := m.subjects[s.subjectID]
+		if query.Department != "" && sub.department != query.Department {
+			continue
+		}
+		if query.FacultyID != 0 && s.substituteID != query.FacultyID && sub.facultyID != query.FacultyID {
+			continue
+		}
+		if !inRange(s.date, query.Filter) {
+			continue
+		}
+		row := domain.Substitution{
+			ID:           s.id,
+			SubjectCode:  sub.code,
+			SubjectName:  sub.name,
+			Department:   sub.department,
+			Date:         s.date,
+			FacultyID:    sub.facultyID,
+			SubstituteID: s.substituteID,
+			Reason:       s.reason,
+			AssignedBy:   s.assignedBy,
+			CreatedAt:    s.createdAt,
+		}
+		if f, ok := m.faculty[sub.facultyID]; ok {
+			row.FacultyName = f.Name
+		}
+		if f, ok := m.faculty[s.substituteID]; ok {
+			row.SubstituteName = f.Name
+		}
+		list = append(list, row)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Date.Equal(list[j].Date) {
+			return list[i].Date.Before(list[j].Date)
+		}
+		return list[i].SubjectCode < list[j].SubjectCode
+	})
+	return list, nil
+}
+
+func (m *MemoryRepo) CreateClassSwap(assignedBy int64, s domain.ClassSwap) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub, err := m.departmentSubject(s.Department, s.SubjectCode)
+	if err != nil {
+		return 0, err
+	}
+	other, err := m.departmentSubject(s.Department, s.OtherSubjectCode)
+	if err != nil {
+		return 0, err
+	}
+
+	id := m.next("class_swaps")
+	m.swaps[id] = &classSwap{
+		id:         id,
+		date:       s.Date,
+		subjectID:  sub.id,
+		otherID:    other.id,
+		start:      s.Start,
+		end:        s.End,
+		otherStart: s.OtherStart,
+		otherEnd:   s.OtherEnd,
+		reason:     s.Reason,
+		assignedBy: assignedBy,
+		createdAt:  time.Now(),
+	}
+	return id, nil
+}
+
+func (m *MemoryRepo) DeleteClassSwap(department string, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.swaps[id]
+	if !ok || m.subjects[s.subjectID].department != department {
+		return domain.NotFound("class swap not found: %d", id)
+	}
+	delete(m.swaps, id)
+	return nil
+}
+
+func (m *MemoryRepo) GetClassSwaps(query domain.SubstitutionQuery) ([]domain.ClassSwap, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.ClassSwap
+	for _, s := range m.swaps {
+		sub, other := m.subjects[s.subjectID], m.subjects[s.otherID]
+		if query.Department != "" && sub.department != query.Department {
+			continue
+		}
+		if query.FacultyID != 0 && sub.facultyID != query.FacultyID && other.facultyID != query.FacultyID {
+			continue
+		}
+		if !inRange(s.date, query.Filter) {
+			continue
+		}
+		list = append(list, domain.ClassSwap{
+			ID:               s.id,
+			Department:       sub.department,
+			Date:             s.date,
+			SubjectCode:      sub.code,
+			SubjectName:      sub.name,
+			Start:            s.start,
+			End:              s.end,
+			OtherSubjectCode: other.code,
+			OtherSubjectName: other.name,
+			OtherStart:       s.otherStart,
+			OtherEnd:         s.otherEnd,
+			Reason:           s.reason,
+			AssignedBy:       s.assignedBy,
+			CreatedAt:        s.createdAt,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Date.Equal(list[j].Date) {
+			return list[i].Date.Before(list[j].Date)
+		}
+		return list[i].Start < list[j].Start
+	})
+	return list, nil
+}
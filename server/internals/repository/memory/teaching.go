@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// teachingSession is a row of the teaching_sessions table.
+type teachingSession struct {
+	id              int64
+	subjectID       int64
+	facultyID       int64
+	date            time.Time
+	startTime       string
+	durationMinutes int
+	topic           string
+	notes           string
+	createdAt       time.Time
+}
+
+// loggedSessions counts the sessions logged per subject and class date in
+// f's range; subjects without any are missing, and count their attendance
+// dates instead.
+func (m *MemoryRepo) loggedSessions(f domain.AttendanceFilter) map[int64]map[string]int {
+	logged := map[int64]map[string]int{}
+	for _, s := range m.sessions {
+		if !inRange(s.date, f) {
+			continue
+		}
+		if logged[s.subjectID] == nil {
+			logged[s.subjectID] = map[string]int{}
+		}
+		logged[s.subjectID][day(s.date)]++
+	}
+	return logged
+}
+
+// classesOn is how many classes a row of subjectID on date stands for: one
+// when the subject has no logged sessions, otherwise the sessions logged
+// that day, so a row off a logged date counts for none.
+func classesOn(logged map[int64]map[string]int, subjectID int64, date time.Time) int {
+	dates, ok := logged[subjectID]
+	if !ok {
+		return 1
+	}
+	return dates[day(date)]
+}
+
+// sessionCount is the number of sessions logged across dates.
+func sessionCount(dates map[string]int) int {
+	n := 0
+	for _, count := range dates {
+		n += count
+	}
+	return n
+}
+
+func (m *MemoryRepo) LogTeachingSession(facultyID int64, s domain.TeachingSession) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := m.subjectByCode(s.SubjectCode)
+	if sub == nil {
+		return 0, domain.NotFound("subject not found for code: %s", s.SubjectCode)
+	}
+	if sub.facultyID != facultyID && !m.substitutes(sub.id, facultyID, s.Date) {
+		return 0, domain.Forbidden("not authorized to log sessions of this subject")
+	}
+	for _, existing := range m.sessions {
+		if existing.subjectID == sub.id && day(existing.date) == day(s.Date) && existing.startTime == s.StartTime {
+			return 0, domain.Conflict("a session of %s is already logged on %s at %s", s.SubjectCode, day(s.Date), s.StartTime)
+		}
+	}
+
+	id := m.next("teaching_sessions")
+	m.sessions[id] = &teachingSession{
+		id:              id,
+		subjectID:       sub.id,
+		facultyID:       facultyID,
+		date:            s.Date,
+		startTime:       s.StartTime,
+		durationMinutes: s.DurationMinutes,
+		topic:           s.Topic,
+		notes:           s.Notes,
+		createdAt:       time.Now(),
+	}
+	return id, nil
+}
+
+func (m *MemoryRepo) sessionOwner(facultyID, sessionID int64) (*teachingSession, error) {
+	s, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, domain.NotFound("teaching session not found: %d", sessionID)
+	}
+	if s.facultyID != facultyID {
+		return nil, domain.Forbidden("not authorized to change this session")
+	}
+	return s, nil
+}
+
+func (m *MemoryRepo) UpdateTeachingSession(facultyID int64, s domain.TeachingSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	row, err := m.sessionOwner(facultyID, s.ID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range m.sessions {
+		if existing != row && existing.subjectID == row.subjectID && day(existing.date) == day(row.date) && existing.startTime == s.StartTime {
+			return domain.Conflict("another session of this subject is already logged at %s that day", s.StartTime)
+		}
+	}
+	row.startTime, row.durationMinutes, row.topic, row.notes = s.StartTime, s.DurationMinutes, s.Topic, s.Notes
+	return nil
+}
+
+func (m *MemoryRepo) DeleteTeachingSession(facultyID, sessionID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.sessionOwner(facultyID, sessionID); err != nil {
+		return err
+	}
+	delete(m.sessions, sessionID)
+	return nil
+}
+
+func (m *MemoryRepo) GetTeachingLog(query domain.TeachingLogQuery) ([]domain.TeachingSession, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.TeachingSession
+	for _, s := range m.sessions {
+		sub := m.subjects[s.subjectID]
+		if query.FacultyID != 0 && s.facultyID != query.FacultyID {
+			continue
+		}
+		if query.SubjectCode != "" && sub.code != query.SubjectCode {
+			continue
+		}
+		if !inRange(s.date, query.Filter) {
+			continue
+		}
+		row := domain.TeachingSession{
+			ID:              s.id,
+			SubjectCode:     sub.code,
+			SubjectName:     sub.name,
+			FacultyID:       s.facultyID,
+			Date:            s.date,
+			StartTime:       s.startTime,
+			DurationMinutes: s.durationMinutes,
+			Topic:           s.topic,
+			Notes:           s.notes,
+			CreatedAt:       s.createdAt,
+		}
+		if f, ok := m.faculty[s.facultyID]; ok {
+			row.FacultyName = f.Name
+		}
+		for _, a := range m.attendance {
+			if a.subjectID != s.subjectID || day(a.date) != day(s.date) {
+				continue
+			}
+			if a.status == "Present" {
+				row.Present++
+			} else {
+				row.Absent++
+			}
+		}
+		list = append(list, row)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Date.Equal(list[j].Date) {
+			return list[i].Date.Before(list[j].Date)
+		}
+		if list[i].StartTime != list[j].StartTime {
+			return list[i].StartTime < list[j].StartTime
+		}
+		return list[i].SubjectCode < list[j].SubjectCode
+	})
+	return list, nil
+}
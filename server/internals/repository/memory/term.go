@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// dateOnly drops the clock, as a DATE column does.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (m *MemoryRepo) CreateTerm(name string, start, end time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, t := range m.terms {
+		if t.Name == name {
+			return 0, domain.Conflict("term %s already exists", name)
+		}
+	}
+	id := m.next("terms")
+	m.terms[id] = domain.Term{ID: id, Name: name, StartDate: dateOnly(start), EndDate: dateOnly(end)}
+	return id, nil
+}
+
+func (m *MemoryRepo) GetTerms() ([]domain.Term, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Term
+	for _, id := range sortedKeys(m.terms) {
+		list = append(list, m.terms[id])
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].StartDate.Before(list[j].StartDate) })
+	return list, nil
+}
+
+func (m *MemoryRepo) GetTermByName(name string) (domain.Term, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, t := range m.terms {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return domain.Term{}, domain.NotFound("term not found: %s", name)
+}
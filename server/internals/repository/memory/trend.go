@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+func (m *MemoryRepo) GetStudentDailyAttendance(usn string, filter domain.AttendanceFilter) ([]domain.DailyAttendance, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	studentID, ok := m.studentByUSN[usn]
+	if !ok {
+		return nil, nil
+	}
+
+	// Subjects with logged sessions list each session instead of the rows,
+	// like the SQL backends.
+	type classDay struct {
+		subjectID int64
+		date      string
+	}
+	logged := m.loggedSessions(filter)
+	marked := map[classDay]string{}
+	var list []domain.DailyAttendance
+	for _, a := range m.attendance {
+		if a.usn != usn || a.subjectID == 0 || !inRange(a.date, filter) {
+			continue
+		}
+		if !m.enrollments[enrollment{studentID, a.subjectID}] {
+			continue
+		}
+		if _, ok := logged[a.subjectID]; ok {
+			marked[classDay{a.subjectID, day(a.date)}] = a.status
+			continue
+		}
+		sub := m.subjects[a.subjectID]
+		list = append(list, domain.DailyAttendance{Date: a.date, SubjectCode: sub.code, SubjectName: sub.name, Status: a.status})
+	}
+	for _, s := range m.sessions {
+		if !inRange(s.date, filter) || !m.enrollments[enrollment{studentID, s.subjectID}] {
+			continue
+		}
+		status, ok := marked[classDay{s.subjectID, day(s.date)}]
+		if !ok {
+			status = "Absent"
+		}
+		sub := m.subjects[s.subjectID]
+		list = append(list, domain.DailyAttendance{Date: s.date, SubjectCode: sub.code, SubjectName: sub.name, Status: status})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Date.Equal(list[j].Date) {
+			return list[i].Date.Before(list[j].Date)
+		}
+		return list[i].SubjectCode < list[j].SubjectCode
+	})
+	return list, nil
+}
+
+func (m *MemoryRepo) GetStudentProgress(usn string, filter domain.AttendanceFilter) ([]domain.SubjectProjection, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	studentID, ok := m.studentByUSN[usn]
+	if !ok {
+		return nil, nil
+	}
+
+	held := map[int64]map[string]bool{}
+	for _, a := range m.attendance {
+		if a.subjectID == 0 || !inRange(a.date, filter) {
+			continue
+		}
+		if held[a.subjectID] == nil {
+			held[a.subjectID] = map[string]bool{}
+		}
+		held[a.subjectID][day(a.date)] = true
+	}
+	logged := m.loggedSessions(filter)
+
+	var list []domain.SubjectProjection
+	for e := range m.enrollments {
+		if e.studentID != studentID {
+			continue
+		}
+		sub := m.subjects[e.subjectID]
+		p := domain.SubjectProjection{
+			SubjectCode:    sub.code,
+			SubjectName:    sub.name,
+			ClassesHeld:    len(held[sub.id]),
+			PlannedClasses: sub.plannedClasses,
+		}
+		for _, a := range m.attendance {
+			if a.usn == usn && a.subjectID == sub.id && inRange(a.date, filter) {
+				p.Total++
+				if a.status == "Present" {
+					p.Attended += classesOn(logged, sub.id, a.date)
+				}
+			}
+		}
+		if dates, ok := logged[sub.id]; ok {
+			p.Total, p.ClassesHeld = sessionCount(dates), sessionCount(dates)
+		}
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SubjectCode < list[j].SubjectCode })
+	return list, nil
+}
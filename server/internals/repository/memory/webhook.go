@@ -0,0 +1,234 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+type webhook struct {
+	domain.Webhook
+	secret string
+}
+
+// copyDelivery detaches a delivery from the stored row, pointers included.
+func copyDelivery(d *domain.WebhookDelivery) domain.WebhookDelivery {
+	c := *d
+	c.Payload = append([]byte(nil), d.Payload...)
+	if d.NextAttemptAt != nil {
+		t := *d.NextAttemptAt
+		c.NextAttemptAt = &t
+	}
+	if d.LastStatusCode != nil {
+		code := *d.LastStatusCode
+		c.LastStatusCode = &code
+	}
+	if d.DeliveredAt != nil {
+		t := *d.DeliveredAt
+		c.DeliveredAt = &t
+	}
+	return c
+}
+
+func (m *MemoryRepo) CreateWebhook(req domain.WebhookPayload) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.next("webhooks")
+	m.webhooks[id] = &webhook{
+		Webhook: domain.Webhook{
+			ID:  id,
+			URL: req.URL,
+			// Round-trip through the stored form, as GetWebhooks would.
+			EventTypes: strings.Split(strings.Join(req.EventTypes, ","), ","),
+			Active:     true,
+			CreatedAt:  time.Now(),
+		},
+		secret: req.Secret,
+	}
+	return id, nil
+}
+
+func (m *MemoryRepo) GetWebhooks() ([]domain.Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []domain.Webhook
+	for _, id := range sortedKeys(m.webhooks) {
+		w := m.webhooks[id].Webhook
+		w.EventTypes = append([]string(nil), w.EventTypes...)
+		list = append(list, w)
+	}
+	return list, nil
+}
+
+// DeleteWebhook also drops the webhook's deliveries, like ON DELETE CASCADE.
+func (m *MemoryRepo) DeleteWebhook(webhookID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.webhooks[webhookID]; !ok {
+		return domain.NotFound("webhook not found: %d", webhookID)
+	}
+	delete(m.webhooks, webhookID)
+	for id, d := range m.deliveries {
+		if d.WebhookID == webhookID {
+			delete(m.deliveries, id)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryRepo) EnqueueDeliveries(eventType string, payload []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	n := 0
+	for _, wid := range sortedKeys(m.webhooks) {
+		w := m.webhooks[wid]
+		events := strings.Join(w.EventTypes, ",")
+		if !w.Active || (!strings.Contains(","+events+",", ","+domain.WebhookAllEvents+",") && !strings.Contains(","+events+",", ","+eventType+",")) {
+			continue
+		}
+		id := m.next("webhook_deliveries")
+		next := now
+		m.deliveries[id] = &domain.WebhookDelivery{
+			ID:            id,
+			WebhookID:     wid,
+			EventType:     eventType,
+			Payload:       append([]byte(nil), payload...),
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: &next,
+			CreatedAt:     now,
+		}
+		n++
+	}
+	return n, nil
+}
+
+func (m *MemoryRepo) ClaimDueDeliveries(limit int, lease time.Duration) ([]domain.PendingDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var due []*domain.WebhookDelivery
+	for _, id := range sortedKeys(m.deliveries) {
+		d := m.deliveries[id]
+		if d.Status == domain.DeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(*due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	var list []domain.PendingDelivery
+	for _, d := range due {
+		next := now.Add(lease)
+		d.NextAttemptAt = &next
+
+		w := m.webhooks[d.WebhookID]
+		claimed := copyDelivery(d)
+		claimed.NextAttemptAt, claimed.LastStatusCode, claimed.LastError = nil, nil, ""
+		list = append(list, domain.PendingDelivery{WebhookDelivery: claimed, URL: w.URL, Secret: w.secret})
+	}
+	return list, nil
+}
+
+func (m *MemoryRepo) MarkDeliverySucceeded(deliveryID int64, statusCode int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if d, ok := m.deliveries[deliveryID]; ok {
+		now := time.Now()
+		d.Status = domain.DeliveryDelivered
+		d.Attempts++
+		d.LastStatusCode = &statusCode
+		d.LastError = ""
+		d.NextAttemptAt = nil
+		d.DeliveredAt = &now
+	}
+	return nil
+}
+
+func (m *MemoryRepo) MarkDeliveryFailed(deliveryID int64, statusCode int, errMsg string, nextAttempt *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d, ok := m.deliveries[deliveryID]
+	if !ok {
+		return nil
+	}
+	d.Status = domain.DeliveryPending
+	if nextAttempt == nil {
+		d.Status = domain.DeliveryDead
+	}
+	d.Attempts++
+	d.LastStatusCode = nil
+	if statusCode != 0 {
+		d.LastStatusCode = &statusCode
+	}
+	d.LastError = errMsg
+	d.NextAttemptAt = nil
+	if nextAttempt != nil {
+		t := *nextAttempt
+		d.NextAttemptAt = &t
+	}
+	return nil
+}
+
+func (m *MemoryRepo) GetDeliveries(filter domain.DeliveryFilter, page domain.PageRequest) ([]domain.WebhookDelivery, int, error) {
+	m.mu.RLock()
+	var list []domain.WebhookDelivery
+	for _, id := range sortedKeys(m.deliveries) {
+		d := m.deliveries[id]
+		if (filter.WebhookID == 0 || d.WebhookID == filter.WebhookID) && (filter.Status == "" || d.Status == filter.Status) {
+			list = append(list, copyDelivery(d))
+		}
+	}
+	m.mu.RUnlock()
+
+	keys := sortKeys[domain.WebhookDelivery]{
+		"created_at": func(a, b domain.WebhookDelivery) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		"attempts":   func(a, b domain.WebhookDelivery) int { return cmp.Compare(a.Attempts, b.Attempts) },
+		"status":     func(a, b domain.WebhookDelivery) int { return strings.Compare(a.Status, b.Status) },
+	}
+	list, total, err := paginate(list, page, keys, "created_at", func(a, b domain.WebhookDelivery) int { return cmp.Compare(a.ID, b.ID) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("get deliveries: %w", err)
+	}
+	return list, total, nil
+}
+
+func (m *MemoryRepo) GetDelivery(deliveryID int64) (domain.WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d, ok := m.deliveries[deliveryID]
+	if !ok {
+		return domain.WebhookDelivery{}, domain.NotFound("delivery not found: %d", deliveryID)
+	}
+	return copyDelivery(d), nil
+}
+
+func (m *MemoryRepo) ReplayDelivery(deliveryID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d, ok := m.deliveries[deliveryID]
+	if !ok {
+		return domain.NotFound("delivery not found: %d", deliveryID)
+	}
+	now := time.Now()
+	d.Status = domain.DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = &now
+	d.DeliveredAt = nil
+	return nil
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// Migration is one versioned schema change, loaded from
+// migrations/<version>_<name>.up.sql and the matching .down.sql.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState is a migration together with when it was applied, if it was.
+type MigrationState struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationLockID serializes migrators across server replicas.
+const migrationLockID = 72_617_001
+
+// LoadMigrations reads the migrations/*.sql scripts of fsys. Each storage
+// backend embeds its own dialect of the same numbered migrations.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, file := range files {
+		m := migrationFile.FindStringSubmatch(path.Base(file))
+		if m == nil {
+			return nil, fmt.Errorf("unexpected migration file name %s", file)
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down scripts", mig.Version, mig.Name)
+		}
+		list = append(list, *mig)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// MigrationStates pairs every migration with when it was applied, if it was.
+func MigrationStates(migrations []Migration, applied map[int]time.Time) []MigrationState {
+	states := make([]MigrationState, 0, len(migrations))
+	for _, m := range migrations {
+		state := MigrationState{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			state.AppliedAt = &at
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// CheckApplied fails unless every migration has been applied and applied
+// holds none this binary does not know about.
+func CheckApplied(migrations []Migration, applied map[int]time.Time) error {
+	known := map[int]bool{}
+	var pending []int
+	for _, m := range migrations {
+		known[m.Version] = true
+		if _, ok := applied[m.Version]; !ok {
+			pending = append(pending, m.Version)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("pending migrations %v", pending)
+	}
+	for version := range applied {
+		if !known[version] {
+			return fmt.Errorf("database has migration %d which this build does not know; deploy a newer build", version)
+		}
+	}
+	return nil
+}
+
+func (p *PostgresRepo) ensureMigrationTable() error {
+	_, err := p.db.Exec(`
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+	    version INT PRIMARY KEY,
+	    name VARCHAR(200) NOT NULL,
+	    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	);`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresRepo) appliedMigrations() (map[int]time.Time, error) {
+	rows, err := p.db.Query(`SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// MigrationStatus lists every known migration and whether it is applied.
+func (p *PostgresRepo) MigrationStatus() ([]MigrationState, error) {
+	migrations, err := LoadMigrations(migrationFS)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ensureMigrationTable(); err != nil {
+		return nil, err
+	}
+	applied, err := p.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return MigrationStates(migrations, applied), nil
+}
+
+// MigrateUp applies every pending migration in order, each in its own
+// transaction, and returns the versions it applied.
+func (p *PostgresRepo) MigrateUp() ([]int, error) {
+	migrations, err := LoadMigrations(migrationFS)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ensureMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for _, m := range migrations {
+		ran, err := p.runMigration(m, true)
+		if err != nil {
+			return done, err
+		}
+		if ran {
+			done = append(done, m.Version)
+		}
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the latest steps applied migrations and returns the
+// versions it reverted.
+func (p *PostgresRepo) MigrateDown(steps int) ([]int, error) {
+	migrations, err := LoadMigrations(migrationFS)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ensureMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		ran, err := p.runMigration(migrations[i], false)
+		if err != nil {
+			return done, err
+		}
+		if ran {
+			done = append(done, migrations[i].Version)
+		}
+	}
+	return done, nil
+}
+
+// runMigration applies (up) or reverts (down) m unless that already happened.
+// The advisory lock makes a concurrent migrator wait and then skip.
+func (p *PostgresRepo) runMigration(m Migration, up bool) (bool, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1);`, migrationLockID); err != nil {
+		return false, fmt.Errorf("lock migrations: %w", err)
+	}
+
+	var applied bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1);`, m.Version).Scan(&applied); err != nil {
+		return false, fmt.Errorf("check migration %d: %w", m.Version, err)
+	}
+	if applied == up {
+		return false, nil
+	}
+
+	script, record := m.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`
+	if !up {
+		script, record = m.Down, `DELETE FROM schema_migrations WHERE version = $1 AND name = $2;`
+	}
+
+	if _, err := tx.Exec(script); err != nil {
+		return false, fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+	}
+	if _, err := tx.Exec(record, m.Version, m.Name); err != nil {
+		return false, fmt.Errorf("record migration %d: %w", m.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit migration %d: %w", m.Version, err)
+	}
+	return true, nil
+}
+
+// CheckSchema fails unless every embedded migration has been applied and the
+// database carries none this binary does not know about.
+func (p *PostgresRepo) CheckSchema() error {
+	migrations, err := LoadMigrations(migrationFS)
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	if err := p.db.QueryRow(`SELECT to_regclass('schema_migrations') IS NOT NULL;`).Scan(&exists); err != nil {
+		return fmt.Errorf("check schema_migrations: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("database has no schema_migrations table; %d migrations pending", len(migrations))
+	}
+
+	applied, err := p.appliedMigrations()
+	if err != nil {
+		return err
+	}
+	return CheckApplied(migrations, applied)
+}
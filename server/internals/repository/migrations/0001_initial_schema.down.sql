@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS attendance;
+DROP TABLE IF EXISTS student_subjects;
+DROP TABLE IF EXISTS subjects;
+DROP TABLE IF EXISTS students;
+DROP TABLE IF EXISTS faculty;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notification_log;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS class_advisors;
+ALTER TABLE students DROP COLUMN IF EXISTS email;
@@ -0,0 +1,33 @@
+ALTER TABLE students ADD COLUMN IF NOT EXISTS email VARCHAR(255) NULL;
+
+CREATE TABLE IF NOT EXISTS class_advisors (
+    department VARCHAR(50) NOT NULL,
+    sem INT NOT NULL,
+    faculty_id INT NOT NULL,
+    PRIMARY KEY (department, sem),
+    CONSTRAINT fk_advisor_faculty FOREIGN KEY (faculty_id) REFERENCES faculty(faculty_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    recipient_type VARCHAR(20) NOT NULL,
+    recipient_id VARCHAR(100) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (recipient_type, recipient_id, kind)
+);
+
+CREATE TABLE IF NOT EXISTS notification_log (
+    log_id SERIAL PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    recipient_type VARCHAR(20) NOT NULL,
+    recipient_id VARCHAR(100) NOT NULL,
+    dedupe_key VARCHAR(200) NOT NULL,
+    channel VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('sent', 'failed')),
+    error TEXT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_log_lookup
+    ON notification_log(kind, recipient_type, recipient_id, dedupe_key, sent_at);
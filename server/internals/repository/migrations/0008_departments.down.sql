@@ -0,0 +1,5 @@
+-- The normalized department values are kept.
+ALTER TABLE subjects DROP CONSTRAINT IF EXISTS fk_subject_department;
+ALTER TABLE students DROP CONSTRAINT IF EXISTS fk_student_department;
+ALTER TABLE faculty DROP CONSTRAINT IF EXISTS fk_faculty_department;
+DROP TABLE IF EXISTS departments;
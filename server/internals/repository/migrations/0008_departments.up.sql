@@ -0,0 +1,68 @@
+-- Departments become an entity. Existing free-text values are normalized
+-- (trimmed, upper-cased and common spellings mapped to their code), every
+-- value left over becomes a department of its own, and faculty, students
+-- and subjects then refer to departments by code.
+
+CREATE TABLE IF NOT EXISTS departments (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    hod_faculty_id INT NULL,
+    semesters INT NOT NULL DEFAULT 8 CHECK (semesters > 0),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT fk_department_hod FOREIGN KEY (hod_faculty_id) REFERENCES faculty(faculty_id) ON DELETE SET NULL
+);
+
+INSERT INTO departments (code, name) VALUES
+    ('CSE', 'Computer Science and Engineering'),
+    ('ISE', 'Information Science and Engineering'),
+    ('ECE', 'Electronics and Communication Engineering'),
+    ('EEE', 'Electrical and Electronics Engineering'),
+    ('ME', 'Mechanical Engineering'),
+    ('CV', 'Civil Engineering'),
+    ('AIML', 'Artificial Intelligence and Machine Learning')
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TEMP TABLE department_aliases (
+    alias VARCHAR(150) PRIMARY KEY,
+    code VARCHAR(50) NOT NULL
+);
+
+INSERT INTO department_aliases (alias, code) VALUES
+    ('CS', 'CSE'), ('COMPUTER SCIENCE', 'CSE'),
+    ('COMPUTER SCIENCE AND ENGINEERING', 'CSE'), ('COMPUTER SCIENCE & ENGINEERING', 'CSE'),
+    ('IS', 'ISE'), ('INFORMATION SCIENCE', 'ISE'),
+    ('INFORMATION SCIENCE AND ENGINEERING', 'ISE'), ('INFORMATION SCIENCE & ENGINEERING', 'ISE'),
+    ('EC', 'ECE'), ('ELECTRONICS AND COMMUNICATION', 'ECE'),
+    ('ELECTRONICS AND COMMUNICATION ENGINEERING', 'ECE'), ('ELECTRONICS & COMMUNICATION ENGINEERING', 'ECE'),
+    ('EE', 'EEE'), ('ELECTRICAL AND ELECTRONICS', 'EEE'),
+    ('ELECTRICAL AND ELECTRONICS ENGINEERING', 'EEE'), ('ELECTRICAL & ELECTRONICS ENGINEERING', 'EEE'),
+    ('MECH', 'ME'), ('MECHANICAL', 'ME'), ('MECHANICAL ENGINEERING', 'ME'),
+    ('CIVIL', 'CV'), ('CIVIL ENGINEERING', 'CV'),
+    ('AI&ML', 'AIML'), ('AI ML', 'AIML'), ('AI-ML', 'AIML'),
+    ('ARTIFICIAL INTELLIGENCE AND MACHINE LEARNING', 'AIML'),
+    ('ARTIFICIAL INTELLIGENCE & MACHINE LEARNING', 'AIML');
+
+UPDATE faculty SET department = COALESCE(
+    (SELECT code FROM department_aliases WHERE alias = UPPER(TRIM(faculty.department))),
+    UPPER(TRIM(department)));
+UPDATE students SET department = COALESCE(
+    (SELECT code FROM department_aliases WHERE alias = UPPER(TRIM(students.department))),
+    UPPER(TRIM(department)));
+UPDATE subjects SET department = COALESCE(
+    (SELECT code FROM department_aliases WHERE alias = UPPER(TRIM(subjects.department))),
+    UPPER(TRIM(department)));
+
+DROP TABLE department_aliases;
+
+INSERT INTO departments (code, name)
+SELECT department, department FROM faculty
+UNION SELECT department, department FROM students
+UNION SELECT department, department FROM subjects
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE faculty ADD CONSTRAINT fk_faculty_department
+    FOREIGN KEY (department) REFERENCES departments(code) ON DELETE RESTRICT;
+ALTER TABLE students ADD CONSTRAINT fk_student_department
+    FOREIGN KEY (department) REFERENCES departments(code) ON DELETE RESTRICT;
+ALTER TABLE subjects ADD CONSTRAINT fk_subject_department
+    FOREIGN KEY (department) REFERENCES departments(code) ON DELETE RESTRICT;
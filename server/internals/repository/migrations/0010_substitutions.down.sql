@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS class_swaps;
+DROP TABLE IF EXISTS substitutions;
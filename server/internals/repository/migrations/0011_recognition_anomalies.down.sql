@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attendance_flags;
+DROP TABLE IF EXISTS recognition_events;
@@ -0,0 +1,37 @@
+-- Every capture the recognizer sends, as received. Screening reads them back
+-- to spot a student seen in two rooms at once or captured over and over.
+CREATE TABLE IF NOT EXISTS recognition_events (
+    event_id SERIAL PRIMARY KEY,
+    usn VARCHAR(50) NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    date DATE NOT NULL,
+    room VARCHAR(50) NOT NULL DEFAULT '',
+    confidence DOUBLE PRECISION NULL,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT fk_recognition_student FOREIGN KEY (usn) REFERENCES students(usn) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_recognition_events_usn
+    ON recognition_events(usn, recorded_at);
+
+-- A capture held back for faculty review instead of being recorded.
+-- reasons is a comma separated list of anomaly kinds; attendance_id is the
+-- row an accepted flag was recorded as.
+CREATE TABLE IF NOT EXISTS attendance_flags (
+    flag_id SERIAL PRIMARY KEY,
+    event_id INT NOT NULL,
+    reasons TEXT NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    review VARCHAR(10) NOT NULL DEFAULT 'open' CHECK (review IN ('open', 'accepted', 'rejected')),
+    reviewed_by INT NULL,
+    reviewed_at TIMESTAMPTZ NULL,
+    attendance_id INT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT uq_flag_event UNIQUE (event_id),
+    CONSTRAINT fk_flag_event FOREIGN KEY (event_id) REFERENCES recognition_events(event_id) ON DELETE CASCADE,
+    CONSTRAINT fk_flag_reviewer FOREIGN KEY (reviewed_by) REFERENCES faculty(faculty_id) ON DELETE SET NULL,
+    CONSTRAINT fk_flag_attendance FOREIGN KEY (attendance_id) REFERENCES attendance(attendance_id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_flags_review ON attendance_flags(review);
@@ -0,0 +1,7 @@
+ALTER TABLE recognition_events
+    DROP COLUMN IF EXISTS face_crop,
+    DROP COLUMN IF EXISTS bbox_height,
+    DROP COLUMN IF EXISTS bbox_width,
+    DROP COLUMN IF EXISTS bbox_y,
+    DROP COLUMN IF EXISTS bbox_x,
+    DROP COLUMN IF EXISTS device_id;
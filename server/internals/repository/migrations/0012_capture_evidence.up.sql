@@ -0,0 +1,9 @@
+-- Evidence sent with a capture: the camera that took it, where the face was
+-- in the frame, and the key of the face crop in the blob store.
+ALTER TABLE recognition_events
+    ADD COLUMN IF NOT EXISTS device_id VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS bbox_x INT NULL,
+    ADD COLUMN IF NOT EXISTS bbox_y INT NULL,
+    ADD COLUMN IF NOT EXISTS bbox_width INT NULL,
+    ADD COLUMN IF NOT EXISTS bbox_height INT NULL,
+    ADD COLUMN IF NOT EXISTS face_crop VARCHAR(64) NULL;
@@ -0,0 +1,10 @@
+-- Keeps the earliest session of each subject and date; the others cannot
+-- be represented with one session per date.
+DELETE FROM teaching_sessions t
+USING teaching_sessions e
+WHERE e.subject_id = t.subject_id AND e.date = t.date
+  AND (e.start_time, e.session_id) < (t.start_time, t.session_id);
+
+ALTER TABLE teaching_sessions DROP CONSTRAINT IF EXISTS uq_teaching_session;
+ALTER TABLE teaching_sessions
+    ADD CONSTRAINT uq_teaching_session UNIQUE (subject_id, date);
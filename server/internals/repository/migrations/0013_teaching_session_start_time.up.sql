@@ -0,0 +1,6 @@
+-- A subject can be taught more than once a day (a lecture and a tutorial,
+-- or a double period), so a session is keyed by its start time too and
+-- every one of them counts as a class held.
+ALTER TABLE teaching_sessions DROP CONSTRAINT IF EXISTS uq_teaching_session;
+ALTER TABLE teaching_sessions
+    ADD CONSTRAINT uq_teaching_session UNIQUE (subject_id, date, start_time);
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+func (p *SQLRepo) GetStudentContacts() ([]domain.StudentContact, error) {
+	rows, err := p.db.Query(`
+	SELECT usn, username, COALESCE(email, ''), department, sem
+	FROM students
+	ORDER BY usn;`)
+	if err != nil {
+		return nil, fmt.Errorf("get student contacts: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.StudentContact
+	for rows.Next() {
+		var s domain.StudentContact
+		if err := rows.Scan(&s.USN, &s.Name, &s.Email, &s.Department, &s.Sem); err != nil {
+			return nil, fmt.Errorf("scan student contact: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) GetClassAdvisors() ([]domain.ClassAdvisor, error) {
+	rows, err := p.db.Query(`
+	SELECT ca.department, ca.sem, f.faculty_id, f.faculty_name, f.email
+	FROM class_advisors ca
+	JOIN faculty f ON f.faculty_id = ca.faculty_id
+	ORDER BY ca.department, ca.sem;`)
+	if err != nil {
+		return nil, fmt.Errorf("get class advisors: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.ClassAdvisor
+	for rows.Next() {
+		var a domain.ClassAdvisor
+		if err := rows.Scan(&a.Department, &a.Sem, &a.FacultyID, &a.Name, &a.Email); err != nil {
+			return nil, fmt.Errorf("scan class advisor: %w", err)
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) SetClassAdvisor(req domain.ClassAdvisorPayload) error {
+	_, err := p.db.Exec(`
+	INSERT INTO class_advisors (department, sem, faculty_id)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (department, sem) DO UPDATE SET faculty_id = EXCLUDED.faculty_id;`,
+		req.Department, req.Sem, req.FacultyID)
+	if err != nil {
+		if p.isForeignKeyViolation(err) {
+			return domain.NotFound("faculty not found: %d", req.FacultyID).Wrap(err)
+		}
+		return fmt.Errorf("set class advisor: %w", err)
+	}
+	return nil
+}
+
+// GetAbsentees lists the absences recorded on date in subjects of the given
+// department and sem.
+func (p *SQLRepo) GetAbsentees(department string, sem int, date time.Time) ([]domain.Absentee, error) {
+	rows, err := p.db.Query(`
+	SELECT a.usn, st.username, subj.subject_code, subj.subject_name
+	FROM attendance a
+	JOIN students st ON st.usn = a.usn
+	JOIN subjects subj ON subj.subject_id = a.subject_id
+	WHERE a.status = 'Absent' AND a.date = $3
+	  AND subj.department = $1 AND subj.sem = $2
+	ORDER BY a.usn, subj.subject_code;`, department, sem, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("get absentees: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Absentee
+	for rows.Next() {
+		var a domain.Absentee
+		if err := rows.Scan(&a.USN, &a.StudentName, &a.SubjectCode, &a.SubjectName); err != nil {
+			return nil, fmt.Errorf("scan absentee: %w", err)
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) GetNotificationPreferences(recipientType, recipientID string) ([]domain.NotificationPreference, error) {
+	rows, err := p.db.Query(`
+	SELECT kind, enabled FROM notification_preferences
+	WHERE recipient_type = $1 AND recipient_id = $2
+	ORDER BY kind;`, recipientType, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("get notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.NotificationPreference
+	for rows.Next() {
+		var pref domain.NotificationPreference
+		if err := rows.Scan(&pref.Kind, &pref.Enabled); err != nil {
+			return nil, fmt.Errorf("scan notification preference: %w", err)
+		}
+		list = append(list, pref)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) SetNotificationPreference(recipientType, recipientID, kind string, enabled bool) error {
+	_, err := p.db.Exec(`
+	INSERT INTO notification_preferences (recipient_type, recipient_id, kind, enabled)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (recipient_type, recipient_id, kind)
+	DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = `+p.dialect.Now+`;`,
+		recipientType, recipientID, kind, enabled)
+	if err != nil {
+		return fmt.Errorf("set notification preference: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLRepo) GetOptedOut(recipientType, kind string) (map[string]bool, error) {
+	out := map[string]bool{}
+	rows, err := p.db.Query(`
+	SELECT recipient_id FROM notification_preferences
+	WHERE recipient_type = $1 AND kind = $2 AND NOT enabled;`, recipientType, kind)
+	if err != nil {
+		return out, fmt.Errorf("get opted out recipients: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return out, fmt.Errorf("scan opted out recipient: %w", err)
+		}
+		out[id] = true
+	}
+	return out, rows.Err()
+}
+
+func (p *SQLRepo) WasNotified(kind, recipientType, recipientID, dedupeKey string, since time.Time) (bool, error) {
+	var exists bool
+	err := p.db.QueryRow(`
+	SELECT EXISTS (
+	    SELECT 1 FROM notification_log
+	    WHERE kind = $1 AND recipient_type = $2 AND recipient_id = $3
+	      AND dedupe_key = $4 AND status = 'sent' AND sent_at >= $5
+	);`, kind, recipientType, recipientID, dedupeKey, p.dialect.Stamp(since)).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check notification log: %w", err)
+	}
+	return exists, nil
+}
+
+func (p *SQLRepo) LogNotification(entry domain.NotificationLogEntry) error {
+	sentAt := entry.SentAt
+	if sentAt.IsZero() {
+		sentAt = time.Now()
+	}
+	_, err := p.db.Exec(`
+	INSERT INTO notification_log (kind, recipient_type, recipient_id, dedupe_key, channel, status, error, sent_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`,
+		entry.Kind, entry.RecipientType, entry.RecipientID, entry.DedupeKey,
+		entry.Channel, entry.Status, sql.NullString{String: entry.Error, Valid: entry.Error != ""}, p.dialect.Stamp(sentAt))
+	if err != nil {
+		return fmt.Errorf("log notification: %w", err)
+	}
+	return nil
+}
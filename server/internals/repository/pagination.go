@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// sortKeys maps the sort keys an endpoint accepts to SQL expressions.
+type sortKeys map[string]string
+
+// paginate counts the rows of base and then runs it again ordered and limited
+// to the requested page. base must not carry its own ORDER BY or LIMIT.
+// tiebreak keeps the order stable between pages when sort keys repeat.
+func (p *SQLRepo) paginate(base string, args []any, page domain.PageRequest, keys sortKeys, defaultKey, tiebreak string) (*sql.Rows, int, error) {
+	key := page.Sort
+	if key == "" {
+		key = defaultKey
+	}
+	column, ok := keys[key]
+	if !ok {
+		return nil, 0, domain.Invalid("sort", "oneof", "unsupported sort key %q", key)
+	}
+
+	var total int
+	if err := p.db.QueryRow(`SELECT COUNT(*) FROM (`+base+`) AS counted;`, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count rows: %w", err)
+	}
+
+	dir := "ASC"
+	if page.Desc {
+		dir = "DESC"
+	}
+	args = append(args, page.Limit, page.Offset)
+	q := fmt.Sprintf("%s\n\tORDER BY %s %s, %s\n\tLIMIT $%d OFFSET $%d;", base, column, dir, tiebreak, len(args)-1, len(args))
+
+	rows, err := p.db.Query(q, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+)
+
+// Postgres is the PostgreSQL dialect.
+var Postgres = Dialect{
+	Now:   "NOW()",
+	Stamp: func(t time.Time) any { return t.UTC() },
+	WeekStart: func(column string) string {
+		return "to_char(date_trunc('week', " + column + "), 'YYYY-MM-DD')"
+	},
+	ForUpdate: func(alias string) string {
+		if alias == "" {
+			return "FOR UPDATE"
+		}
+		return "FOR UPDATE OF " + alias
+	},
+	UniqueViolation:     func(err error) bool { return isViolation(err, uniqueViolation) },
+	ForeignKeyViolation: func(err error) bool { return isViolation(err, foreignKeyViolation) },
+}
+
+// PostgresRepo is the PostgreSQL backend: the shared SQLRepo plus the
+// Postgres migrations and the webhook claim that relies on SKIP LOCKED.
+type PostgresRepo struct {
+	*SQLRepo
+}
+
+// NewPostgresRepo builds the repository; a nil cal means UTC.
+func NewPostgresRepo(db *sql.DB, cal *calendar.Calendar) *PostgresRepo {
+	return &PostgresRepo{NewSQLRepo(db, cal, Postgres)}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// RepairAttendanceDates re-derives the date of every attendance row from its
+// recorded_at with the repository's calendar. The moves are planned over
+// every row first (see domain.PlanDateRepairs); those that would leave a
+// student with two rows for a subject on one date are reported as conflicts
+// and left alone. Everything runs in one transaction.
+func (p *SQLRepo) RepairAttendanceDates(dryRun bool) ([]domain.DateRepair, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(`
+	SELECT attendance_id, usn, subject_id, date, recorded_at
+	FROM attendance
+	ORDER BY attendance_id
+	`+p.dialect.ForUpdate("")+`;`)
+	if err != nil {
+		return nil, fmt.Errorf("query attendance: %w", err)
+	}
+	var all []domain.DateRepair
+	for rows.Next() {
+		var r domain.DateRepair
+		var subjectID sql.NullInt64
+		if err := rows.Scan(&r.AttendanceID, &r.USN, &subjectID, &r.OldDate, &r.RecordedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan attendance: %w", err)
+		}
+		r.SubjectID = subjectID.Int64
+		r.NewDate = p.cal.DateOf(r.RecordedAt)
+		all = append(all, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	repairs := domain.PlanDateRepairs(all)
+	if dryRun {
+		return repairs, nil
+	}
+
+	// The unique indexes are checked row by row, so a move onto a date
+	// another move is about to vacate would fail. Every row is first parked
+	// on a date nothing in its slot uses, then moved to its new date.
+	parked := parkingDates(all, repairs)
+	move := func(id int64, date time.Time) error {
+		_, err := tx.Exec(`UPDATE attendance SET date = $1, updated_at = `+p.dialect.Now+` WHERE attendance_id = $2;`,
+			date.Format("2006-01-02"), id)
+		return err
+	}
+	for _, r := range repairs {
+		if r.Conflict {
+			continue
+		}
+		if err := move(r.AttendanceID, parked[r.AttendanceID]); err != nil {
+			return nil, fmt.Errorf("park attendance %d: %w", r.AttendanceID, err)
+		}
+	}
+	for _, r := range repairs {
+		if r.Conflict {
+			continue
+		}
+		if err := move(r.AttendanceID, r.NewDate); err != nil {
+			return nil, fmt.Errorf("move attendance %d: %w", r.AttendanceID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return repairs, nil
+}
+
+// parkingDates gives each repair that is not a conflict a date, counting up
+// from 1900-01-01, that no row of its student and subject has now or is
+// moving to.
+func parkingDates(all, repairs []domain.DateRepair) map[int64]time.Time {
+	type slot struct {
+		usn       string
+		subjectID int64
+	}
+	used := map[slot]map[time.Time]bool{}
+	mark := func(r domain.DateRepair, d time.Time) {
+		k := slot{r.USN, r.SubjectID}
+		if used[k] == nil {
+			used[k] = map[time.Time]bool{}
+		}
+		used[k][d] = true
+	}
+	day := func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC) }
+	for _, r := range all {
+		mark(r, day(r.OldDate))
+		mark(r, day(r.NewDate))
+	}
+
+	parked := map[int64]time.Time{}
+	for _, r := range repairs {
+		if r.Conflict {
+			continue
+		}
+		d := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+		for used[slot{r.USN, r.SubjectID}][d] {
+			d = d.AddDate(0, 0, 1)
+		}
+		mark(r, d)
+		parked[r.AttendanceID] = d
+	}
+	return parked
+}
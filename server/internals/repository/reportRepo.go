@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// GetAttendanceRegister loads the subject details and the classes that make
+// up the register columns between from and to (inclusive): the logged
+// teaching sessions if there are any, one column each, else the dates with
+// attendance.
+func (p *SQLRepo) GetAttendanceRegister(subjectCode string, from, to time.Time) (domain.AttendanceRegister, error) {
+	reg := domain.AttendanceRegister{From: from, To: to}
+
+	q := `SELECT s.subject_id, s.subject_code, s.subject_name, s.department, s.sem, f.faculty_name
+	      FROM subjects s JOIN faculty f ON s.faculty_id = f.faculty_id
+	      WHERE s.subject_code = $1;`
+	err := p.db.QueryRow(q, subjectCode).Scan(&reg.SubjectID, &reg.SubjectCode, &reg.SubjectName,
+		&reg.Department, &reg.Sem, &reg.FacultyName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return reg, domain.NotFound("subject not found for code: %s", subjectCode)
+		}
+		return reg, fmt.Errorf("lookup subject: %w", err)
+	}
+
+	fromDate, toDate := from.Format("2006-01-02"), to.Format("2006-01-02")
+	if err := p.db.QueryRow(`
+	SELECT EXISTS (
+	    SELECT 1 FROM teaching_sessions
+	    WHERE subject_id = $1 AND date BETWEEN $2 AND $3
+	);`, reg.SubjectID, fromDate, toDate).Scan(&reg.SessionsLogged); err != nil {
+		return reg, fmt.Errorf("check teaching sessions: %w", err)
+	}
+	q = `
+	SELECT DISTINCT date, '' FROM attendance
+	WHERE subject_id = $1 AND date BETWEEN $2 AND $3
+	ORDER BY date;`
+	if reg.SessionsLogged {
+		q = `
+		SELECT date, start_time FROM teaching_sessions
+		WHERE subject_id = $1 AND date BETWEEN $2 AND $3
+		ORDER BY date, start_time;`
+	}
+
+	rows, err := p.db.Query(q, reg.SubjectID, fromDate, toDate)
+	if err != nil {
+		return reg, fmt.Errorf("query register dates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d time.Time
+		var start string
+		if err := rows.Scan(&d, &start); err != nil {
+			return reg, fmt.Errorf("scan register date: %w", err)
+		}
+		reg.Dates = append(reg.Dates, d)
+		if reg.SessionsLogged {
+			reg.StartTimes = append(reg.StartTimes, start)
+		}
+	}
+	return reg, rows.Err()
+}
+
+// StreamAttendanceRegisterRows walks the enrolled students ordered by USN and
+// hands each completed row to fn, so large classes are never held in memory.
+func (p *SQLRepo) StreamAttendanceRegisterRows(reg domain.AttendanceRegister, fn func(row domain.AttendanceRegisterRow) error) error {
+	// A date with two logged sessions has two columns, both filled from the
+	// student's one row that day.
+	columns := make(map[string][]int, len(reg.Dates))
+	for i, d := range reg.Dates {
+		key := d.Format("2006-01-02")
+		columns[key] = append(columns[key], i)
+	}
+
+	q := `
+	SELECT st.usn, st.username, a.date, a.status
+	FROM student_subjects ss
+	JOIN students st ON st.student_id = ss.student_id
+	LEFT JOIN attendance a ON a.usn = st.usn
+	     AND a.subject_id = ss.subject_id
+	     AND a.date BETWEEN $2 AND $3
+	WHERE ss.subject_id = $1
+	ORDER BY st.usn, a.date;`
+
+	rows, err := p.db.Query(q, reg.SubjectID, reg.From.Format("2006-01-02"), reg.To.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("query register rows: %w", err)
+	}
+	defer rows.Close()
+
+	var current *domain.AttendanceRegisterRow
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		if reg.SessionsLogged {
+			current.Total = len(reg.Dates)
+		}
+		if current.Total > 0 {
+			current.Percentage = math.Round(10000*float64(current.Attended)/float64(current.Total)) / 100
+		}
+		return fn(*current)
+	}
+
+	for rows.Next() {
+		var usn, name string
+		var date sql.NullTime
+		var status sql.NullString
+		if err := rows.Scan(&usn, &name, &date, &status); err != nil {
+			return fmt.Errorf("scan register row: %w", err)
+		}
+
+		if current == nil || current.USN != usn {
+			if err := flush(); err != nil {
+				return err
+			}
+			current = &domain.AttendanceRegisterRow{USN: usn, StudentName: name, Cells: make([]string, len(reg.Dates))}
+		}
+
+		if !date.Valid || !status.Valid {
+			continue
+		}
+		for _, i := range columns[date.Time.Format("2006-01-02")] {
+			current.Total++
+			if status.String == "Present" {
+				current.Attended++
+				current.Cells[i] = "P"
+			} else {
+				current.Cells[i] = "A"
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows err: %w", err)
+	}
+	return flush()
+}
+
+// GetDefaulters returns every enrolled student whose percentage in a subject
+// is below query.Threshold, with the subject's held and planned class counts.
+// Every class held counts against every enrolled student, so a student never
+// marked is listed at 0%.
+func (p *SQLRepo) GetDefaulters(query domain.DefaulterQuery) ([]domain.Defaulter, error) {
+	args := []any{query.Department, query.Sem, query.SubjectCode, query.Threshold, query.FacultyID}
+	heldCond, args := dateRange("date", query.Filter, args)
+	rowCond, args := dateRange("a.date", query.Filter, args)
+	logged, args := loggedSessions(query.Filter, args)
+
+	q := `
+	WITH held AS (
+	    SELECT subject_id, COUNT(DISTINCT date) AS classes_held
+	    FROM attendance
+	    WHERE subject_id IS NOT NULL` + heldCond + `
+	    GROUP BY subject_id
+	),` + logged + `
+	SELECT st.usn, st.username, st.department, st.sem,
+	       sub.subject_code, sub.subject_name,
+	       COALESCE(lc.classes, h.classes_held) AS total_classes,
+	       ` + attendedExpr + ` AS attended,
+	       COALESCE(lc.classes, h.classes_held) AS classes_held,
+	       sub.planned_classes,
+	       c.usn IS NOT NULL AS condoned,
+	       COALESCE(c.reason, '') AS reason
+	FROM student_subjects ss
+	JOIN students st ON st.student_id = ss.student_id
+	JOIN subjects sub ON sub.subject_id = ss.subject_id
+	LEFT JOIN held h ON h.subject_id = sub.subject_id
+	LEFT JOIN logged lc ON lc.subject_id = sub.subject_id
+	LEFT JOIN attendance a ON a.usn = st.usn AND a.subject_id = sub.subject_id` + rowCond + `
+	LEFT JOIN teaching_sessions ts ON ts.subject_id = a.subject_id AND ts.date = a.date
+	LEFT JOIN attendance_condonations c ON c.usn = st.usn AND c.subject_id = sub.subject_id
+	WHERE (sub.department = $1 OR $1 = '')
+	  AND (sub.sem = $2 OR $2 = 0)
+	  AND (sub.subject_code = $3 OR $3 = '')
+	  AND (sub.faculty_id = $5 OR $5 = 0)
+	  AND COALESCE(lc.classes, h.classes_held, 0) > 0
+	GROUP BY st.usn, st.username, st.department, st.sem, sub.subject_code, sub.subject_name,
+	         lc.classes, h.classes_held, sub.planned_classes, c.usn, c.reason
+	HAVING 100.0 * ` + attendedExpr + ` / COALESCE(lc.classes, h.classes_held) < $4
+	ORDER BY sub.subject_code, st.usn;`
+
+	rows, err := p.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get defaulters: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Defaulter
+	for rows.Next() {
+		var d domain.Defaulter
+		if err := rows.Scan(&d.USN, &d.StudentName, &d.Department, &d.Sem, &d.SubjectCode, &d.SubjectName,
+			&d.TotalClasses, &d.Attended, &d.ClassesHeld, &d.PlannedClasses, &d.Condoned, &d.CondonationReason); err != nil {
+			return nil, fmt.Errorf("scan defaulter: %w", err)
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) SetCondonation(adminID int64, req domain.CondonationPayload) error {
+	var subjectID int64
+	var enrolled bool
+	err := p.db.QueryRow(`
+	SELECT sub.subject_id,
+	       EXISTS (
+	           SELECT 1 FROM student_subjects ss
+	           JOIN students st ON st.student_id = ss.student_id
+	           WHERE st.usn = $1 AND ss.subject_id = sub.subject_id
+	       )
+	FROM subjects sub
+	WHERE sub.subject_code = $2;`, req.USN, req.SubjectCode).Scan(&subjectID, &enrolled)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NotFound("subject not found for code: %s", req.SubjectCode)
+		}
+		return fmt.Errorf("lookup subject: %w", err)
+	}
+	if !enrolled {
+		var exists bool
+		if err := p.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM students WHERE usn = $1)`, req.USN).Scan(&exists); err != nil {
+			return fmt.Errorf("lookup student: %w", err)
+		}
+		if !exists {
+			return domain.NotFound("student not found: %s", req.USN)
+		}
+		return domain.Invalid("subjectCode", "enrolled", "%s is not enrolled in %s", req.USN, req.SubjectCode)
+	}
+
+	q := `
+	INSERT INTO attendance_condonations (usn, subject_id, reason, granted_by)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (usn, subject_id)
+	DO UPDATE SET reason = EXCLUDED.reason,
+	              granted_by = EXCLUDED.granted_by,
+	              created_at = ` + p.dialect.Now + `;`
+
+	if _, err := p.db.Exec(q, req.USN, subjectID, req.Reason, adminID); err != nil {
+		return fmt.Errorf("set condonation: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLRepo) RemoveCondonation(usn, subjectCode string) error {
+	q := `
+	DELETE FROM attendance_condonations
+	WHERE usn = $1
+	  AND subject_id IN (SELECT subject_id FROM subjects WHERE subject_code = $2);`
+
+	res, err := p.db.Exec(q, usn, subjectCode)
+	if err != nil {
+		return fmt.Errorf("remove condonation: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("condonation not found")
+	}
+	return nil
+}
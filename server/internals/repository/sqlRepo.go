@@ -0,0 +1,920 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+//student 
+func (p *SQLRepo) StudentRegister(student domain.StudentRegisterPayload) (int64, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, err := p.insertStudent(tx, student)
+	if err != nil {
+		return id, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return id, fmt.Errorf("commit tx: %w", err)
+	}
+	return id, nil
+}
+
+// insertStudent registers a student inside tx and enrolls them in the
+// existing subjects of their department and sem.
+func (p *SQLRepo) insertStudent(tx *sql.Tx, student domain.StudentRegisterPayload) (int64, error) {
+	if err := checkDepartment(tx, student.Department, student.Sem); err != nil {
+		return 0, err
+	}
+	var pwHash string
+	var err error
+	if student.Password != "" {
+		pwHash, err = utils.HashPassword(student.Password)
+		if err != nil {
+			return 0, fmt.Errorf("hash password: %w", err)
+		}
+	}
+
+	var id int64
+	query := `INSERT INTO students (usn, username, password_hash, department, sem, email)
+	          VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')) RETURNING student_id;`
+	err = tx.QueryRow(query, student.USN, student.Username, pwHash, student.Department, student.Sem, student.Email).Scan(&id)
+	if err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("student with usn %s already exists", student.USN).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert student: %w", err)
+	}
+
+	// Auto assign existing subjects for dept+sem
+	assignQuery := `
+	INSERT INTO student_subjects (student_id, subject_id)
+	SELECT $1, s.subject_id FROM subjects s WHERE s.department = $2 AND s.sem = $3
+	ON CONFLICT DO NOTHING;`
+	if _, err := tx.Exec(assignQuery, id, student.Department, student.Sem); err != nil {
+		return id, fmt.Errorf("auto-assign subjects: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) LoginStudent(usn, password string) (string, error) {
+	var pwHash string
+	var studentID int64
+	q := `SELECT student_id, password_hash FROM students WHERE usn = $1;`
+	if err := p.db.QueryRow(q, usn).Scan(&studentID, &pwHash); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.Unauthorized("invalid credentials")
+		}
+		return "", fmt.Errorf("query student: %w", err)
+	}
+
+	if err := utils.ComparePassword(pwHash, password); err != nil {
+		return "", domain.Unauthorized("invalid credentials").Wrap(err)
+	}
+
+	token, err := utils.GenerateTokenForStudent(studentID, usn)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return token, nil
+}
+
+func (p *SQLRepo) UpdateStudentInfo(studentID int, payload domain.StudentUpdatePayload) error {
+	if err := checkDepartment(p.db, payload.Department, payload.Sem); err != nil {
+		return err
+	}
+	query := `UPDATE students SET username = $2, department = $3, sem = $4, email = NULLIF($5, '') WHERE student_id = $1;`
+	if _, err := p.db.Exec(query, studentID, payload.Username, payload.Department, payload.Sem, payload.Email); err != nil {
+		return fmt.Errorf("update student: %w", err)
+	}
+	return nil
+}
+
+
+func (p *SQLRepo) AddSubject(subject domain.SubjectPayload) (int64, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, err := p.insertSubject(tx, subject)
+	if err != nil {
+		return id, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return id, fmt.Errorf("commit tx: %w", err)
+	}
+	return id, nil
+}
+
+// insertSubject creates a subject inside tx and enrolls every student of its
+// department and sem.
+func (p *SQLRepo) insertSubject(tx *sql.Tx, subject domain.SubjectPayload) (int64, error) {
+	if err := checkDepartment(tx, subject.Department, subject.Sem); err != nil {
+		return 0, err
+	}
+	var id int64
+	query := `INSERT INTO subjects (subject_code, subject_name, faculty_id, department, sem, planned_classes)
+	          VALUES ($1, $2, $3, $4, $5, $6) RETURNING subject_id;`
+	if err := tx.QueryRow(query, subject.Code, subject.Name, subject.FacultyID, subject.Department, subject.Sem, subject.PlannedClasses).Scan(&id); err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("subject %s already exists", subject.Code).Wrap(err)
+		}
+		if p.isForeignKeyViolation(err) {
+			return 0, domain.NotFound("faculty not found: %d", subject.FacultyID).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert subject: %w", err)
+	}
+
+	assignQuery := `
+	INSERT INTO student_subjects (student_id, subject_id)
+	SELECT s.student_id, $1 FROM students s WHERE s.department = $2 AND s.sem = $3
+	ON CONFLICT DO NOTHING;`
+	if _, err := tx.Exec(assignQuery, id, subject.Department, subject.Sem); err != nil {
+		return id, fmt.Errorf("assign subject to students: %w", err)
+	}
+	return id, nil
+}
+
+
+// SetPlannedClasses records how many classes are scheduled for the subject
+// this term; defaulter reports use it to work out the classes remaining.
+func (p *SQLRepo) SetPlannedClasses(subjectCode string, planned int) error {
+	res, err := p.db.Exec(`UPDATE subjects SET planned_classes = $2 WHERE subject_code = $1;`, subjectCode, planned)
+	if err != nil {
+		return fmt.Errorf("update planned classes: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("subject not found for code: %s", subjectCode)
+	}
+	return nil
+}
+
+//subjects of a particular department and sem
+func (p *SQLRepo) GetSubjectsByDeptAndSem(department string, sem int, page domain.PageRequest) ([]domain.Subject, int, error) {
+	q := `SELECT s.subject_id, s.subject_code, s.subject_name, s.department, s.sem, f.faculty_name
+	      FROM subjects s JOIN faculty f ON s.faculty_id = f.faculty_id
+	      WHERE s.department = $1 AND s.sem = $2`
+	keys := sortKeys{"code": "s.subject_code", "name": "s.subject_name", "faculty": "f.faculty_name"}
+	rows, total, err := p.paginate(q, []any{department, sem}, page, keys, "code", "s.subject_id")
+	if err != nil {
+		return nil, 0, fmt.Errorf("query subjects: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Subject
+	for rows.Next() {
+		var s domain.Subject
+		if err := rows.Scan(&s.ID, &s.Code, &s.Name, &s.Department, &s.Sem, &s.Faculty); err != nil {
+			return nil, 0, fmt.Errorf("scan subject: %w", err)
+		}
+		list = append(list, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows err: %w", err)
+	}
+	return list, total, nil
+}
+
+func (p *SQLRepo) GetSubjectsByStudentID(studentID int64) ([]domain.SubjectPayload, error) {
+	q := `SELECT sub.subject_code, sub.subject_name, sub.faculty_id, sub.department, sub.sem, sub.planned_classes
+	      FROM subjects sub JOIN student_subjects ss ON sub.subject_id = ss.subject_id
+	      WHERE ss.student_id = $1;`
+	rows, err := p.db.Query(q, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("get subjects for student: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.SubjectPayload
+	for rows.Next() {
+		var sp domain.SubjectPayload
+		if err := rows.Scan(&sp.Code, &sp.Name, &sp.FacultyID, &sp.Department, &sp.Sem, &sp.PlannedClasses); err != nil {
+			return nil, fmt.Errorf("scan subj payload: %w", err)
+		}
+		out = append(out, sp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+	return out, nil
+}
+//subjects handled by faculty
+func (p *SQLRepo) GetSubjectsByFacultyID(facultyID int64) ([]domain.Subject, error) {
+	fmt.Println("DEBUG: facultyID in repo:", facultyID)
+	q := `SELECT s.subject_id, s.subject_code, s.subject_name, s.department, s.sem, f.faculty_name
+	      FROM subjects s JOIN faculty f ON s.faculty_id = f.faculty_id
+	      WHERE s.faculty_id = $1;`
+	rows, err := p.db.Query(q, facultyID)
+	if err != nil {
+		return nil, fmt.Errorf("query subjects faculty: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Subject
+	for rows.Next() {
+		var s domain.Subject
+		if err := rows.Scan(&s.ID, &s.Code, &s.Name, &s.Department, &s.Sem, &s.Faculty); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		list = append(list, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+	return list, nil
+}
+
+// Register Faculty
+func (p *SQLRepo) CreateFaculty(req domain.FacultyRegisterPayload) (int64, error) {
+    tx, err := p.db.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("begin tx: %w", err)
+    }
+    defer func() { _ = tx.Rollback() }()
+
+    id, err := p.insertFaculty(tx, req)
+    if err != nil {
+        return 0, err
+    }
+
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("commit tx: %w", err)
+    }
+    return id, nil
+}
+
+func (p *SQLRepo) insertFaculty(tx *sql.Tx, req domain.FacultyRegisterPayload) (int64, error) {
+    if err := checkDepartment(tx, req.Department, 0); err != nil {
+        return 0, err
+    }
+
+    // 1. Hash password
+    pwHash, err := utils.HashPassword(req.Password)
+    if err != nil {
+        return 0, fmt.Errorf("hash password: %w", err)
+    }
+
+    // 2. Insert into DB
+    var id int64
+    q := `
+        INSERT INTO faculty (faculty_name, email, password_hash, department)
+        VALUES ($1, $2, $3, $4)
+        RETURNING faculty_id;
+    `
+    if err := tx.QueryRow(q, req.Name, req.Email, pwHash, req.Department).Scan(&id); err != nil {
+        if p.isUniqueViolation(err) {
+            return 0, domain.Conflict("faculty with email %s already exists", req.Email).Wrap(err)
+        }
+        return 0, fmt.Errorf("insert faculty: %w", err)
+    }
+
+    return id, nil
+}
+
+// Authenticate Faculty
+func (p *SQLRepo) AuthenticateFaculty(req domain.FacultyLoginPayload) (string, error) {
+    var id int64
+    var pwHash string
+
+    // 1. Get faculty by email
+    q := `SELECT faculty_id, password_hash FROM faculty WHERE email = $1;`
+    if err := p.db.QueryRow(q, req.Email).Scan(&id, &pwHash); err != nil {
+        if err == sql.ErrNoRows {
+            return "", domain.Unauthorized("invalid credentials")
+        }
+        return "", fmt.Errorf("query faculty: %w", err)
+    }
+
+    // 2. Compare password
+    if err := utils.ComparePassword(pwHash, req.Password); err != nil {
+		fmt.Println("DEBUG: Stored hash:", pwHash)
+fmt.Println("DEBUG: Login password:", req.Password)
+
+if err := utils.ComparePassword(pwHash, req.Password); err != nil {
+    fmt.Println("DEBUG: Compare failed:", err)
+    return "", domain.Unauthorized("invalid credentials")
+}
+
+        return "", domain.Unauthorized("invalid credentials")
+    }
+
+    // 3. Generate JWT
+    token, err := utils.GenerateTokenForFaculty(id, req.Email)
+    if err != nil {
+        return "", fmt.Errorf("generate token: %w", err)
+    }
+
+    return token, nil
+}
+
+// Get all faculty
+func (p *SQLRepo) GetAllFaculty(filter domain.FacultyFilter, page domain.PageRequest) ([]domain.Faculty, int, error) {
+    query := `SELECT faculty_id, faculty_name, email, department FROM faculty WHERE 1 = 1`
+
+    var args []any
+    if filter.Department != "" {
+        args = append(args, filter.Department)
+        query += fmt.Sprintf(" AND department = $%d", len(args))
+    }
+    if filter.Search != "" {
+        args = append(args, "%"+strings.ToLower(filter.Search)+"%")
+        query += fmt.Sprintf(" AND (LOWER(faculty_name) LIKE $%d OR LOWER(email) LIKE $%d)", len(args), len(args))
+    }
+
+    keys := sortKeys{"id": "faculty_id", "name": "faculty_name", "email": "email", "department": "department", "created_at": "created_at"}
+    rows, total, err := p.paginate(query, args, page, keys, "name", "faculty_id")
+    if err != nil {
+        return nil, 0, fmt.Errorf("get faculty: %w", err)
+    }
+    defer rows.Close()
+
+    var facultyList []domain.Faculty
+    for rows.Next() {
+        var f domain.Faculty
+        if err := rows.Scan(&f.ID, &f.Name, &f.Email, &f.Department); err != nil {
+            return nil, 0, err
+        }
+        facultyList = append(facultyList, f)
+    }
+    return facultyList, total, rows.Err()
+}
+
+func (p *SQLRepo) GetFacultyByDepartment(department string) ([]domain.Faculty, error) {
+    query := `SELECT faculty_id, faculty_name, email, department FROM faculty WHERE department = $1`
+
+    rows, err := p.db.Query(query, department)
+    if err != nil {
+        return nil, fmt.Errorf("get faculty by department: %w", err)
+    }
+    defer rows.Close()
+
+    var facultyList []domain.Faculty
+    for rows.Next() {
+        var f domain.Faculty
+        if err := rows.Scan(&f.ID, &f.Name, &f.Email, &f.Department); err != nil {
+            return nil, err
+        }
+        facultyList = append(facultyList, f)
+    }
+    return facultyList, nil
+}
+
+func (p *SQLRepo) GetFacultyByID(facultyID int64) (domain.Faculty, error) {
+	var f domain.Faculty
+	q := `SELECT faculty_id, faculty_name, email, department, created_at FROM faculty WHERE faculty_id = $1;`
+	if err := p.db.QueryRow(q, facultyID).Scan(&f.ID, &f.Name, &f.Email, &f.Department, &f.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Faculty{}, domain.NotFound("faculty not found")
+		}
+		return domain.Faculty{}, fmt.Errorf("query faculty: %w", err)
+	}
+	return f, nil
+}
+
+
+func (p *SQLRepo) CreateAdmin(username, email, password string) (int64, error) {
+	pwHash, err := utils.HashPassword(password)
+	if err != nil {
+		return 0, fmt.Errorf("hash password: %w", err)
+	}
+	var id int64
+	q := `INSERT INTO admins (username, email, password_hash) VALUES ($1, $2, $3) RETURNING admin_id;`
+	if err := p.db.QueryRow(q, username, email, pwHash).Scan(&id); err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("admin with email %s already exists", email).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert admin: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) AuthenticateAdmin(req domain.AdminLoginPayload) (string, error) {
+	var id int64
+	var pwHash string
+	q := `SELECT admin_id, password_hash FROM admins WHERE email = $1;`
+	if err := p.db.QueryRow(q, req.Email).Scan(&id, &pwHash); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.Unauthorized("invalid credentials")
+		}
+		return "", fmt.Errorf("query admin: %w", err)
+	}
+
+	if err := utils.ComparePassword(pwHash, req.Password); err != nil {
+		return "", domain.Unauthorized("invalid credentials")
+	}
+
+	token, err := utils.GenerateTokenForAdmin(id, req.Email)
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return token, nil
+}
+
+func (p *SQLRepo) CountAdmins() (int, error) {
+	var count int
+	if err := p.db.QueryRow(`SELECT COUNT(*) FROM admins;`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count admins: %w", err)
+	}
+	return count, nil
+}
+
+
+func (p *SQLRepo) MarkAttendance(req *domain.AttendancePayload) (int64, error) {
+	var attendanceID int64
+
+	classDate := p.cal.DateOf(req.RecordedAt).Format("2006-01-02")
+
+	// Insert attendance without subject
+	query := `
+	INSERT INTO attendance (usn, subject_id, date, status, recorded_at)
+	VALUES ($1, NULL, $2, $3, $4)
+	ON CONFLICT (usn, date)
+	WHERE subject_id IS NULL
+	DO UPDATE SET status = EXCLUDED.status,
+	              recorded_at = EXCLUDED.recorded_at
+	RETURNING attendance_id;
+	`
+
+	err := p.db.QueryRow(query, req.USN, classDate, req.Status, p.dialect.Stamp(req.RecordedAt)).Scan(&attendanceID)
+	if err != nil {
+		return 0, fmt.Errorf("mark attendance: %w", err)
+	}
+
+	return attendanceID, nil
+}
+
+func (p *SQLRepo) BulkMarkAttendance(attendances []domain.AttendancePayload) (int, error) {
+    tx, err := p.db.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("begin tx: %w", err)
+    }
+    defer tx.Rollback()
+
+    query := `
+    INSERT INTO attendance (usn, subject_id, date, status, recorded_at)
+    VALUES ($1, NULL, $2, $3, $4)
+    ON CONFLICT (usn, date)
+    WHERE subject_id IS NULL
+    DO UPDATE SET status = EXCLUDED.status,
+                  recorded_at = EXCLUDED.recorded_at
+    RETURNING attendance_id;
+    `
+
+    stmt, err := tx.Prepare(query)
+    if err != nil {
+        return 0, fmt.Errorf("prepare stmt: %w", err)
+    }
+    defer stmt.Close()
+
+    count := 0
+    for _, a := range attendances {
+        classDate := p.cal.DateOf(a.RecordedAt).Format("2006-01-02")
+
+        var id int64
+        if err := stmt.QueryRow(a.USN, classDate, a.Status, p.dialect.Stamp(a.RecordedAt)).Scan(&id); err != nil {
+            return 0, fmt.Errorf("insert attendance (usn=%s): %w", a.USN, err)
+        }
+        count++
+    }
+
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("commit tx: %w", err)
+    }
+
+    return count, nil
+}
+
+//here iam assigning a subject to time range of attendance marker in the attendance table 
+func (p *SQLRepo) AssignSubjectToTimeRange(
+	facultyID int64,
+	subjectCode string,
+	classDate time.Time,
+	startTime, endTime time.Time,
+) (int64, int64, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Lookup subject_id from subject_code
+	var subjectID int64
+	var ownerID int64
+	err = tx.QueryRow(`SELECT subject_id, faculty_id FROM subjects WHERE subject_code = $1`, subjectCode).Scan(&subjectID, &ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, 0, domain.NotFound("subject not found")
+		}
+		return 0, 0, fmt.Errorf("query subject by code: %w", err)
+	}
+
+	// Verify faculty owns this subject or substitutes for it on the date
+	if ownerID != facultyID {
+		covering, err := substitutes(tx, subjectID, facultyID, classDate.Format("2006-01-02"))
+		if err != nil {
+			return 0, 0, err
+		}
+		if !covering {
+			return 0, 0, domain.Forbidden("not authorized to assign this subject")
+		}
+	}
+
+	// Build UTC timestamps for the given date + time range (institution time -> UTC)
+	startDT, endDT := p.cal.Window(classDate, startTime, endTime)
+
+	// Update attendance rows that have subject_id=NULL in the given time range
+	updateSQL := `
+	UPDATE attendance AS a
+	SET subject_id = $1, updated_at = `+p.dialect.Now+`
+	WHERE a.subject_id IS NULL
+	  AND a.date = $2
+	  AND a.recorded_at BETWEEN $3 AND $4
+	  AND NOT EXISTS (
+	    SELECT 1 FROM attendance existing
+	    WHERE existing.usn = a.usn
+	      AND existing.subject_id = $1
+	      AND existing.date = a.date
+	  )
+	RETURNING attendance_id;
+	`
+
+	rows, err := tx.Query(updateSQL, subjectID, classDate.Format("2006-01-02"), p.dialect.Stamp(startDT), p.dialect.Stamp(endDT))
+	if err != nil {
+		return 0, 0, fmt.Errorf("update attendance: %w", err)
+	}
+	defer rows.Close()
+
+	var updatedCount int64
+	for rows.Next() {
+		updatedCount++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	// Count skipped (attendance rows that were NULL but already had subject)
+	var totalCandidates int64
+	if err := tx.QueryRow(`
+		SELECT COUNT(*) FROM attendance
+		WHERE subject_id IS NULL
+		  AND date = $1
+		  AND recorded_at BETWEEN $2 AND $3
+	`, classDate.Format("2006-01-02"), p.dialect.Stamp(startDT), p.dialect.Stamp(endDT)).Scan(&totalCandidates); err != nil {
+		return 0, 0, fmt.Errorf("count candidates: %w", err)
+	}
+	skipped := totalCandidates - updatedCount
+	if skipped < 0 {
+		skipped = 0
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return updatedCount, skipped, nil
+}
+
+
+func (p *SQLRepo) GetAttendanceByStudentAndSubject(usn string, subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.AttendanceWithNames, int, error) {
+	var subjectID int64
+	err := p.db.QueryRow(`SELECT subject_id FROM subjects WHERE subject_code = $1`, subjectCode).Scan(&subjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, domain.NotFound("subject not found for code: %s", subjectCode)
+		}
+		return nil, 0, fmt.Errorf("lookup subject_id: %w", err)
+	}
+
+	
+	q := `
+	SELECT a.attendance_id, a.usn, st.username AS student_name,
+	       a.subject_id, sub.subject_name,
+	       a.date, a.status, a.recorded_at, a.created_at
+	FROM attendance a
+	JOIN students st ON a.usn = st.usn
+	LEFT JOIN subjects sub ON a.subject_id = sub.subject_id
+	WHERE a.usn = $1 AND a.subject_id = $2`
+
+	cond, args := attendanceConditions("a", filter, []any{usn, subjectID})
+	rows, total, err := p.paginate(q+cond, args, page, historySortKeys, "date", "a.attendance_id")
+	if err != nil {
+		return nil, 0, fmt.Errorf("query attendance: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.AttendanceWithNames
+	for rows.Next() {
+		var a domain.AttendanceWithNames
+		if err := rows.Scan(&a.ID, &a.USN, &a.StudentName, &a.SubjectID, &a.SubjectName,
+			&a.Date, &a.Status, &a.RecordedAt, &a.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan attendance: %w", err)
+		}
+		list = append(list, a)
+	}
+	return list, total, rows.Err()
+}
+
+
+func (p *SQLRepo) GetAttendanceBySubjectAndDate(subjectCode string, date time.Time) ([]domain.AttendanceWithNames, error) {
+
+	var subjectID int64
+	err := p.db.QueryRow(`SELECT subject_id FROM subjects WHERE subject_code = $1`, subjectCode).Scan(&subjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("subject not found for code: %s", subjectCode)
+		}
+		return nil, fmt.Errorf("lookup subject_id: %w", err)
+	}
+
+	startDT, endDT := p.cal.DayBounds(date)
+
+	q := `
+	SELECT a.attendance_id, a.usn, st.username AS student_name,
+	       a.subject_id, sub.subject_name,
+	       a.date, a.status, a.recorded_at, a.created_at
+	FROM attendance a
+	JOIN students st ON a.usn = st.usn
+	LEFT JOIN subjects sub ON a.subject_id = sub.subject_id
+	WHERE a.subject_id = $1
+	  AND a.recorded_at BETWEEN $2 AND $3
+	ORDER BY a.recorded_at ASC;`
+
+	rows, err := p.db.Query(q, subjectID, p.dialect.Stamp(startDT), p.dialect.Stamp(endDT))
+	if err != nil {
+		return nil, fmt.Errorf("query attendance by subject and date: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.AttendanceWithNames
+	for rows.Next() {
+		var a domain.AttendanceWithNames
+		if err := rows.Scan(&a.ID, &a.USN, &a.StudentName, &a.SubjectID, &a.SubjectName,
+			&a.Date, &a.Status, &a.RecordedAt, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan attendance: %w", err)
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+//i need to write the service and handler for this function 
+func (p *SQLRepo) GetAttendanceSummaryByStudent(usn string, filter domain.AttendanceFilter) ([]domain.SubjectSummary, error) {
+	// Every enrolled subject gets a row, so one the student was never
+	// marked in reads 0 of its logged sessions rather than being left out.
+	logged, args := loggedSessions(filter, []any{usn})
+	cond, args := dateRange("a.date", filter, args)
+	q := `
+	WITH` + logged + `
+	SELECT subj.subject_id, subj.subject_name,
+	       ` + rosterClassesExpr + ` AS total_classes,
+	       ` + attendedExpr + ` AS attended,
+	       COALESCE(ROUND(100.0 * ` + attendedExpr + ` / NULLIF(` + rosterClassesExpr + `, 0), 2), 0) AS percentage
+	FROM students st
+	JOIN student_subjects ss ON ss.student_id = st.student_id
+	JOIN subjects subj ON subj.subject_id = ss.subject_id
+	LEFT JOIN logged lc ON lc.subject_id = ss.subject_id
+	LEFT JOIN attendance a ON a.usn = st.usn AND a.subject_id = ss.subject_id` + cond + `
+	LEFT JOIN teaching_sessions ts ON ts.subject_id = a.subject_id AND ts.date = a.date
+	WHERE st.usn = $1
+	GROUP BY subj.subject_id, subj.subject_name
+	ORDER BY subj.subject_id;`
+
+	rows, err := p.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get student summary: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.SubjectSummary
+	for rows.Next() {
+		var s domain.SubjectSummary
+		if err := rows.Scan(&s.SubjectID, &s.SubjectName, &s.TotalClasses, &s.Attended, &s.Percentage); err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+
+func (p *SQLRepo) GetAttendanceSummaryBySubject(subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.StudentSummary, int, error) {
+	var subjectID int64
+	err := p.db.QueryRow(`SELECT subject_id FROM subjects WHERE subject_code = $1`, subjectCode).Scan(&subjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, domain.NotFound("subject not found for code: %s", subjectCode)
+		}
+		return nil, 0, fmt.Errorf("lookup subject_id: %w", err)
+	}
+
+	// Every enrolled student gets a row, so one never marked reads 0 of the
+	// logged sessions rather than being left out.
+	logged, args := loggedSessions(filter, []any{subjectID})
+	cond, args := dateRange("a.date", filter, args)
+	q := `
+	WITH` + logged + `
+	SELECT st.usn, st.username AS student_name,
+	       ` + rosterClassesExpr + ` AS total_classes,
+	       ` + attendedExpr + ` AS attended,
+	       COALESCE(ROUND(100.0 * ` + attendedExpr + ` / NULLIF(` + rosterClassesExpr + `, 0), 2), 0) AS percentage
+	FROM student_subjects ss
+	JOIN students st ON st.student_id = ss.student_id
+	LEFT JOIN logged lc ON lc.subject_id = ss.subject_id
+	LEFT JOIN attendance a ON a.usn = st.usn AND a.subject_id = ss.subject_id` + cond + `
+	LEFT JOIN teaching_sessions ts ON ts.subject_id = a.subject_id AND ts.date = a.date
+	WHERE ss.subject_id = $1
+	GROUP BY st.usn, st.username`
+
+	// SQLite resolves a bare usn against the joined tables, where it is ambiguous.
+	keys := sortKeys{"name": "student_name", "usn": "st.usn", "percentage": "percentage", "attended": "attended", "total_classes": "total_classes"}
+	rows, total, err := p.paginate(q, args, page, keys, "name", "st.usn")
+	if err != nil {
+		return nil, 0, fmt.Errorf("get subject summary: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.StudentSummary
+	for rows.Next() {
+		var s domain.StudentSummary
+		if err := rows.Scan(&s.USN, &s.StudentName, &s.TotalClasses, &s.Attended, &s.Percentage); err != nil {
+			return nil, 0, err
+		}
+		list = append(list, s)
+	}
+	return list, total, rows.Err()
+}
+
+
+
+func (p *SQLRepo) GetClassAttendance(subjectCode string, date time.Time) ([]domain.ClassAttendance, error) {
+
+	var subjectID int64
+	err := p.db.QueryRow(`SELECT subject_id FROM subjects WHERE subject_code = $1`, subjectCode).Scan(&subjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("subject not found for code: %s", subjectCode)
+		}
+		return nil, fmt.Errorf("lookup subject_id: %w", err)
+	}
+
+	startDT, endDT := p.cal.DayBounds(date)
+
+	q := `
+	SELECT a.usn, st.username AS student_name, a.date, a.status
+	FROM attendance a
+	JOIN students st ON a.usn = st.usn
+	WHERE a.subject_id = $1
+	  AND a.recorded_at BETWEEN $2 AND $3
+	ORDER BY st.username;`
+
+	rows, err := p.db.Query(q, subjectID, p.dialect.Stamp(startDT), p.dialect.Stamp(endDT))
+	if err != nil {
+		return nil, fmt.Errorf("get class attendance: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.ClassAttendance
+	for rows.Next() {
+		var ca domain.ClassAttendance
+		if err := rows.Scan(&ca.USN, &ca.StudentName, &ca.Date, &ca.Status); err != nil {
+			return nil, err
+		}
+		list = append(list, ca)
+	}
+	return list, nil
+}
+
+func (p *SQLRepo) GetStudentAttendanceHistory(usn string, subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.StudentHistory, int, error) {
+var subjectID int64
+	err := p.db.QueryRow(`SELECT subject_id FROM subjects WHERE subject_code = $1`, subjectCode).Scan(&subjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, domain.NotFound("subject not found for code: %s", subjectCode)
+		}
+		return nil, 0, fmt.Errorf("lookup subject_id: %w", err)
+	}
+
+	q := `
+	SELECT a.attendance_id, a.date, a.status,
+	       a.subject_id, sub.subject_name,
+	       a.recorded_at
+	FROM attendance a
+	LEFT JOIN subjects sub ON a.subject_id = sub.subject_id
+	WHERE a.usn = $1 AND a.subject_id = $2`
+
+	cond, args := attendanceConditions("a", filter, []any{usn, subjectID})
+	rows, total, err := p.paginate(q+cond, args, page, historySortKeys, "date", "a.attendance_id")
+	if err != nil {
+		return nil, 0, fmt.Errorf("get student history: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.StudentHistory
+	for rows.Next() {
+		var h domain.StudentHistory
+		if err := rows.Scan(&h.ID, &h.Date, &h.Status, &h.SubjectID, &h.SubjectName, &h.RecordedAt); err != nil {
+			return nil, 0, err
+		}
+		list = append(list, h)
+	}
+	return list, total, rows.Err()
+}
+
+// CorrectAttendance changes the status of a subject-assigned attendance row
+// owned by facultyID, or by a subject facultyID substitutes for on the row's
+// date, and reports the previous status.
+func (p *SQLRepo) CorrectAttendance(facultyID int64, attendanceID int64, status string) (domain.AttendanceCorrection, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return domain.AttendanceCorrection{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	c := domain.AttendanceCorrection{AttendanceID: attendanceID, Status: status}
+	var subjectID, ownerID int64
+	var date time.Time
+	err = tx.QueryRow(`
+	SELECT a.usn, s.subject_id, s.subject_code, s.faculty_id, a.status, a.recorded_at, a.date
+	FROM attendance a
+	JOIN subjects s ON a.subject_id = s.subject_id
+	WHERE a.attendance_id = $1
+	`+p.dialect.ForUpdate("a")+`;`, attendanceID).Scan(&c.USN, &subjectID, &c.SubjectCode, &ownerID, &c.OldStatus, &c.RecordedAt, &date)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c, domain.NotFound("attendance not found or not assigned to a subject")
+		}
+		return c, fmt.Errorf("query attendance: %w", err)
+	}
+
+	if ownerID != facultyID {
+		covering, err := substitutes(tx, subjectID, facultyID, date.Format("2006-01-02"))
+		if err != nil {
+			return c, err
+		}
+		if !covering {
+			return c, domain.Forbidden("not authorized to correct this attendance")
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE attendance SET status = $2, updated_at = `+p.dialect.Now+` WHERE attendance_id = $1;`,
+		attendanceID, status); err != nil {
+		return c, fmt.Errorf("update attendance: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return c, fmt.Errorf("commit tx: %w", err)
+	}
+	return c, nil
+}
+
+func (p *SQLRepo) GetSubjectOwner(subjectCode string) (int64, error) {
+	var ownerID int64
+	err := p.db.QueryRow(`SELECT faculty_id FROM subjects WHERE subject_code = $1`, subjectCode).Scan(&ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.NotFound("subject not found for code: %s", subjectCode)
+		}
+		return 0, fmt.Errorf("lookup subject owner: %w", err)
+	}
+	return ownerID, nil
+}
+
+func (p *SQLRepo) GetCaptureSubject(usn string, recordedAt time.Time) (string, error) {
+	date := p.cal.DateOf(recordedAt)
+	rows, err := p.db.Query(`
+	SELECT sub.subject_code, ts.start_time, ts.duration_minutes
+	FROM teaching_sessions ts
+	JOIN subjects sub ON sub.subject_id = ts.subject_id
+	JOIN student_subjects ss ON ss.subject_id = ts.subject_id
+	JOIN students st ON st.student_id = ss.student_id
+	WHERE st.usn = $1 AND ts.date = $2
+	ORDER BY ts.start_time;`, usn, date.Format("2006-01-02"))
+	if err != nil {
+		return "", fmt.Errorf("get capture subject: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code, start string
+		var minutes int
+		if err := rows.Scan(&code, &start, &minutes); err != nil {
+			return "", fmt.Errorf("scan capture subject: %w", err)
+		}
+		from, to, err := p.cal.Session(date, start, minutes)
+		if err == nil && !recordedAt.Before(from) && !recordedAt.After(to) {
+			return code, nil
+		}
+	}
+	return "", rows.Err()
+}
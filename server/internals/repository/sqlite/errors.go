@@ -0,0 +1,28 @@
+package sqlite
+
+import (
+	"errors"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+func constraintCode(err error) int {
+	var sqlErr *sqlite.Error
+	if errors.As(err, &sqlErr) {
+		return sqlErr.Code()
+	}
+	return 0
+}
+
+func isUniqueViolation(err error) bool {
+	code := constraintCode(err)
+	return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
+
+// isForeignKeyViolation also matches the triggers that stand in for the
+// department foreign keys (see migration 0008).
+func isForeignKeyViolation(err error) bool {
+	code := constraintCode(err)
+	return code == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY || code == sqlite3.SQLITE_CONSTRAINT_TRIGGER
+}
@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository"
+)
+
+// migrations holds the SQLite dialect of repository/migrations; versions and
+// names must stay in step with the PostgreSQL scripts.
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+func (s *SQLiteRepo) ensureMigrationTable() error {
+	_, err := s.db.Exec(`
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+	    version INT PRIMARY KEY,
+	    name VARCHAR(200) NOT NULL,
+	    applied_at TEXT NOT NULL
+	);`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteRepo) appliedMigrations() (map[int]time.Time, error) {
+	rows, err := s.db.Query(`SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var at string
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		t, err := time.Parse(timestampLayout, at)
+		if err != nil {
+			return nil, fmt.Errorf("parse applied_at of migration %d: %w", version, err)
+		}
+		applied[version] = t
+	}
+	return applied, rows.Err()
+}
+
+// MigrationStatus lists every known migration and whether it is applied.
+func (s *SQLiteRepo) MigrationStatus() ([]repository.MigrationState, error) {
+	migrations, err := repository.LoadMigrations(migrationFS)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMigrationTable(); err != nil {
+		return nil, err
+	}
+	applied, err := s.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.MigrationStates(migrations, applied), nil
+}
+
+// MigrateUp applies every pending migration in order, each in its own
+// transaction, and returns the versions it applied.
+func (s *SQLiteRepo) MigrateUp() ([]int, error) {
+	migrations, err := repository.LoadMigrations(migrationFS)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for _, m := range migrations {
+		ran, err := s.runMigration(m, true)
+		if err != nil {
+			return done, err
+		}
+		if ran {
+			done = append(done, m.Version)
+		}
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the latest steps applied migrations and returns the
+// versions it reverted.
+func (s *SQLiteRepo) MigrateDown(steps int) ([]int, error) {
+	migrations, err := repository.LoadMigrations(migrationFS)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		ran, err := s.runMigration(migrations[i], false)
+		if err != nil {
+			return done, err
+		}
+		if ran {
+			done = append(done, migrations[i].Version)
+		}
+	}
+	return done, nil
+}
+
+// runMigration applies (up) or reverts (down) m unless that already happened.
+// SQLite takes the write lock for the whole transaction, so a concurrent
+// migrator waits and then skips.
+func (s *SQLiteRepo) runMigration(m repository.Migration, up bool) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var applied bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1);`, m.Version).Scan(&applied); err != nil {
+		return false, fmt.Errorf("check migration %d: %w", m.Version, err)
+	}
+	if applied == up {
+		return false, nil
+	}
+
+	script := m.Up
+	if !up {
+		script = m.Down
+	}
+	if _, err := tx.Exec(script); err != nil {
+		return false, fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+	}
+
+	if up {
+		_, err = tx.Exec(`INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3);`,
+			m.Version, m.Name, stamp(time.Now()))
+	} else {
+		_, err = tx.Exec(`DELETE FROM schema_migrations WHERE version = $1 AND name = $2;`, m.Version, m.Name)
+	}
+	if err != nil {
+		return false, fmt.Errorf("record migration %d: %w", m.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit migration %d: %w", m.Version, err)
+	}
+	return true, nil
+}
+
+// CheckSchema fails unless every embedded migration has been applied and the
+// database carries none this binary does not know about.
+func (s *SQLiteRepo) CheckSchema() error {
+	migrations, err := repository.LoadMigrations(migrationFS)
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	if err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations');`).Scan(&exists); err != nil {
+		return fmt.Errorf("check schema_migrations: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("database has no schema_migrations table; %d migrations pending", len(migrations))
+	}
+
+	applied, err := s.appliedMigrations()
+	if err != nil {
+		return err
+	}
+	return repository.CheckApplied(migrations, applied)
+}
@@ -0,0 +1,69 @@
+-- SQLite dialect of the core tables. Timestamps are stored as UTC text
+-- ("YYYY-MM-DD HH:MM:SS.ffffff") and dates as "YYYY-MM-DD" so that they
+-- compare correctly as strings.
+
+CREATE TABLE IF NOT EXISTS faculty (
+    faculty_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    faculty_name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) UNIQUE NOT NULL,
+    password_hash VARCHAR(256) NOT NULL,
+    department VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS students (
+    student_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    usn VARCHAR(50) UNIQUE NOT NULL,
+    username VARCHAR(100) NOT NULL,
+    password_hash VARCHAR(256) NULL,
+    department VARCHAR(50) NOT NULL,
+    sem INT NOT NULL,
+    face_encoding BLOB NULL,
+    nfc_uid VARCHAR(100) UNIQUE NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS subjects (
+    subject_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subject_code VARCHAR(50) UNIQUE NOT NULL,
+    subject_name VARCHAR(150) NOT NULL,
+    department VARCHAR(50) NOT NULL,
+    sem INT NOT NULL,
+    faculty_id INT NOT NULL,
+    CONSTRAINT fk_faculty_sub FOREIGN KEY (faculty_id) REFERENCES faculty(faculty_id) ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS student_subjects (
+    student_id INT NOT NULL,
+    subject_id INT NOT NULL,
+    PRIMARY KEY (student_id, subject_id),
+    CONSTRAINT fk_student_sub FOREIGN KEY (student_id) REFERENCES students(student_id) ON DELETE CASCADE,
+    CONSTRAINT fk_subject_sub FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS attendance (
+    attendance_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    usn VARCHAR(50) NOT NULL,
+    subject_id INT NULL,
+    date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('Present', 'Absent')),
+    recorded_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT fk_attendance_student FOREIGN KEY (usn) REFERENCES students(usn) ON DELETE CASCADE,
+    CONSTRAINT fk_attendance_subject FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE SET NULL
+);
+
+-- MarkAttendance upserts on this partial index, exactly as on PostgreSQL.
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_usn_date_null_subject
+    ON attendance(usn, date)
+    WHERE subject_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_usn_subject_date
+    ON attendance(usn, subject_id, date);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_date_recorded
+    ON attendance(date, recorded_at);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_subject_date
+    ON attendance(subject_id, date);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attendance_condonations;
+ALTER TABLE subjects DROP COLUMN planned_classes;
@@ -0,0 +1,13 @@
+ALTER TABLE subjects ADD COLUMN planned_classes INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS attendance_condonations (
+    usn VARCHAR(50) NOT NULL,
+    subject_id INT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    granted_by INT NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (usn, subject_id),
+    CONSTRAINT fk_condonation_student FOREIGN KEY (usn) REFERENCES students(usn) ON DELETE CASCADE,
+    CONSTRAINT fk_condonation_subject FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    CONSTRAINT fk_condonation_admin FOREIGN KEY (granted_by) REFERENCES admins(admin_id) ON DELETE SET NULL
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS terms (
+    term_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    CONSTRAINT chk_term_dates CHECK (end_date >= start_date)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS guardian_students;
+DROP TABLE IF EXISTS guardians;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS guardians (
+    guardian_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    relation VARCHAR(50) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(256) NOT NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS guardian_students (
+    guardian_id INT NOT NULL,
+    usn VARCHAR(50) NOT NULL,
+    PRIMARY KEY (guardian_id, usn),
+    CONSTRAINT fk_guardian_link FOREIGN KEY (guardian_id) REFERENCES guardians(guardian_id) ON DELETE CASCADE,
+    CONSTRAINT fk_guardian_student FOREIGN KEY (usn) REFERENCES students(usn) ON DELETE CASCADE
+);
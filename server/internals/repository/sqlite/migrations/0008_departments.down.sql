@@ -0,0 +1,9 @@
+-- The normalized department values are kept.
+DROP TRIGGER IF EXISTS fk_subjects_department_update;
+DROP TRIGGER IF EXISTS fk_subjects_department_insert;
+DROP TRIGGER IF EXISTS fk_students_department_update;
+DROP TRIGGER IF EXISTS fk_students_department_insert;
+DROP TRIGGER IF EXISTS fk_faculty_department_update;
+DROP TRIGGER IF EXISTS fk_faculty_department_insert;
+DROP TRIGGER IF EXISTS fk_department_restrict;
+DROP TABLE IF EXISTS departments;
@@ -0,0 +1,103 @@
+-- SQLite dialect of 0008_departments. ALTER TABLE cannot add foreign keys
+-- and rebuilding students would cascade-delete their attendance, so the
+-- references from faculty, students and subjects are enforced by triggers.
+
+CREATE TABLE IF NOT EXISTS departments (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    hod_faculty_id INT NULL,
+    semesters INT NOT NULL DEFAULT 8 CHECK (semesters > 0),
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT fk_department_hod FOREIGN KEY (hod_faculty_id) REFERENCES faculty(faculty_id) ON DELETE SET NULL
+);
+
+INSERT INTO departments (code, name) VALUES
+    ('CSE', 'Computer Science and Engineering'),
+    ('ISE', 'Information Science and Engineering'),
+    ('ECE', 'Electronics and Communication Engineering'),
+    ('EEE', 'Electrical and Electronics Engineering'),
+    ('ME', 'Mechanical Engineering'),
+    ('CV', 'Civil Engineering'),
+    ('AIML', 'Artificial Intelligence and Machine Learning')
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TEMP TABLE department_aliases (
+    alias VARCHAR(150) PRIMARY KEY,
+    code VARCHAR(50) NOT NULL
+);
+
+INSERT INTO department_aliases (alias, code) VALUES
+    ('CS', 'CSE'), ('COMPUTER SCIENCE', 'CSE'),
+    ('COMPUTER SCIENCE AND ENGINEERING', 'CSE'), ('COMPUTER SCIENCE & ENGINEERING', 'CSE'),
+    ('IS', 'ISE'), ('INFORMATION SCIENCE', 'ISE'),
+    ('INFORMATION SCIENCE AND ENGINEERING', 'ISE'), ('INFORMATION SCIENCE & ENGINEERING', 'ISE'),
+    ('EC', 'ECE'), ('ELECTRONICS AND COMMUNICATION', 'ECE'),
+    ('ELECTRONICS AND COMMUNICATION ENGINEERING', 'ECE'), ('ELECTRONICS & COMMUNICATION ENGINEERING', 'ECE'),
+    ('EE', 'EEE'), ('ELECTRICAL AND ELECTRONICS', 'EEE'),
+    ('ELECTRICAL AND ELECTRONICS ENGINEERING', 'EEE'), ('ELECTRICAL & ELECTRONICS ENGINEERING', 'EEE'),
+    ('MECH', 'ME'), ('MECHANICAL', 'ME'), ('MECHANICAL ENGINEERING', 'ME'),
+    ('CIVIL', 'CV'), ('CIVIL ENGINEERING', 'CV'),
+    ('AI&ML', 'AIML'), ('AI ML', 'AIML'), ('AI-ML', 'AIML'),
+    ('ARTIFICIAL INTELLIGENCE AND MACHINE LEARNING', 'AIML'),
+    ('ARTIFICIAL INTELLIGENCE & MACHINE LEARNING', 'AIML');
+
+UPDATE faculty SET department = COALESCE(
+    (SELECT code FROM department_aliases WHERE alias = UPPER(TRIM(faculty.department))),
+    UPPER(TRIM(department)));
+UPDATE students SET department = COALESCE(
+    (SELECT code FROM department_aliases WHERE alias = UPPER(TRIM(students.department))),
+    UPPER(TRIM(department)));
+UPDATE subjects SET department = COALESCE(
+    (SELECT code FROM department_aliases WHERE alias = UPPER(TRIM(subjects.department))),
+    UPPER(TRIM(department)));
+
+DROP TABLE department_aliases;
+
+INSERT OR IGNORE INTO departments (code, name)
+SELECT department, department FROM faculty
+UNION SELECT department, department FROM students
+UNION SELECT department, department FROM subjects;
+
+CREATE TRIGGER IF NOT EXISTS fk_faculty_department_insert BEFORE INSERT ON faculty
+WHEN NOT EXISTS (SELECT 1 FROM departments WHERE code = NEW.department)
+BEGIN
+    SELECT RAISE(ABORT, 'FOREIGN KEY constraint failed: faculty.department');
+END;
+
+CREATE TRIGGER IF NOT EXISTS fk_faculty_department_update BEFORE UPDATE OF department ON faculty
+WHEN NOT EXISTS (SELECT 1 FROM departments WHERE code = NEW.department)
+BEGIN
+    SELECT RAISE(ABORT, 'FOREIGN KEY constraint failed: faculty.department');
+END;
+
+CREATE TRIGGER IF NOT EXISTS fk_students_department_insert BEFORE INSERT ON students
+WHEN NOT EXISTS (SELECT 1 FROM departments WHERE code = NEW.department)
+BEGIN
+    SELECT RAISE(ABORT, 'FOREIGN KEY constraint failed: students.department');
+END;
+
+CREATE TRIGGER IF NOT EXISTS fk_students_department_update BEFORE UPDATE OF department ON students
+WHEN NOT EXISTS (SELECT 1 FROM departments WHERE code = NEW.department)
+BEGIN
+    SELECT RAISE(ABORT, 'FOREIGN KEY constraint failed: students.department');
+END;
+
+CREATE TRIGGER IF NOT EXISTS fk_subjects_department_insert BEFORE INSERT ON subjects
+WHEN NOT EXISTS (SELECT 1 FROM departments WHERE code = NEW.department)
+BEGIN
+    SELECT RAISE(ABORT, 'FOREIGN KEY constraint failed: subjects.department');
+END;
+
+CREATE TRIGGER IF NOT EXISTS fk_subjects_department_update BEFORE UPDATE OF department ON subjects
+WHEN NOT EXISTS (SELECT 1 FROM departments WHERE code = NEW.department)
+BEGIN
+    SELECT RAISE(ABORT, 'FOREIGN KEY constraint failed: subjects.department');
+END;
+
+CREATE TRIGGER IF NOT EXISTS fk_department_restrict BEFORE DELETE ON departments
+WHEN EXISTS (SELECT 1 FROM faculty WHERE department = OLD.code)
+  OR EXISTS (SELECT 1 FROM students WHERE department = OLD.code)
+  OR EXISTS (SELECT 1 FROM subjects WHERE department = OLD.code)
+BEGIN
+    SELECT RAISE(ABORT, 'FOREIGN KEY constraint failed: departments.code');
+END;
@@ -0,0 +1,21 @@
+-- One row per lecture a faculty member logged as held. Once a subject has
+-- logged sessions in a date range, they replace the distinct attendance
+-- dates as its classes held, and so as the denominator of percentages.
+CREATE TABLE IF NOT EXISTS teaching_sessions (
+    session_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subject_id INT NOT NULL,
+    faculty_id INT NOT NULL,
+    date DATE NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    duration_minutes INT NOT NULL CHECK (duration_minutes > 0),
+    topic TEXT NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT uq_teaching_session UNIQUE (subject_id, date),
+    CONSTRAINT fk_session_subject FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    CONSTRAINT fk_session_faculty FOREIGN KEY (faculty_id) REFERENCES faculty(faculty_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_teaching_sessions_faculty
+    ON teaching_sessions(faculty_id, date);
@@ -0,0 +1,41 @@
+-- A substitute covering one date's classes of a subject. For that date the
+-- substitute may assign and correct the subject's attendance and log its
+-- teaching session, as the subject's own faculty can.
+CREATE TABLE IF NOT EXISTS substitutions (
+    substitution_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subject_id INT NOT NULL,
+    date DATE NOT NULL,
+    substitute_faculty_id INT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    assigned_by INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT uq_substitution UNIQUE (subject_id, date),
+    CONSTRAINT fk_substitution_subject FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    CONSTRAINT fk_substitution_substitute FOREIGN KEY (substitute_faculty_id) REFERENCES faculty(faculty_id),
+    CONSTRAINT fk_substitution_assigned_by FOREIGN KEY (assigned_by) REFERENCES faculty(faculty_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_substitutions_substitute
+    ON substitutions(substitute_faculty_id, date);
+
+-- Two subjects that exchanged their slots on a date. Each stays with its own
+-- faculty, so a swap is a record for the audit trail and grants nothing.
+CREATE TABLE IF NOT EXISTS class_swaps (
+    swap_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    date DATE NOT NULL,
+    subject_id INT NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    end_time VARCHAR(5) NOT NULL,
+    other_subject_id INT NOT NULL,
+    other_start_time VARCHAR(5) NOT NULL,
+    other_end_time VARCHAR(5) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    assigned_by INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT chk_swap_subjects CHECK (subject_id <> other_subject_id),
+    CONSTRAINT fk_swap_subject FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    CONSTRAINT fk_swap_other_subject FOREIGN KEY (other_subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    CONSTRAINT fk_swap_assigned_by FOREIGN KEY (assigned_by) REFERENCES faculty(faculty_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_class_swaps_date ON class_swaps(date);
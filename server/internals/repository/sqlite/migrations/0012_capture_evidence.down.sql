@@ -0,0 +1,6 @@
+ALTER TABLE recognition_events DROP COLUMN face_crop;
+ALTER TABLE recognition_events DROP COLUMN bbox_height;
+ALTER TABLE recognition_events DROP COLUMN bbox_width;
+ALTER TABLE recognition_events DROP COLUMN bbox_y;
+ALTER TABLE recognition_events DROP COLUMN bbox_x;
+ALTER TABLE recognition_events DROP COLUMN device_id;
@@ -0,0 +1,8 @@
+-- Evidence sent with a capture: the camera that took it, where the face was
+-- in the frame, and the key of the face crop in the blob store.
+ALTER TABLE recognition_events ADD COLUMN device_id VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE recognition_events ADD COLUMN bbox_x INT NULL;
+ALTER TABLE recognition_events ADD COLUMN bbox_y INT NULL;
+ALTER TABLE recognition_events ADD COLUMN bbox_width INT NULL;
+ALTER TABLE recognition_events ADD COLUMN bbox_height INT NULL;
+ALTER TABLE recognition_events ADD COLUMN face_crop VARCHAR(64) NULL;
@@ -0,0 +1,30 @@
+-- Keeps the earliest session of each subject and date; the others cannot
+-- be represented with one session per date.
+CREATE TABLE teaching_sessions_old (
+    session_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subject_id INT NOT NULL,
+    faculty_id INT NOT NULL,
+    date DATE NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    duration_minutes INT NOT NULL CHECK (duration_minutes > 0),
+    topic TEXT NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT uq_teaching_session UNIQUE (subject_id, date),
+    CONSTRAINT fk_session_subject FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    CONSTRAINT fk_session_faculty FOREIGN KEY (faculty_id) REFERENCES faculty(faculty_id)
+);
+
+INSERT INTO teaching_sessions_old
+SELECT * FROM teaching_sessions t
+WHERE NOT EXISTS (
+    SELECT 1 FROM teaching_sessions e
+    WHERE e.subject_id = t.subject_id AND e.date = t.date
+      AND (e.start_time < t.start_time OR (e.start_time = t.start_time AND e.session_id < t.session_id))
+);
+DROP TABLE teaching_sessions;
+ALTER TABLE teaching_sessions_old RENAME TO teaching_sessions;
+
+CREATE INDEX IF NOT EXISTS idx_teaching_sessions_faculty
+    ON teaching_sessions(faculty_id, date);
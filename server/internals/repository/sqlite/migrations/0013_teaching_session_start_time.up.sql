@@ -0,0 +1,24 @@
+-- SQLite dialect of 0013_teaching_session_start_time. SQLite cannot change a
+-- table constraint, so teaching_sessions is rebuilt; nothing references it.
+CREATE TABLE teaching_sessions_new (
+    session_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subject_id INT NOT NULL,
+    faculty_id INT NOT NULL,
+    date DATE NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    duration_minutes INT NOT NULL CHECK (duration_minutes > 0),
+    topic TEXT NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT uq_teaching_session UNIQUE (subject_id, date, start_time),
+    CONSTRAINT fk_session_subject FOREIGN KEY (subject_id) REFERENCES subjects(subject_id) ON DELETE CASCADE,
+    CONSTRAINT fk_session_faculty FOREIGN KEY (faculty_id) REFERENCES faculty(faculty_id)
+);
+
+INSERT INTO teaching_sessions_new SELECT * FROM teaching_sessions;
+DROP TABLE teaching_sessions;
+ALTER TABLE teaching_sessions_new RENAME TO teaching_sessions;
+
+CREATE INDEX IF NOT EXISTS idx_teaching_sessions_faculty
+    ON teaching_sessions(faculty_id, date);
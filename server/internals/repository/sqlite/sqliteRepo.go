@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository"
+)
+
+// SQLiteRepo is the storage backend for single-classroom deployments that
+// run from one binary and one database file. It runs the same queries as
+// repository.PostgresRepo through repository.SQLRepo, in the SQLite dialect.
+//
+// SQLite has no date types, so dates are stored as "2006-01-02" and
+// timestamps as UTC "2006-01-02 15:04:05.000" text; both compare correctly
+// as strings. Open the database with a single connection: SQLite allows one
+// writer at a time.
+type SQLiteRepo struct {
+	*repository.SQLRepo
+	db *sql.DB
+}
+
+// NewSQLiteRepo builds the repository; a nil cal means UTC.
+func NewSQLiteRepo(db *sql.DB, cal *calendar.Calendar) *SQLiteRepo {
+	return &SQLiteRepo{SQLRepo: repository.NewSQLRepo(db, cal, Dialect), db: db}
+}
+
+// Dialect is the SQLite dialect of the shared queries.
+var Dialect = repository.Dialect{
+	Now:   "strftime('%Y-%m-%d %H:%M:%f', 'now')",
+	Stamp: func(t time.Time) any { return stamp(t) },
+	// Weeks start on the Monday found by stepping back strftime('%w')
+	// days, shifted so Sunday is 6.
+	WeekStart: func(column string) string {
+		return "date(" + column + ", '-' || ((CAST(strftime('%w', " + column + ") AS INTEGER) + 6) % 7) || ' days')"
+	},
+	ForUpdate:           func(string) string { return "" },
+	UniqueViolation:     isUniqueViolation,
+	ForeignKeyViolation: isForeignKeyViolation,
+}
+
+// timestampLayout matches strftime('%Y-%m-%d %H:%M:%f'), which the column
+// defaults use, so stored timestamps all have the same width.
+const timestampLayout = "2006-01-02 15:04:05.000"
+
+// stamp renders t the way timestamps are stored.
+func stamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
+
+var _ repository.Store = (*SQLiteRepo)(nil)
@@ -0,0 +1,791 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/sqlite"
+)
+
+var classDay = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+// open migrates a fresh database file the way cmd.ConnectToDB opens one.
+func open(t *testing.T) *sqlite.SQLiteRepo {
+	t.Helper()
+	return migrated(t, openDB(t), nil)
+}
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := "file:" + filepath.Join(t.TempDir(), "attendance.db") + "?_pragma=foreign_keys(1)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func migrated(t *testing.T, db *sql.DB, cal *calendar.Calendar) *sqlite.SQLiteRepo {
+	t.Helper()
+	repo := sqlite.NewSQLiteRepo(db, cal)
+	if _, err := repo.MigrateUp(); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+	if err := repo.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema: %v", err)
+	}
+	return repo
+}
+
+func seed(t *testing.T, repo *sqlite.SQLiteRepo) int64 {
+	t.Helper()
+	facultyID, err := repo.CreateFaculty(domain.FacultyRegisterPayload{Name: "Ravi", Email: "ravi@college.edu", Password: "secret123", Department: "CSE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.AddSubject(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: facultyID, Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.ImportStudents([]domain.StudentRegisterPayload{
+		{USN: "1RV21CS001", Username: "Alice", Department: "CSE", Sem: 5},
+		{USN: "1RV21CS002", Username: "Bob", Department: "CSE", Sem: 5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return facultyID
+}
+
+func TestConstraintViolationsAreDomainErrors(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+
+	_, err := repo.AddSubject(domain.SubjectPayload{Code: "CS501", Name: "Again", FacultyID: facultyID, Department: "CSE", Sem: 5})
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("duplicate subject: %v, want a conflict", err)
+	}
+	_, err = repo.AddSubject(domain.SubjectPayload{Code: "CS502", Name: "Networks", FacultyID: facultyID + 100, Department: "CSE", Sem: 5})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("unknown faculty: %v, want not found", err)
+	}
+	if _, err := repo.LoginStudent("1RV21CS999", "secret123"); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("unknown student login: %v, want unauthorized", err)
+	}
+}
+
+func TestMarkAttendanceUpsertsUnassignedRow(t *testing.T) {
+	repo := open(t)
+	seed(t, repo)
+
+	first, err := repo.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS001", Status: "Absent", RecordedAt: classDay.Add(9 * time.Hour)})
+	if err != nil {
+		t.Fatalf("MarkAttendance: %v", err)
+	}
+	second, err := repo.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS001", Status: "Present", RecordedAt: classDay.Add(9*time.Hour + 5*time.Minute)})
+	if err != nil {
+		t.Fatalf("MarkAttendance again: %v", err)
+	}
+	if first != second {
+		t.Errorf("second mark created row %d, want the upsert to reuse %d", second, first)
+	}
+}
+
+func TestAssignAndSummarize(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+
+	if _, err := repo.BulkMarkAttendance([]domain.AttendancePayload{
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: classDay.Add(9 * time.Hour)},
+		{USN: "1RV21CS002", Status: "Absent", RecordedAt: classDay.Add(9*time.Hour + 30*time.Second)},
+		// Outside the class window, so it stays unassigned.
+		{USN: "1RV21CS002", Status: "Present", RecordedAt: classDay.AddDate(0, 0, 1).Add(9 * time.Hour)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := classDay.Add(8 * time.Hour)
+	updated, skipped, err := repo.AssignSubjectToTimeRange(facultyID, "CS501", classDay, start, start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("AssignSubjectToTimeRange: %v", err)
+	}
+	if updated != 2 || skipped != 0 {
+		t.Fatalf("assigned %d, skipped %d; want 2, 0", updated, skipped)
+	}
+
+	history, total, err := repo.GetStudentAttendanceHistory("1RV21CS002", "CS501", domain.AttendanceFilter{}, domain.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetStudentAttendanceHistory: %v", err)
+	}
+	if total != 1 || !history[0].Date.Equal(classDay) || history[0].Status != "Absent" {
+		t.Fatalf("history = %+v (total %d)", history, total)
+	}
+
+	summary, err := repo.GetAttendanceSummaryByStudent("1RV21CS001", domain.AttendanceFilter{From: classDay, To: classDay})
+	if err != nil {
+		t.Fatalf("GetAttendanceSummaryByStudent: %v", err)
+	}
+	if len(summary) != 1 || summary[0].Percentage != 100 {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	defaulters, err := repo.GetDefaulters(domain.DefaulterQuery{Threshold: 75})
+	if err != nil {
+		t.Fatalf("GetDefaulters: %v", err)
+	}
+	if len(defaulters) != 1 || defaulters[0].USN != "1RV21CS002" || defaulters[0].ClassesHeld != 1 {
+		t.Fatalf("defaulters = %+v", defaulters)
+	}
+
+	reg, err := repo.GetAttendanceRegister("CS501", classDay, classDay.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("GetAttendanceRegister: %v", err)
+	}
+	if len(reg.Dates) != 1 || !reg.Dates[0].Equal(classDay) {
+		t.Fatalf("register dates = %v", reg.Dates)
+	}
+}
+
+func TestDefaultersIncludeUnmarkedStudents(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+	if _, err := repo.ImportStudents([]domain.StudentRegisterPayload{
+		{USN: "1RV21CS003", Username: "Chitra", Department: "CSE", Sem: 5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.BulkMarkAttendance([]domain.AttendancePayload{
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: classDay.Add(9 * time.Hour)},
+		{USN: "1RV21CS002", Status: "Present", RecordedAt: classDay.Add(9 * time.Hour)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	start := classDay.Add(8 * time.Hour)
+	if _, _, err := repo.AssignSubjectToTimeRange(facultyID, "CS501", classDay, start, start.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	defaulters, err := repo.GetDefaulters(domain.DefaulterQuery{Threshold: 75, FacultyID: facultyID})
+	if err != nil {
+		t.Fatalf("GetDefaulters: %v", err)
+	}
+	if len(defaulters) != 1 || defaulters[0].USN != "1RV21CS003" || defaulters[0].TotalClasses != 1 || defaulters[0].Attended != 0 {
+		t.Fatalf("defaulters = %+v, want Chitra at 0 of 1", defaulters)
+	}
+
+	defaulters, err = repo.GetDefaulters(domain.DefaulterQuery{Threshold: 75, FacultyID: facultyID + 1})
+	if err != nil || len(defaulters) != 0 {
+		t.Fatalf("defaulters of another faculty = %+v, %v", defaulters, err)
+	}
+}
+
+func TestCondonationNeedsAnEnrolledStudent(t *testing.T) {
+	repo := open(t)
+	seed(t, repo)
+	adminID, err := repo.CreateAdmin("admin", "admin@college.edu", "secret123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.ImportStudents([]domain.StudentRegisterPayload{
+		{USN: "1RV21CS101", Username: "Deepa", Department: "CSE", Sem: 3},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		usn, subject string
+		want         error
+	}{
+		{"1RV21CS999", "CS501", domain.ErrNotFound},
+		{"1RV21CS001", "CS999", domain.ErrNotFound},
+		{"1RV21CS101", "CS501", domain.ErrValidation},
+	} {
+		err := repo.SetCondonation(adminID, domain.CondonationPayload{USN: tc.usn, SubjectCode: tc.subject, Reason: "medical"})
+		if !errors.Is(err, tc.want) {
+			t.Errorf("condonation of %s in %s: %v, want %v", tc.usn, tc.subject, err, tc.want)
+		}
+	}
+
+	for _, reason := range []string{"medical", "sports"} {
+		if err := repo.SetCondonation(adminID, domain.CondonationPayload{USN: "1RV21CS001", SubjectCode: "CS501", Reason: reason}); err != nil {
+			t.Fatalf("SetCondonation(%s): %v", reason, err)
+		}
+	}
+}
+
+func TestSubjectSummaryIncludesUnmarkedStudents(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+	if _, err := repo.ImportStudents([]domain.StudentRegisterPayload{
+		{USN: "1RV21CS003", Username: "Chitra", Department: "CSE", Sem: 5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.BulkMarkAttendance([]domain.AttendancePayload{
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: classDay.Add(9 * time.Hour)},
+		{USN: "1RV21CS002", Status: "Absent", RecordedAt: classDay.Add(9 * time.Hour)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	start := classDay.Add(8 * time.Hour)
+	if _, _, err := repo.AssignSubjectToTimeRange(facultyID, "CS501", classDay, start, start.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	for _, offset := range []int{0, 2} {
+		if _, err := repo.LogTeachingSession(facultyID, domain.TeachingSession{
+			SubjectCode: "CS501", Date: classDay.AddDate(0, 0, offset), StartTime: "08:00", DurationMinutes: 60, Topic: "Parsing",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summary, total, err := repo.GetAttendanceSummaryBySubject("CS501", domain.AttendanceFilter{}, domain.PageRequest{Limit: 10, Sort: "usn"})
+	if err != nil {
+		t.Fatalf("GetAttendanceSummaryBySubject: %v", err)
+	}
+	if total != 3 || len(summary) != 3 {
+		t.Fatalf("summary = %+v (total %d), want all three enrolled students", summary, total)
+	}
+	if s := summary[0]; s.Attended != 1 || s.TotalClasses != 2 || s.Percentage != 50 {
+		t.Errorf("Alice = %+v, want 1 of 2", s)
+	}
+	if s := summary[2]; s.USN != "1RV21CS003" || s.Attended != 0 || s.TotalClasses != 2 || s.Percentage != 0 {
+		t.Errorf("Chitra = %+v, want 0 of 2", s)
+	}
+
+	subjects, err := repo.GetAttendanceSummaryByStudent("1RV21CS003", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatalf("GetAttendanceSummaryByStudent: %v", err)
+	}
+	if len(subjects) != 1 || subjects[0].SubjectName != "Compilers" || subjects[0].TotalClasses != 2 || subjects[0].Percentage != 0 {
+		t.Errorf("Chitra's subjects = %+v, want Compilers at 0 of 2", subjects)
+	}
+}
+
+func TestDepartmentAnalytics(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+
+	// Classes on Monday, Thursday and the next Monday, where Alice is
+	// absent; Tuesday's capture is never assigned.
+	for _, offset := range []int{0, 3, 7, 8} {
+		date := classDay.AddDate(0, 0, offset)
+		alice := "Present"
+		if offset == 7 {
+			alice = "Absent"
+		}
+		if _, err := repo.BulkMarkAttendance([]domain.AttendancePayload{
+			{USN: "1RV21CS001", Status: alice, RecordedAt: date.Add(9 * time.Hour)},
+			{USN: "1RV21CS002", Status: "Present", RecordedAt: date.Add(9 * time.Hour)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if offset == 8 {
+			continue
+		}
+		start := date.Add(8 * time.Hour)
+		if _, _, err := repo.AssignSubjectToTimeRange(facultyID, "CS501", date, start, start.Add(2*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a, err := repo.GetDepartmentAnalytics(domain.AnalyticsQuery{Department: "CSE", TopAbsentees: 10})
+	if err != nil {
+		t.Fatalf("GetDepartmentAnalytics: %v", err)
+	}
+	if len(a.Semesters) != 1 || a.Semesters[0].Students != 2 || a.Semesters[0].Present != 5 || a.Semesters[0].Total != 6 {
+		t.Errorf("semesters = %+v", a.Semesters)
+	}
+	if len(a.Subjects) != 1 || a.Subjects[0].ClassesHeld != 3 || a.Subjects[0].FacultyName != "Ravi" {
+		t.Errorf("subjects = %+v", a.Subjects)
+	}
+	if len(a.Weekly) != 2 || !a.Weekly[0].WeekStart.Equal(classDay) || a.Weekly[0].Total != 4 ||
+		!a.Weekly[1].WeekStart.Equal(classDay.AddDate(0, 0, 7)) || a.Weekly[1].Present != 1 {
+		t.Errorf("weekly = %+v", a.Weekly)
+	}
+	if len(a.Faculty) != 1 || a.Faculty[0].Sessions != 3 || a.Faculty[0].Subjects != 1 {
+		t.Errorf("faculty = %+v", a.Faculty)
+	}
+	if len(a.TopAbsentees) != 1 || a.TopAbsentees[0].USN != "1RV21CS001" || a.TopAbsentees[0].Absent != 1 {
+		t.Errorf("top absentees = %+v", a.TopAbsentees)
+	}
+	if len(a.Unassigned) != 1 || !a.Unassigned[0].Date.Equal(classDay.AddDate(0, 0, 8)) || a.Unassigned[0].Rows != 2 {
+		t.Errorf("unassigned = %+v", a.Unassigned)
+	}
+}
+
+func TestStudentProgress(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+	if err := repo.SetPlannedClasses("CS501", 10); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, status := range []string{"Present", "Absent"} {
+		date := classDay.AddDate(0, 0, i)
+		if _, err := repo.BulkMarkAttendance([]domain.AttendancePayload{
+			{USN: "1RV21CS001", Status: status, RecordedAt: date.Add(9 * time.Hour)},
+			{USN: "1RV21CS002", Status: "Present", RecordedAt: date.Add(9 * time.Hour)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		start := date.Add(8 * time.Hour)
+		if _, _, err := repo.AssignSubjectToTimeRange(facultyID, "CS501", date, start, start.Add(2*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	days, err := repo.GetStudentDailyAttendance("1RV21CS001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatalf("GetStudentDailyAttendance: %v", err)
+	}
+	if len(days) != 2 || !days[1].Date.Equal(classDay.AddDate(0, 0, 1)) || days[1].Status != "Absent" {
+		t.Errorf("days = %+v", days)
+	}
+
+	progress, err := repo.GetStudentProgress("1RV21CS001", domain.AttendanceFilter{To: classDay})
+	if err != nil {
+		t.Fatalf("GetStudentProgress: %v", err)
+	}
+	if len(progress) != 1 || progress[0].Attended != 1 || progress[0].Total != 1 || progress[0].ClassesHeld != 1 || progress[0].PlannedClasses != 10 {
+		t.Errorf("progress = %+v", progress)
+	}
+}
+
+// Sessions are logged on the first and third day, two of them on the first;
+// the second day's rows were captured without a session and drop out of the
+// percentages, and the first day's rows count once per session.
+func TestTeachingSessionsAreTheDenominator(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+
+	for i := range 2 {
+		date := classDay.AddDate(0, 0, i)
+		if _, err := repo.BulkMarkAttendance([]domain.AttendancePayload{
+			{USN: "1RV21CS001", Status: "Present", RecordedAt: date.Add(9 * time.Hour)},
+			{USN: "1RV21CS002", Status: "Absent", RecordedAt: date.Add(9 * time.Hour)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		start := date.Add(8 * time.Hour)
+		if _, _, err := repo.AssignSubjectToTimeRange(facultyID, "CS501", date, start, start.Add(2*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, offset := range []int{0, 2} {
+		if _, err := repo.LogTeachingSession(facultyID, domain.TeachingSession{
+			SubjectCode: "CS501", Date: classDay.AddDate(0, 0, offset), StartTime: "08:00", DurationMinutes: 60, Topic: "Parsing",
+		}); err != nil {
+			t.Fatalf("LogTeachingSession: %v", err)
+		}
+	}
+	_, err := repo.LogTeachingSession(facultyID, domain.TeachingSession{SubjectCode: "CS501", Date: classDay, StartTime: "08:00", DurationMinutes: 60, Topic: "Again"})
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second session on a date and start time: %v, want a conflict", err)
+	}
+	if _, err := repo.LogTeachingSession(facultyID, domain.TeachingSession{
+		SubjectCode: "CS501", Date: classDay, StartTime: "10:00", DurationMinutes: 60, Topic: "Tutorial",
+	}); err != nil {
+		t.Fatalf("second session on a date at another time: %v", err)
+	}
+
+	summary, err := repo.GetAttendanceSummaryByStudent("1RV21CS001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary) != 1 || summary[0].TotalClasses != 3 || summary[0].Attended != 2 || summary[0].Percentage != 66.67 {
+		t.Errorf("summary = %+v, want 2 of 3 logged sessions", summary)
+	}
+
+	days, err := repo.GetStudentDailyAttendance("1RV21CS001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatalf("GetStudentDailyAttendance: %v", err)
+	}
+	if len(days) != 3 || !days[1].Date.Equal(classDay) || days[1].Status != "Present" ||
+		!days[2].Date.Equal(classDay.AddDate(0, 0, 2)) || days[2].Status != "Absent" {
+		t.Errorf("days = %+v, want the three logged sessions, the last missed", days)
+	}
+
+	defaulters, err := repo.GetDefaulters(domain.DefaulterQuery{Threshold: 75})
+	if err != nil {
+		t.Fatalf("GetDefaulters: %v", err)
+	}
+	if len(defaulters) != 2 || defaulters[0].TotalClasses != 3 || defaulters[0].ClassesHeld != 3 || defaulters[0].Attended != 2 {
+		t.Errorf("defaulters = %+v", defaulters)
+	}
+
+	reg, err := repo.GetAttendanceRegister("CS501", classDay, classDay.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reg.SessionsLogged || len(reg.Dates) != 3 || !reg.Dates[1].Equal(classDay) || reg.StartTimes[1] != "10:00" ||
+		!reg.Dates[2].Equal(classDay.AddDate(0, 0, 2)) {
+		t.Errorf("register = %+v, want a column per logged session", reg)
+	}
+	var rows []domain.AttendanceRegisterRow
+	if err := repo.StreamAttendanceRegisterRows(reg, func(row domain.AttendanceRegisterRow) error {
+		rows = append(rows, row)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || strings.Join(rows[0].Cells, ",") != "P,P," || rows[0].Attended != 2 || rows[0].Total != 3 {
+		t.Errorf("register rows = %+v", rows)
+	}
+
+	log, err := repo.GetTeachingLog(domain.TeachingLogQuery{SubjectCode: "CS501"})
+	if err != nil {
+		t.Fatalf("GetTeachingLog: %v", err)
+	}
+	if len(log) != 3 || log[1].StartTime != "10:00" || log[1].Present != 1 || log[1].Absent != 1 ||
+		log[2].Present+log[2].Absent != 0 || log[0].FacultyName != "Ravi" {
+		t.Errorf("log = %+v", log)
+	}
+}
+
+func TestCaptureSubject(t *testing.T) {
+	repo := open(t)
+	facultyID := seed(t, repo)
+	if _, err := repo.LogTeachingSession(facultyID, domain.TeachingSession{
+		SubjectCode: "CS501", Date: classDay, StartTime: "09:00", DurationMinutes: 60, Topic: "Parsing",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		usn  string
+		at   time.Time
+		want string
+	}{
+		{"1RV21CS001", classDay.Add(9*time.Hour + 10*time.Minute), "CS501"},
+		{"1RV21CS001", classDay.Add(10*time.Hour + 30*time.Minute), ""},
+		{"1RV21CS001", classDay.AddDate(0, 0, 1).Add(9 * time.Hour), ""},
+		{"1RV21CS999", classDay.Add(9*time.Hour + 10*time.Minute), ""},
+	} {
+		got, err := repo.GetCaptureSubject(tc.usn, tc.at)
+		if err != nil || got != tc.want {
+			t.Errorf("GetCaptureSubject(%s, %s) = %q, %v, want %q", tc.usn, tc.at.Format(time.Kitchen), got, err, tc.want)
+		}
+	}
+}
+
+func TestSubstituteAuthority(t *testing.T) {
+	repo := open(t)
+	ravi := seed(t, repo)
+	asha, err := repo.CreateFaculty(domain.FacultyRegisterPayload{Name: "Asha", Email: "asha@college.edu", Password: "secret123", Department: "CSE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.AddSubject(domain.SubjectPayload{Code: "CS502", Name: "Networks", FacultyID: asha, Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS001", Status: "Present", RecordedAt: classDay.Add(9 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	start := classDay.Add(8 * time.Hour)
+	if _, _, err := repo.AssignSubjectToTimeRange(asha, "CS501", classDay, start, start.Add(2*time.Hour)); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("assign without a substitution: %v, want forbidden", err)
+	}
+
+	id, err := repo.CreateSubstitution(ravi, domain.Substitution{Department: "CSE", SubjectCode: "CS501", Date: classDay, SubstituteID: asha})
+	if err != nil {
+		t.Fatalf("CreateSubstitution: %v", err)
+	}
+	if _, err := repo.CreateSubstitution(ravi, domain.Substitution{Department: "CSE", SubjectCode: "CS501", Date: classDay.AddDate(0, 0, 1), SubstituteID: asha + 100}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("unknown substitute: %v, want not found", err)
+	}
+	if n, _, err := repo.AssignSubjectToTimeRange(asha, "CS501", classDay, start, start.Add(2*time.Hour)); err != nil || n != 1 {
+		t.Fatalf("substitute assigning = %d, %v", n, err)
+	}
+	rows, err := repo.GetAttendanceBySubjectAndDate("CS501", classDay)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("GetAttendanceBySubjectAndDate = %v, %v", rows, err)
+	}
+	if _, err := repo.CorrectAttendance(asha, rows[0].ID, "Absent"); err != nil {
+		t.Errorf("substitute correcting: %v", err)
+	}
+	if _, err := repo.LogTeachingSession(asha, domain.TeachingSession{SubjectCode: "CS501", Date: classDay, StartTime: "08:00", DurationMinutes: 60, Topic: "Parsing"}); err != nil {
+		t.Errorf("substitute logging the session: %v", err)
+	}
+
+	subs, err := repo.GetSubstitutions(domain.SubstitutionQuery{FacultyID: asha, Filter: domain.AttendanceFilter{From: classDay, To: classDay}})
+	if err != nil || len(subs) != 1 || subs[0].FacultyName != "Ravi" || subs[0].SubstituteName != "Asha" || !subs[0].Date.Equal(classDay) {
+		t.Errorf("GetSubstitutions = %+v, %v", subs, err)
+	}
+
+	if _, err := repo.CreateClassSwap(ravi, domain.ClassSwap{
+		Department: "CSE", Date: classDay, SubjectCode: "CS501", Start: "09:00", End: "10:00",
+		OtherSubjectCode: "CS502", OtherStart: "11:00", OtherEnd: "12:00",
+	}); err != nil {
+		t.Fatalf("CreateClassSwap: %v", err)
+	}
+	swaps, err := repo.GetClassSwaps(domain.SubstitutionQuery{FacultyID: asha})
+	if err != nil || len(swaps) != 1 || swaps[0].SubjectCode != "CS501" || swaps[0].OtherSubjectName != "Networks" {
+		t.Errorf("GetClassSwaps = %+v, %v", swaps, err)
+	}
+
+	if err := repo.DeleteSubstitution("CSE", id); err != nil {
+		t.Fatalf("DeleteSubstitution: %v", err)
+	}
+	if _, err := repo.CorrectAttendance(asha, rows[0].ID, "Present"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("correct after the substitution was withdrawn: %v, want forbidden", err)
+	}
+}
+
+func TestAnomalyFlags(t *testing.T) {
+	repo := open(t)
+	ravi := seed(t, repo)
+
+	confidence := 0.4
+	capture := domain.AttendancePayload{USN: "1RV21CS001", Status: "Present", RecordedAt: classDay.Add(9 * time.Hour), Room: "A101", Confidence: &confidence}
+	box := &domain.BoundingBox{X: 10, Y: 20, Width: 64, Height: 80}
+	eventID, err := repo.RecordRecognitionEvent(domain.RecognitionEvent{
+		USN: capture.USN, Status: capture.Status, RecordedAt: capture.RecordedAt, Room: capture.Room, Confidence: capture.Confidence,
+		DeviceID: "cam-3", BoundingBox: box, FaceCropKey: strings.Repeat("ab", 32),
+	})
+	if err != nil {
+		t.Fatalf("RecordRecognitionEvent: %v", err)
+	}
+	if _, err := repo.RecordRecognitionEvent(domain.RecognitionEvent{USN: "1RV21CS001", Status: "Present", RecordedAt: classDay.Add(8 * time.Hour)}); err != nil {
+		t.Fatalf("RecordRecognitionEvent without evidence: %v", err)
+	}
+	if _, err := repo.RecordRecognitionEvent(domain.RecognitionEvent{USN: "1RV21CS999", Status: "Present", RecordedAt: classDay}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("event for an unknown usn: %v, want not found", err)
+	}
+	events, err := repo.GetRecognitionEvents("1RV21CS001", classDay, classDay.Add(10*time.Hour))
+	if err != nil || len(events) != 2 {
+		t.Fatalf("GetRecognitionEvents = %+v, %v", events, err)
+	}
+	if e := events[0]; e.BoundingBox != nil || e.FaceCropKey != "" || e.DeviceID != "" {
+		t.Errorf("event without evidence = %+v", e)
+	}
+	if e := events[1]; e.Room != "A101" || e.Confidence == nil || *e.Confidence != confidence ||
+		e.DeviceID != "cam-3" || e.BoundingBox == nil || *e.BoundingBox != *box || e.FaceCropKey != strings.Repeat("ab", 32) {
+		t.Errorf("event with evidence = %+v", e)
+	}
+
+	if _, err := repo.LogTeachingSession(ravi, domain.TeachingSession{SubjectCode: "CS501", Date: classDay, StartTime: "09:00", DurationMinutes: 60, Topic: "Parsing"}); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := repo.GetStudentSessions("1RV21CS001", classDay)
+	if err != nil || len(sessions) != 1 || sessions[0].StartTime != "09:00" {
+		t.Errorf("GetStudentSessions = %+v, %v", sessions, err)
+	}
+
+	flagID, err := repo.CreateAnomalyFlag(eventID, []string{domain.AnomalyLowConfidence, domain.AnomalyOutsideSession}, "confidence 0.40 is below 0.60")
+	if err != nil {
+		t.Fatalf("CreateAnomalyFlag: %v", err)
+	}
+	flags, err := repo.GetAnomalyFlags(domain.FlagQuery{Department: "CSE", Review: domain.FlagOpen, Filter: domain.AttendanceFilter{From: classDay, To: classDay}})
+	if err != nil || len(flags) != 1 || len(flags[0].Reasons) != 2 || flags[0].StudentName != "Alice" || flags[0].AttendanceID != nil || flags[0].Event.DeviceID != "cam-3" {
+		t.Fatalf("GetAnomalyFlags = %+v, %v", flags, err)
+	}
+	if other, err := repo.GetAnomalyFlags(domain.FlagQuery{Department: "ECE"}); err != nil || len(other) != 0 {
+		t.Errorf("flags of another department = %+v, %v", other, err)
+	}
+
+	attendanceID, err := repo.MarkAttendance(&capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.ResolveAnomalyFlag(flagID, ravi, domain.FlagAccepted, &attendanceID); err != nil {
+		t.Fatalf("ResolveAnomalyFlag: %v", err)
+	}
+	if err := repo.ResolveAnomalyFlag(flagID, ravi, domain.FlagRejected, nil); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second review: %v, want a conflict", err)
+	}
+	if err := repo.ResolveAnomalyFlag(flagID+1, ravi, domain.FlagRejected, nil); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("review of a missing flag: %v, want not found", err)
+	}
+	flag, err := repo.GetAnomalyFlag(flagID)
+	if err != nil || flag.Review != domain.FlagAccepted || flag.AttendanceID == nil || *flag.AttendanceID != attendanceID || flag.ReviewedAt == nil {
+		t.Errorf("GetAnomalyFlag = %+v, %v", flag, err)
+	}
+}
+
+func TestClaimDueDeliveries(t *testing.T) {
+	repo := open(t)
+	if _, err := repo.CreateWebhook(domain.WebhookPayload{URL: "http://example.test/hook", EventTypes: []string{"attendance.recorded"}, Secret: "0123456789abcdef"}); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := repo.EnqueueDeliveries("attendance.recorded", []byte(`{}`)); err != nil || n != 1 {
+		t.Fatalf("EnqueueDeliveries = %d, %v", n, err)
+	}
+
+	claimed, err := repo.ClaimDueDeliveries(10, time.Minute)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("ClaimDueDeliveries = %v, %v", claimed, err)
+	}
+	// The lease hides the delivery from the next poll.
+	if again, err := repo.ClaimDueDeliveries(10, time.Minute); err != nil || len(again) != 0 {
+		t.Fatalf("second claim = %v, %v", again, err)
+	}
+}
+
+// Rows stored before "*" was normalized may list it next to other events.
+func TestWildcardMatchesAsAListElement(t *testing.T) {
+	repo := open(t)
+	if _, err := repo.CreateWebhook(domain.WebhookPayload{URL: "http://example.test/hook", EventTypes: []string{"attendance.recorded", "*"}, Secret: "0123456789abcdef"}); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := repo.EnqueueDeliveries("attendance.corrected", []byte(`{}`)); err != nil || n != 1 {
+		t.Fatalf("EnqueueDeliveries = %d, %v, want the wildcard to match", n, err)
+	}
+}
+
+func TestMigrateDown(t *testing.T) {
+	repo := open(t)
+	reverted, err := repo.MigrateDown(100)
+	if err != nil {
+		t.Fatalf("MigrateDown: %v", err)
+	}
+	if len(reverted) != 13 {
+		t.Fatalf("reverted %v, want all 13 migrations", reverted)
+	}
+	if err := repo.CheckSchema(); err == nil {
+		t.Fatal("CheckSchema passed with every migration reverted")
+	}
+}
+
+func TestDepartmentsMigrationNormalizes(t *testing.T) {
+	db := openDB(t)
+	repo := migrated(t, db, nil)
+	// Back to before migration 8.
+	states, err := repo.MigrationStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.MigrateDown(len(states) - 7); err != nil {
+		t.Fatalf("MigrateDown: %v", err)
+	}
+	for _, q := range []string{
+		`INSERT INTO faculty (faculty_name, email, password_hash, department) VALUES ('Ravi', 'ravi@college.edu', 'x', 'cse ')`,
+		`INSERT INTO students (usn, username, department, sem) VALUES ('1RV21CS001', 'Alice', 'Computer Science', 5)`,
+		`INSERT INTO subjects (subject_code, subject_name, department, sem, faculty_id) VALUES ('PH101', 'Physics', 'Physics', 1, 1)`,
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repo.MigrateUp(); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+
+	var faculty, student string
+	if err := db.QueryRow(`SELECT (SELECT department FROM faculty), (SELECT department FROM students)`).Scan(&faculty, &student); err != nil {
+		t.Fatal(err)
+	}
+	if faculty != "CSE" || student != "CSE" {
+		t.Errorf("normalized departments = %q, %q, want CSE", faculty, student)
+	}
+	if _, err := repo.GetDepartment("PHYSICS"); err != nil {
+		t.Errorf("leftover value did not become a department: %v", err)
+	}
+
+	if err := repo.DeleteDepartment("CSE"); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("delete department in use: %v, want a conflict", err)
+	}
+	if _, err := db.Exec(`UPDATE students SET department = 'XYZ'`); err == nil {
+		t.Error("trigger let a student move to an unknown department")
+	}
+	_, err = repo.CreateFaculty(domain.FacultyRegisterPayload{Name: "Asha", Email: "asha@college.edu", Password: "secret123", Department: "XYZ"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("faculty in unknown department: %v, want a validation error", err)
+	}
+}
+
+func TestRepairAttendanceDates(t *testing.T) {
+	db := openDB(t)
+	utc := migrated(t, db, nil)
+	seed(t, utc)
+
+	// 04:30 IST on 3 June, filed under 2 June by a UTC-bucketing build.
+	early := classDay.Add(23 * time.Hour)
+	for _, a := range []domain.AttendancePayload{
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: early},
+		{USN: "1RV21CS002", Status: "Present", RecordedAt: early},
+	} {
+		if _, err := utc.MarkAttendance(&a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ist := migrated(t, db, calendar.New(time.FixedZone("IST", 5*3600+1800)))
+	// Bob already has a correctly dated row for 3 June, so his old row cannot move.
+	if _, err := ist.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS002", Status: "Absent", RecordedAt: early.Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := ist.RepairAttendanceDates(true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(dry) != 2 || dry[0].Conflict || !dry[1].Conflict {
+		t.Fatalf("dry run = %+v", dry)
+	}
+
+	if _, err := ist.RepairAttendanceDates(false); err != nil {
+		t.Fatalf("RepairAttendanceDates: %v", err)
+	}
+	again, err := ist.RepairAttendanceDates(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 1 || again[0].USN != "1RV21CS002" || !again[0].Conflict {
+		t.Fatalf("after repair, still to fix: %+v", again)
+	}
+}
+
+// A row can move onto a date another row is leaving, and two rows can trade
+// dates; neither is a conflict, whichever row comes first.
+func TestRepairChainedAndSwappedDates(t *testing.T) {
+	db := openDB(t)
+	repo := migrated(t, db, nil)
+	seed(t, repo)
+
+	for _, usn := range []string{"1RV21CS001", "1RV21CS002"} {
+		for day := 1; day <= 2; day++ {
+			if _, err := repo.MarkAttendance(&domain.AttendancePayload{USN: usn, Status: "Present", RecordedAt: classDay.AddDate(0, 0, day).Add(9 * time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// Alice's rows each slipped a day early; Bob's traded places.
+	for _, q := range []string{
+		`UPDATE attendance SET date = '2025-06-02' WHERE usn = '1RV21CS001' AND date = '2025-06-03'`,
+		`UPDATE attendance SET date = '2025-06-03' WHERE usn = '1RV21CS001' AND date = '2025-06-04'`,
+		`UPDATE attendance SET date = '2025-06-01' WHERE usn = '1RV21CS002' AND date = '2025-06-03'`,
+		`UPDATE attendance SET date = '2025-06-03' WHERE usn = '1RV21CS002' AND date = '2025-06-04'`,
+		`UPDATE attendance SET date = '2025-06-04' WHERE usn = '1RV21CS002' AND date = '2025-06-01'`,
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repairs, err := repo.RepairAttendanceDates(false)
+	if err != nil {
+		t.Fatalf("RepairAttendanceDates: %v", err)
+	}
+	if len(repairs) != 4 {
+		t.Fatalf("repairs = %+v, want all four rows moved", repairs)
+	}
+	for _, r := range repairs {
+		if r.Conflict {
+			t.Errorf("attendance %d reported as a conflict", r.AttendanceID)
+		}
+	}
+	if again, err := repo.RepairAttendanceDates(false); err != nil || len(again) != 0 {
+		t.Fatalf("after repair, still to fix: %+v, %v", again, err)
+	}
+}
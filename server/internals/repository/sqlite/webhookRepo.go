@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// ClaimDueDeliveries leases due rows by pushing their next attempt past the
+// lease. SQLite has a single writer, so the transaction alone keeps two
+// workers from claiming the same row.
+func (s *SQLiteRepo) ClaimDueDeliveries(limit int, lease time.Duration) ([]domain.PendingDelivery, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	rows, err := tx.Query(`
+	SELECT d.delivery_id, d.webhook_id, d.event_type, d.payload, d.attempts, d.created_at, w.url, w.secret
+	FROM webhook_deliveries d
+	JOIN webhook_subscriptions w ON w.webhook_id = d.webhook_id
+	WHERE d.status = 'pending' AND d.next_attempt_at <= $1
+	ORDER BY d.next_attempt_at
+	LIMIT $2;`, stamp(now), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim deliveries: %w", err)
+	}
+
+	var list []domain.PendingDelivery
+	for rows.Next() {
+		var d domain.PendingDelivery
+		var payload string
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventType, &payload, &d.Attempts, &d.CreatedAt, &d.URL, &d.Secret); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan delivery: %w", err)
+		}
+		d.Payload = []byte(payload)
+		d.Status = domain.DeliveryPending
+		list = append(list, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	for _, d := range list {
+		if _, err := tx.Exec(`UPDATE webhook_deliveries SET next_attempt_at = $2 WHERE delivery_id = $1;`,
+			d.ID, stamp(now.Add(lease))); err != nil {
+			return nil, fmt.Errorf("lease delivery %d: %w", d.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return list, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import "github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+
+// Migrator applies and inspects a backend's versioned schema.
+type Migrator interface {
+	MigrateUp() ([]int, error)
+	MigrateDown(steps int) ([]int, error)
+	MigrationStatus() ([]MigrationState, error)
+	CheckSchema() error
+}
+
+// DateRepairer moves attendance rows to the class date of their
+// recorded_at; dryRun reports the moves without making them.
+type DateRepairer interface {
+	RepairAttendanceDates(dryRun bool) ([]domain.DateRepair, error)
+}
+
+// Store is a complete storage backend: every repository the services need
+// plus its migrations. PostgresRepo and sqlite.SQLiteRepo both implement it.
+type Store interface {
+	domain.StudentRepo
+	domain.SubjectRepo
+	domain.FacultyRepo
+	domain.AdminRepo
+	domain.AttendanceRepository
+	domain.TermRepo
+	domain.ReportRepo
+	domain.DefaulterRepo
+	domain.ImportRepo
+	domain.NotificationRepo
+	domain.GuardianRepo
+	domain.WebhookRepo
+	domain.DepartmentRepo
+	domain.AnalyticsRepo
+	domain.StudentTrendRepo
+	domain.TeachingSessionRepo
+	domain.SubstitutionRepo
+	domain.AnomalyRepo
+	Migrator
+	DateRepairer
+}
+
+var _ Store = (*PostgresRepo)(nil)
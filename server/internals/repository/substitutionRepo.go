@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// departmentSubject looks up a subject of department by code and returns its
+// id and faculty.
+func (p *SQLRepo) departmentSubject(department, code string) (int64, int64, error) {
+	var subjectID, facultyID int64
+	var dept string
+	err := p.db.QueryRow(`SELECT subject_id, faculty_id, department FROM subjects WHERE subject_code = $1`, code).
+		Scan(&subjectID, &facultyID, &dept)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, 0, domain.NotFound("subject not found for code: %s", code)
+		}
+		return 0, 0, fmt.Errorf("lookup subject: %w", err)
+	}
+	if dept != department {
+		return 0, 0, domain.Forbidden("subject %s is not in department %s", code, department)
+	}
+	return subjectID, facultyID, nil
+}
+
+// substitutes reports whether facultyID covers subjectID's classes on date.
+func substitutes(q interface {
+	QueryRow(string, ...any) *sql.Row
+}, subjectID, facultyID int64, date string) (bool, error) {
+	var ok bool
+	err := q.QueryRow(`
+	SELECT EXISTS (
+	    SELECT 1 FROM substitutions
+	    WHERE subject_id = $1 AND date = $2 AND substitute_faculty_id = $3
+	);`, subjectID, date, facultyID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("check substitution: %w", err)
+	}
+	return ok, nil
+}
+
+func (p *SQLRepo) CreateSubstitution(assignedBy int64, s domain.Substitution) (int64, error) {
+	subjectID, ownerID, err := p.departmentSubject(s.Department, s.SubjectCode)
+	if err != nil {
+		return 0, err
+	}
+	if ownerID == s.SubstituteID {
+		return 0, domain.Invalid("substitute_faculty_id", "nefield", "the substitute already teaches %s", s.SubjectCode)
+	}
+
+	var id int64
+	err = p.db.QueryRow(`
+	INSERT INTO substitutions (subject_id, date, substitute_faculty_id, reason, assigned_by)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING substitution_id;`,
+		subjectID, s.Date.Format("2006-01-02"), s.SubstituteID, s.Reason, assignedBy).Scan(&id)
+	if err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("%s already has a substitute on %s", s.SubjectCode, s.Date.Format("2006-01-02")).Wrap(err)
+		}
+		if p.isForeignKeyViolation(err) {
+			return 0, domain.NotFound("faculty not found: %d", s.SubstituteID).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert substitution: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) DeleteSubstitution(department string, id int64) error {
+	res, err := p.db.Exec(`
+	DELETE FROM substitutions
+	WHERE substitution_id = $1
+	  AND subject_id IN (SELECT subject_id FROM subjects WHERE department = $2);`, id, department)
+	if err != nil {
+		return fmt.Errorf("delete substitution: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("substitution not found: %d", id)
+	}
+	return nil
+}
+
+func (p *SQLRepo) GetSubstitutions(query domain.SubstitutionQuery) ([]domain.Substitution, error) {
+	cond, args := dateRange("s.date", query.Filter, []any{query.Department, query.FacultyID})
+	rows, err := p.db.Query(`
+	SELECT s.substitution_id, sub.subject_code, sub.subject_name, sub.department, s.date,
+	       sub.faculty_id, f.faculty_name, s.substitute_faculty_id, sf.faculty_name,
+	       s.reason, s.assigned_by, s.created_at
+	FROM substitutions s
+	JOIN subjects sub ON sub.subject_id = s.subject_id
+	JOIN faculty f ON f.faculty_id = sub.faculty_id
+	JOIN faculty sf ON sf.faculty_id = s.substitute_faculty_id
+	WHERE (sub.department = $1 OR $1 = '')
+	  AND ($2 = 0 OR s.substitute_faculty_id = $2 OR sub.faculty_id = $2)`+cond+`
+	ORDER BY s.date, sub.subject_code;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get substitutions: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Substitution
+	for rows.Next() {
+		var s domain.Substitution
+		if err := rows.Scan(&s.ID, &s.SubjectCode, &s.SubjectName, &s.Department, &s.Date,
+			&s.FacultyID, &s.FacultyName, &s.SubstituteID, &s.SubstituteName,
+			&s.Reason, &s.AssignedBy, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan substitution: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) CreateClassSwap(assignedBy int64, s domain.ClassSwap) (int64, error) {
+	subjectID, _, err := p.departmentSubject(s.Department, s.SubjectCode)
+	if err != nil {
+		return 0, err
+	}
+	otherID, _, err := p.departmentSubject(s.Department, s.OtherSubjectCode)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = p.db.QueryRow(`
+	INSERT INTO class_swaps (date, subject_id, start_time, end_time,
+	                         other_subject_id, other_start_time, other_end_time, reason, assigned_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING swap_id;`,
+		s.Date.Format("2006-01-02"), subjectID, s.Start, s.End,
+		otherID, s.OtherStart, s.OtherEnd, s.Reason, assignedBy).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("insert class swap: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) DeleteClassSwap(department string, id int64) error {
+	res, err := p.db.Exec(`
+	DELETE FROM class_swaps
+	WHERE swap_id = $1
+	  AND subject_id IN (SELECT subject_id FROM subjects WHERE department = $2);`, id, department)
+	if err != nil {
+		return fmt.Errorf("delete class swap: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("class swap not found: %d", id)
+	}
+	return nil
+}
+
+func (p *SQLRepo) GetClassSwaps(query domain.SubstitutionQuery) ([]domain.ClassSwap, error) {
+	cond, args := dateRange("cs.date", query.Filter, []any{query.Department, query.FacultyID})
+	rows, err := p.db.Query(`
+	SELECT cs.swap_id, sub.department, cs.date,
+	       sub.subject_code, sub.subject_name, cs.start_time, cs.end_time,
+	       other.subject_code, other.subject_name, cs.other_start_time, cs.other_end_time,
+	       cs.reason, cs.assigned_by, cs.created_at
+	FROM class_swaps cs
+	JOIN subjects sub ON sub.subject_id = cs.subject_id
+	JOIN subjects other ON other.subject_id = cs.other_subject_id
+	WHERE (sub.department = $1 OR $1 = '')
+	  AND ($2 = 0 OR sub.faculty_id = $2 OR other.faculty_id = $2)`+cond+`
+	ORDER BY cs.date, cs.start_time;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get class swaps: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.ClassSwap
+	for rows.Next() {
+		var s domain.ClassSwap
+		if err := rows.Scan(&s.ID, &s.Department, &s.Date,
+			&s.SubjectCode, &s.SubjectName, &s.Start, &s.End,
+			&s.OtherSubjectCode, &s.OtherSubjectName, &s.OtherStart, &s.OtherEnd,
+			&s.Reason, &s.AssignedBy, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan class swap: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
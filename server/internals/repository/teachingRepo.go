@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+func (p *SQLRepo) LogTeachingSession(facultyID int64, s domain.TeachingSession) (int64, error) {
+	var subjectID, ownerID int64
+	err := p.db.QueryRow(`SELECT subject_id, faculty_id FROM subjects WHERE subject_code = $1`, s.SubjectCode).Scan(&subjectID, &ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.NotFound("subject not found for code: %s", s.SubjectCode)
+		}
+		return 0, fmt.Errorf("lookup subject: %w", err)
+	}
+	if ownerID != facultyID {
+		covering, err := substitutes(p.db, subjectID, facultyID, s.Date.Format("2006-01-02"))
+		if err != nil {
+			return 0, err
+		}
+		if !covering {
+			return 0, domain.Forbidden("not authorized to log sessions of this subject")
+		}
+	}
+
+	var id int64
+	err = p.db.QueryRow(`
+	INSERT INTO teaching_sessions (subject_id, faculty_id, date, start_time, duration_minutes, topic, notes)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING session_id;`,
+		subjectID, facultyID, s.Date.Format("2006-01-02"), s.StartTime, s.DurationMinutes, s.Topic, s.Notes).Scan(&id)
+	if err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("a session of %s is already logged on %s at %s", s.SubjectCode, s.Date.Format("2006-01-02"), s.StartTime)
+		}
+		return 0, fmt.Errorf("insert teaching session: %w", err)
+	}
+	return id, nil
+}
+
+// sessionOwner checks that facultyID logged the session.
+func (p *SQLRepo) sessionOwner(facultyID, sessionID int64) error {
+	var loggedBy int64
+	err := p.db.QueryRow(`SELECT faculty_id FROM teaching_sessions WHERE session_id = $1`, sessionID).Scan(&loggedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NotFound("teaching session not found: %d", sessionID)
+		}
+		return fmt.Errorf("lookup teaching session: %w", err)
+	}
+	if loggedBy != facultyID {
+		return domain.Forbidden("not authorized to change this session")
+	}
+	return nil
+}
+
+func (p *SQLRepo) UpdateTeachingSession(facultyID int64, s domain.TeachingSession) error {
+	if err := p.sessionOwner(facultyID, s.ID); err != nil {
+		return err
+	}
+	_, err := p.db.Exec(`
+	UPDATE teaching_sessions
+	SET start_time = $2, duration_minutes = $3, topic = $4, notes = $5, updated_at = `+p.dialect.Now+`
+	WHERE session_id = $1;`, s.ID, s.StartTime, s.DurationMinutes, s.Topic, s.Notes)
+	if err != nil {
+		if p.isUniqueViolation(err) {
+			return domain.Conflict("another session of this subject is already logged at %s that day", s.StartTime)
+		}
+		return fmt.Errorf("update teaching session: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLRepo) DeleteTeachingSession(facultyID, sessionID int64) error {
+	if err := p.sessionOwner(facultyID, sessionID); err != nil {
+		return err
+	}
+	if _, err := p.db.Exec(`DELETE FROM teaching_sessions WHERE session_id = $1`, sessionID); err != nil {
+		return fmt.Errorf("delete teaching session: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLRepo) GetTeachingLog(query domain.TeachingLogQuery) ([]domain.TeachingSession, error) {
+	cond, args := dateRange("ts.date", query.Filter, []any{query.FacultyID, query.SubjectCode})
+	rows, err := p.db.Query(`
+	SELECT ts.session_id, sub.subject_code, sub.subject_name, ts.faculty_id, f.faculty_name,
+	       ts.date, ts.start_time, ts.duration_minutes, ts.topic, ts.notes,
+	       COALESCE(SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END), 0),
+	       COALESCE(SUM(CASE WHEN a.status = 'Absent' THEN 1 ELSE 0 END), 0),
+	       ts.created_at
+	FROM teaching_sessions ts
+	JOIN subjects sub ON sub.subject_id = ts.subject_id
+	JOIN faculty f ON f.faculty_id = ts.faculty_id
+	LEFT JOIN attendance a ON a.subject_id = ts.subject_id AND a.date = ts.date
+	WHERE (ts.faculty_id = $1 OR $1 = 0)
+	  AND (sub.subject_code = $2 OR $2 = '')`+cond+`
+	GROUP BY ts.session_id, sub.subject_code, sub.subject_name, f.faculty_name
+	ORDER BY ts.date, ts.start_time, sub.subject_code;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get teaching log: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.TeachingSession
+	for rows.Next() {
+		var s domain.TeachingSession
+		if err := rows.Scan(&s.ID, &s.SubjectCode, &s.SubjectName, &s.FacultyID, &s.FacultyName,
+			&s.Date, &s.StartTime, &s.DurationMinutes, &s.Topic, &s.Notes,
+			&s.Present, &s.Absent, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan teaching session: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
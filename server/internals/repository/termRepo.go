@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+func (p *SQLRepo) CreateTerm(name string, start, end time.Time) (int64, error) {
+	var id int64
+	q := `INSERT INTO terms (name, start_date, end_date) VALUES ($1, $2, $3) RETURNING term_id;`
+	if err := p.db.QueryRow(q, name, start.Format("2006-01-02"), end.Format("2006-01-02")).Scan(&id); err != nil {
+		if p.isUniqueViolation(err) {
+			return 0, domain.Conflict("term %s already exists", name).Wrap(err)
+		}
+		return 0, fmt.Errorf("insert term: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) GetTerms() ([]domain.Term, error) {
+	rows, err := p.db.Query(`SELECT term_id, name, start_date, end_date FROM terms ORDER BY start_date;`)
+	if err != nil {
+		return nil, fmt.Errorf("get terms: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Term
+	for rows.Next() {
+		var t domain.Term
+		if err := rows.Scan(&t.ID, &t.Name, &t.StartDate, &t.EndDate); err != nil {
+			return nil, fmt.Errorf("scan term: %w", err)
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) GetTermByName(name string) (domain.Term, error) {
+	var t domain.Term
+	q := `SELECT term_id, name, start_date, end_date FROM terms WHERE name = $1;`
+	if err := p.db.QueryRow(q, name).Scan(&t.ID, &t.Name, &t.StartDate, &t.EndDate); err != nil {
+		if err == sql.ErrNoRows {
+			return t, domain.NotFound("term not found: %s", name)
+		}
+		return t, fmt.Errorf("query term: %w", err)
+	}
+	return t, nil
+}
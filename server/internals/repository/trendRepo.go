@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// GetStudentDailyAttendance lists a student's classes day by day. Once a
+// subject has logged sessions they are its classes: each one appears with
+// the student's status that day, Absent when they were never marked, and
+// rows off a logged date are left out. Other subjects list their rows.
+func (p *SQLRepo) GetStudentDailyAttendance(usn string, filter domain.AttendanceFilter) ([]domain.DailyAttendance, error) {
+	logged, args := loggedSessions(filter, []any{usn})
+	rowCond, args := dateRange("a.date", filter, args)
+	sessionCond, args := dateRange("ts.date", filter, args)
+	rows, err := p.db.Query(`
+	WITH`+logged+`
+	SELECT a.date AS date, sub.subject_code AS subject_code, sub.subject_name, a.status
+	FROM attendance a
+	JOIN students st ON st.usn = a.usn
+	JOIN student_subjects ss ON ss.student_id = st.student_id AND ss.subject_id = a.subject_id
+	JOIN subjects sub ON sub.subject_id = a.subject_id
+	LEFT JOIN logged lc ON lc.subject_id = a.subject_id
+	WHERE a.usn = $1 AND lc.subject_id IS NULL`+rowCond+`
+	UNION ALL
+	SELECT ts.date, sub.subject_code, sub.subject_name, COALESCE(a.status, 'Absent')
+	FROM students st
+	JOIN student_subjects ss ON ss.student_id = st.student_id
+	JOIN subjects sub ON sub.subject_id = ss.subject_id
+	JOIN teaching_sessions ts ON ts.subject_id = ss.subject_id`+sessionCond+`
+	LEFT JOIN attendance a ON a.usn = st.usn AND a.subject_id = ts.subject_id AND a.date = ts.date
+	WHERE st.usn = $1
+	ORDER BY date, subject_code;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("student daily attendance: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.DailyAttendance
+	for rows.Next() {
+		var d domain.DailyAttendance
+		if err := rows.Scan(&d.Date, &d.SubjectCode, &d.SubjectName, &d.Status); err != nil {
+			return nil, fmt.Errorf("scan daily attendance: %w", err)
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+// GetStudentProgress counts held classes the way GetDefaulters does: the
+// logged sessions, or else the distinct dates any student was marked in the
+// subject.
+func (p *SQLRepo) GetStudentProgress(usn string, filter domain.AttendanceFilter) ([]domain.SubjectProjection, error) {
+	heldCond, args := dateRange("date", filter, []any{usn})
+	rowCond, args := dateRange("a.date", filter, args)
+	logged, args := loggedSessions(filter, args)
+	rows, err := p.db.Query(`
+	WITH held AS (
+	    SELECT subject_id, COUNT(DISTINCT date) AS classes_held
+	    FROM attendance
+	    WHERE subject_id IS NOT NULL`+heldCond+`
+	    GROUP BY subject_id
+	),`+logged+`
+	SELECT sub.subject_code, sub.subject_name,
+	       COALESCE(`+attendedExpr+`, 0),
+	       COALESCE(MAX(lc.classes), COUNT(a.attendance_id)),
+	       COALESCE(MAX(lc.classes), h.classes_held, 0),
+	       sub.planned_classes
+	FROM students st
+	JOIN student_subjects ss ON ss.student_id = st.student_id
+	JOIN subjects sub ON sub.subject_id = ss.subject_id
+	LEFT JOIN attendance a ON a.usn = st.usn AND a.subject_id = sub.subject_id`+rowCond+`
+	LEFT JOIN held h ON h.subject_id = sub.subject_id
+	LEFT JOIN logged lc ON lc.subject_id = sub.subject_id
+	LEFT JOIN teaching_sessions ts ON ts.subject_id = a.subject_id AND ts.date = a.date
+	WHERE st.usn = $1
+	GROUP BY sub.subject_code, sub.subject_name, h.classes_held, sub.planned_classes
+	ORDER BY sub.subject_code;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("student progress: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.SubjectProjection
+	for rows.Next() {
+		var s domain.SubjectProjection
+		if err := rows.Scan(&s.SubjectCode, &s.SubjectName, &s.Attended, &s.Total, &s.ClassesHeld, &s.PlannedClasses); err != nil {
+			return nil, fmt.Errorf("scan student progress: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
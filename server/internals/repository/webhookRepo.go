@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// CreateWebhook stores the event types comma separated, e.g.
+// "attendance.recorded,attendance.corrected". EnqueueDeliveries matches
+// them, "*" included, as elements of that list.
+func (p *SQLRepo) CreateWebhook(req domain.WebhookPayload) (int64, error) {
+	var id int64
+	err := p.db.QueryRow(`
+	INSERT INTO webhook_subscriptions (url, event_types, secret)
+	VALUES ($1, $2, $3) RETURNING webhook_id;`,
+		req.URL, strings.Join(req.EventTypes, ","), req.Secret).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("insert webhook: %w", err)
+	}
+	return id, nil
+}
+
+func (p *SQLRepo) GetWebhooks() ([]domain.Webhook, error) {
+	rows, err := p.db.Query(`
+	SELECT webhook_id, url, event_types, active, created_at
+	FROM webhook_subscriptions
+	ORDER BY webhook_id;`)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Webhook
+	for rows.Next() {
+		var w domain.Webhook
+		var events string
+		if err := rows.Scan(&w.ID, &w.URL, &events, &w.Active, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan webhook: %w", err)
+		}
+		w.EventTypes = strings.Split(events, ",")
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) DeleteWebhook(webhookID int64) error {
+	res, err := p.db.Exec(`DELETE FROM webhook_subscriptions WHERE webhook_id = $1;`, webhookID)
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("webhook not found: %d", webhookID)
+	}
+	return nil
+}
+
+func (p *SQLRepo) EnqueueDeliveries(eventType string, payload []byte) (int, error) {
+	res, err := p.db.Exec(`
+	INSERT INTO webhook_deliveries (webhook_id, event_type, payload, status, next_attempt_at)
+	SELECT webhook_id, CAST($1 AS TEXT), CAST($2 AS TEXT), 'pending', `+p.dialect.Now+`
+	FROM webhook_subscriptions
+	WHERE active
+	  AND (',' || event_types || ',' LIKE '%,*,%' OR ',' || event_types || ',' LIKE '%,' || CAST($1 AS TEXT) || ',%');`,
+		eventType, string(payload))
+	if err != nil {
+		return 0, fmt.Errorf("enqueue deliveries: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+// ClaimDueDeliveries leases due rows with SKIP LOCKED so several server
+// replicas can run the worker side by side.
+func (p *PostgresRepo) ClaimDueDeliveries(limit int, lease time.Duration) ([]domain.PendingDelivery, error) {
+	rows, err := p.db.Query(`
+	WITH due AS (
+	    SELECT delivery_id FROM webhook_deliveries
+	    WHERE status = 'pending' AND next_attempt_at <= NOW()
+	    ORDER BY next_attempt_at
+	    LIMIT $1
+	    FOR UPDATE SKIP LOCKED
+	)
+	UPDATE webhook_deliveries d
+	SET next_attempt_at = NOW() + make_interval(secs => $2)
+	FROM due, webhook_subscriptions w
+	WHERE d.delivery_id = due.delivery_id AND w.webhook_id = d.webhook_id
+	RETURNING d.delivery_id, d.webhook_id, d.event_type, d.payload, d.attempts, d.created_at, w.url, w.secret;`,
+		limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.PendingDelivery
+	for rows.Next() {
+		var d domain.PendingDelivery
+		var payload string
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventType, &payload, &d.Attempts, &d.CreatedAt, &d.URL, &d.Secret); err != nil {
+			return nil, fmt.Errorf("scan delivery: %w", err)
+		}
+		d.Payload = []byte(payload)
+		d.Status = domain.DeliveryPending
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func (p *SQLRepo) MarkDeliverySucceeded(deliveryID int64, statusCode int) error {
+	_, err := p.db.Exec(`
+	UPDATE webhook_deliveries
+	SET status = 'delivered', attempts = attempts + 1, last_status_code = $2,
+	    last_error = NULL, next_attempt_at = NULL, delivered_at = `+p.dialect.Now+`
+	WHERE delivery_id = $1;`, deliveryID, statusCode)
+	if err != nil {
+		return fmt.Errorf("mark delivery succeeded: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLRepo) MarkDeliveryFailed(deliveryID int64, statusCode int, errMsg string, nextAttempt *time.Time) error {
+	status := domain.DeliveryPending
+	var next any
+	if nextAttempt == nil {
+		status = domain.DeliveryDead
+	} else {
+		next = p.dialect.Stamp(*nextAttempt)
+	}
+	_, err := p.db.Exec(`
+	UPDATE webhook_deliveries
+	SET status = $2, attempts = attempts + 1, last_status_code = $3,
+	    last_error = $4, next_attempt_at = $5
+	WHERE delivery_id = $1;`,
+		deliveryID, status, sql.NullInt64{Int64: int64(statusCode), Valid: statusCode != 0}, errMsg, next)
+	if err != nil {
+		return fmt.Errorf("mark delivery failed: %w", err)
+	}
+	return nil
+}
+
+const deliveryColumns = `delivery_id, webhook_id, event_type, payload, status, attempts,
+	       next_attempt_at, last_status_code, COALESCE(last_error, ''), created_at, delivered_at`
+
+func scanDelivery(row interface{ Scan(...any) error }) (domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var payload string
+	var next, delivered sql.NullTime
+	var code sql.NullInt64
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventType, &payload, &d.Status, &d.Attempts,
+		&next, &code, &d.LastError, &d.CreatedAt, &delivered)
+	if err != nil {
+		return d, err
+	}
+	d.Payload = []byte(payload)
+	if next.Valid {
+		d.NextAttemptAt = &next.Time
+	}
+	if delivered.Valid {
+		d.DeliveredAt = &delivered.Time
+	}
+	if code.Valid {
+		c := int(code.Int64)
+		d.LastStatusCode = &c
+	}
+	return d, nil
+}
+
+func (p *SQLRepo) GetDeliveries(filter domain.DeliveryFilter, page domain.PageRequest) ([]domain.WebhookDelivery, int, error) {
+	q := `SELECT ` + deliveryColumns + `
+	FROM webhook_deliveries
+	WHERE (CAST($1 AS BIGINT) = 0 OR webhook_id = CAST($1 AS BIGINT)) AND (CAST($2 AS TEXT) = '' OR status = CAST($2 AS TEXT))`
+
+	keys := sortKeys{"created_at": "created_at", "attempts": "attempts", "status": "status"}
+	rows, total, err := p.paginate(q, []any{filter.WebhookID, filter.Status}, page, keys, "created_at", "delivery_id")
+	if err != nil {
+		return nil, 0, fmt.Errorf("get deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan delivery: %w", err)
+		}
+		list = append(list, d)
+	}
+	return list, total, rows.Err()
+}
+
+func (p *SQLRepo) GetDelivery(deliveryID int64) (domain.WebhookDelivery, error) {
+	row := p.db.QueryRow(`SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE delivery_id = $1;`, deliveryID)
+	d, err := scanDelivery(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return d, domain.NotFound("delivery not found: %d", deliveryID)
+		}
+		return d, fmt.Errorf("get delivery: %w", err)
+	}
+	return d, nil
+}
+
+func (p *SQLRepo) ReplayDelivery(deliveryID int64) error {
+	res, err := p.db.Exec(`
+	UPDATE webhook_deliveries
+	SET status = 'pending', attempts = 0, next_attempt_at = `+p.dialect.Now+`, delivered_at = NULL
+	WHERE delivery_id = $1;`, deliveryID)
+	if err != nil {
+		return fmt.Errorf("replay delivery: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.NotFound("delivery not found: %d", deliveryID)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package admin_service
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+type AdminService struct {
+	adminRepo domain.AdminRepo
+	validate  *validator.Validate
+}
+
+func NewAdminService(adminRepo domain.AdminRepo) *AdminService {
+	v := validation.New()
+	return &AdminService{
+		adminRepo: adminRepo,
+		validate:  v,
+	}
+}
+
+func (s *AdminService) RegisterAdmin(req domain.AdminRegisterPayload) (int64, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return 0, validation.Error(err)
+	}
+
+	id, err := s.adminRepo.CreateAdmin(req.Username, req.Email, req.Password)
+	if err != nil {
+		return 0, fmt.Errorf("error while creating admin: %w", err)
+	}
+	return id, nil
+}
+
+// EnsureAdmin creates the first admin account when none exists yet, so a
+// fresh deployment has someone who can log in and register the rest.
+func (s *AdminService) EnsureAdmin(req domain.AdminRegisterPayload) error {
+	count, err := s.adminRepo.CountAdmins()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	_, err = s.RegisterAdmin(req)
+	return err
+}
+
+func (s *AdminService) AuthenticateAdmin(req domain.AdminLoginPayload) (string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return "", validation.Error(err)
+	}
+
+	token, err := s.adminRepo.AuthenticateAdmin(req)
+	if err != nil {
+		return "", fmt.Errorf("authentication failed: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,76 @@
+package analytics_service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// maxTopAbsentees caps how many students one dashboard request may list.
+const maxTopAbsentees = 100
+
+type AnalyticsService struct {
+	analyticsRepo  domain.AnalyticsRepo
+	departmentRepo domain.DepartmentRepo
+	termRepo       domain.TermRepo
+}
+
+func NewAnalyticsService(analyticsRepo domain.AnalyticsRepo, departmentRepo domain.DepartmentRepo, termRepo domain.TermRepo) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo:  analyticsRepo,
+		departmentRepo: departmentRepo,
+		termRepo:       termRepo,
+	}
+}
+
+// AuthorizeHOD checks that the faculty is the head of the department.
+func (s *AnalyticsService) AuthorizeHOD(facultyID int64, code string) error {
+	return domain.AuthorizeHOD(s.departmentRepo, facultyID, code)
+}
+
+func (s *AnalyticsService) GetDepartmentAnalytics(query domain.AnalyticsQuery) (domain.DepartmentAnalytics, error) {
+	query.Department = domain.DepartmentCode(query.Department)
+	if query.TopAbsentees == 0 {
+		query.TopAbsentees = domain.DefaultTopAbsentees
+	}
+	if query.TopAbsentees < 0 || query.TopAbsentees > maxTopAbsentees {
+		return domain.DepartmentAnalytics{}, domain.Invalid("limit", "range", "limit must be between 1 and %d", maxTopAbsentees)
+	}
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, query.Filter)
+	if err != nil {
+		return domain.DepartmentAnalytics{}, err
+	}
+	query.Filter = filter
+
+	if _, err := s.departmentRepo.GetDepartment(query.Department); err != nil {
+		return domain.DepartmentAnalytics{}, err
+	}
+
+	a, err := s.analyticsRepo.GetDepartmentAnalytics(query)
+	if err != nil {
+		return domain.DepartmentAnalytics{}, fmt.Errorf("error fetching department analytics: %w", err)
+	}
+
+	for i := range a.Semesters {
+		a.Semesters[i].Percentage = percentage(a.Semesters[i].Present, a.Semesters[i].Total)
+	}
+	for i := range a.Subjects {
+		a.Subjects[i].Percentage = percentage(a.Subjects[i].Present, a.Subjects[i].Total)
+	}
+	for i := range a.Weekly {
+		a.Weekly[i].Percentage = percentage(a.Weekly[i].Present, a.Weekly[i].Total)
+	}
+	for i := range a.TopAbsentees {
+		st := &a.TopAbsentees[i]
+		st.Percentage = percentage(st.Total-st.Absent, st.Total)
+	}
+	return a, nil
+}
+
+func percentage(present, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(10000*float64(present)/float64(total)) / 100
+}
@@ -0,0 +1,96 @@
+package analytics_service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	analytics_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/analytics"
+)
+
+var monday = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+// seed holds CS501 on Monday, Thursday and the next Monday, where Alice is
+// absent; Tuesday's capture is never assigned. Ravi heads CSE.
+func seed(t *testing.T) (*memory.MemoryRepo, int64) {
+	t.Helper()
+	b := memorytest.New(t)
+	ravi := b.Faculty("Ravi", "CSE")
+	b.HOD("CSE", ravi)
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi})
+	b.Students(
+		domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"},
+		domain.StudentRegisterPayload{USN: "1RV21CS002", Username: "Bob"},
+	)
+	for _, offset := range []int{0, 3, 7} {
+		alice := "Present"
+		if offset == 7 {
+			alice = "Absent"
+		}
+		b.Class("CS501", monday.AddDate(0, 0, offset), map[string]string{"1RV21CS001": alice, "1RV21CS002": "Present"})
+	}
+	b.Capture(monday.AddDate(0, 0, 8), map[string]string{"1RV21CS001": "Present", "1RV21CS002": "Present"})
+	return b.Repo, ravi
+}
+
+func TestDepartmentAnalytics(t *testing.T) {
+	repo, _ := seed(t)
+	svc := analytics_service.NewAnalyticsService(repo, repo, repo)
+
+	a, err := svc.GetDepartmentAnalytics(domain.AnalyticsQuery{Department: "cse"})
+	if err != nil {
+		t.Fatalf("GetDepartmentAnalytics: %v", err)
+	}
+	if len(a.Semesters) != 1 || a.Semesters[0].Students != 2 || a.Semesters[0].Percentage != 83.33 {
+		t.Errorf("semesters = %+v", a.Semesters)
+	}
+	if len(a.Subjects) != 1 || a.Subjects[0].ClassesHeld != 3 || a.Subjects[0].FacultyName != "Ravi" {
+		t.Errorf("subjects = %+v", a.Subjects)
+	}
+	if len(a.Weekly) != 2 || !a.Weekly[1].WeekStart.Equal(monday.AddDate(0, 0, 7)) || a.Weekly[1].Percentage != 50 {
+		t.Errorf("weekly = %+v", a.Weekly)
+	}
+	if len(a.Faculty) != 1 || a.Faculty[0].Sessions != 3 {
+		t.Errorf("faculty = %+v", a.Faculty)
+	}
+	if len(a.TopAbsentees) != 1 || a.TopAbsentees[0].USN != "1RV21CS001" || a.TopAbsentees[0].Percentage != 66.67 {
+		t.Errorf("top absentees = %+v", a.TopAbsentees)
+	}
+	if len(a.Unassigned) != 1 || a.Unassigned[0].Rows != 2 {
+		t.Errorf("unassigned = %+v", a.Unassigned)
+	}
+
+	// The first week alone has no absentees.
+	a, err = svc.GetDepartmentAnalytics(domain.AnalyticsQuery{Department: "CSE", Filter: domain.AttendanceFilter{To: monday.AddDate(0, 0, 6)}})
+	if err != nil {
+		t.Fatalf("GetDepartmentAnalytics first week: %v", err)
+	}
+	if len(a.TopAbsentees) != 0 || a.TopAbsentees == nil || a.Subjects[0].ClassesHeld != 2 {
+		t.Errorf("first week = %+v", a)
+	}
+
+	if _, err := svc.GetDepartmentAnalytics(domain.AnalyticsQuery{Department: "PHY"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("unknown department: %v, want not found", err)
+	}
+	if _, err := svc.GetDepartmentAnalytics(domain.AnalyticsQuery{Department: "CSE", TopAbsentees: 500}); err == nil {
+		t.Error("limit 500 was accepted")
+	}
+}
+
+func TestAuthorizeHOD(t *testing.T) {
+	repo, hod := seed(t)
+	svc := analytics_service.NewAnalyticsService(repo, repo, repo)
+
+	if err := svc.AuthorizeHOD(hod, "cse"); err != nil {
+		t.Errorf("HOD of CSE: %v", err)
+	}
+	if err := svc.AuthorizeHOD(hod, "ISE"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("HOD of another department: %v, want forbidden", err)
+	}
+	if err := svc.AuthorizeHOD(hod+1, "CSE"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("other faculty: %v, want forbidden", err)
+	}
+}
@@ -0,0 +1,364 @@
+package anomaly_service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/blob"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+// Config holds the screening rules; a zero window, size or confidence turns
+// its rule off.
+type Config struct {
+	// TravelWindow is how soon after a capture in one room a capture in
+	// another is impossible travel.
+	TravelWindow time.Duration
+	// BurstSize captures of a student within BurstWindow are a duplicate
+	// burst.
+	BurstWindow time.Duration
+	BurstSize   int
+	// MinConfidence is the recognizer score below which a match is doubted.
+	MinConfidence float64
+	// SessionGrace widens each logged session on both sides for students
+	// who arrive early or leave late.
+	SessionGrace time.Duration
+	// Calendar reads session times in the institution timezone.
+	Calendar *calendar.Calendar
+	// Evidence keeps the face crops sent with captures; without it captures
+	// carrying a crop are refused. MaxFaceCropBytes caps a decoded crop.
+	Evidence         blob.Store
+	MaxFaceCropBytes int
+}
+
+// faceCropTypes are the image formats accepted as face crops.
+var faceCropTypes = map[string]bool{"image/jpeg": true, "image/png": true}
+
+type AnomalyService struct {
+	anomalyRepo    domain.AnomalyRepo
+	attendanceRepo domain.AttendanceRepository
+	facultyRepo    domain.FacultyRepo
+	termRepo       domain.TermRepo
+	publisher      domain.EventPublisher
+	cfg            Config
+	validate       *validator.Validate
+}
+
+// NewAnomalyService builds the service. It implements
+// domain.CaptureScreener so it can be handed to the attendance service.
+func NewAnomalyService(anomalyRepo domain.AnomalyRepo, attendanceRepo domain.AttendanceRepository, facultyRepo domain.FacultyRepo, termRepo domain.TermRepo, publisher domain.EventPublisher, cfg Config) *AnomalyService {
+	if cfg.Calendar == nil {
+		cfg.Calendar = calendar.New(nil)
+	}
+	v := validation.New()
+	return &AnomalyService{
+		anomalyRepo:    anomalyRepo,
+		attendanceRepo: attendanceRepo,
+		facultyRepo:    facultyRepo,
+		termRepo:       termRepo,
+		publisher:      publisher,
+		cfg:            cfg,
+		validate:       v,
+	}
+}
+
+// Check runs the rules against a capture and decodes its face crop; nothing
+// is stored until Record.
+func (s *AnomalyService) Check(a domain.AttendancePayload, pending []domain.AttendancePayload) (domain.Screening, error) {
+	screening := domain.Screening{Capture: a}
+	if a.FaceCrop != "" {
+		crop, err := s.decodeFaceCrop(a.FaceCrop)
+		if err != nil {
+			return domain.Screening{}, err
+		}
+		screening.FaceCrop = crop
+	}
+
+	reasons, details, err := s.detect(a, pending)
+	if err != nil {
+		return domain.Screening{}, err
+	}
+	screening.Reasons, screening.Detail = reasons, strings.Join(details, "; ")
+	return screening, nil
+}
+
+// Record stores a checked capture as a recognition event, with its face
+// crop in the evidence store, and flags it when it was held.
+func (s *AnomalyService) Record(screening domain.Screening) (int64, error) {
+	a := screening.Capture
+	event := domain.RecognitionEvent{
+		USN:         a.USN,
+		Status:      a.Status,
+		Room:        a.Room,
+		Confidence:  a.Confidence,
+		RecordedAt:  a.RecordedAt,
+		DeviceID:    a.DeviceID,
+		BoundingBox: a.BoundingBox,
+	}
+	if screening.FaceCrop != nil {
+		key, err := s.cfg.Evidence.Put(screening.FaceCrop)
+		if err != nil {
+			return 0, fmt.Errorf("error storing face crop: %w", err)
+		}
+		event.FaceCropKey = key
+	}
+
+	eventID, err := s.anomalyRepo.RecordRecognitionEvent(event)
+	if err != nil {
+		return 0, err
+	}
+	if !screening.Held() {
+		return 0, nil
+	}
+
+	flagID, err := s.anomalyRepo.CreateAnomalyFlag(eventID, screening.Reasons, screening.Detail)
+	if err != nil {
+		return 0, fmt.Errorf("error flagging capture: %w", err)
+	}
+	return flagID, nil
+}
+
+// decodeFaceCrop decodes and checks a base64 face crop.
+func (s *AnomalyService) decodeFaceCrop(encoded string) ([]byte, error) {
+	if s.cfg.Evidence == nil {
+		return nil, domain.Invalid("face_crop", "unsupported", "face crops are not accepted by this server")
+	}
+	// Refuse an oversized crop before allocating its decoded copy; no
+	// encoding of MaxFaceCropBytes bytes is longer than EncodedLen of them.
+	tooLarge := domain.Invalid("face_crop", "max", "face_crop must be at most %d bytes", s.cfg.MaxFaceCropBytes)
+	if s.cfg.MaxFaceCropBytes > 0 && len(encoded) > base64.StdEncoding.EncodedLen(s.cfg.MaxFaceCropBytes) {
+		return nil, tooLarge
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, domain.Invalid("face_crop", "base64", "face_crop must be base64 encoded").Wrap(err)
+	}
+	if s.cfg.MaxFaceCropBytes > 0 && len(data) > s.cfg.MaxFaceCropBytes {
+		return nil, tooLarge
+	}
+	if !faceCropTypes[http.DetectContentType(data)] {
+		return nil, domain.Invalid("face_crop", "image", "face_crop must be a JPEG or PNG image")
+	}
+	return data, nil
+}
+
+// detect checks a against the student's earlier captures, including those
+// pending in the same batch, and the sessions logged for their semester that
+// day.
+func (s *AnomalyService) detect(a domain.AttendancePayload, pending []domain.AttendancePayload) ([]string, []string, error) {
+	var reasons, details []string
+	flag := func(kind, format string, args ...any) {
+		reasons = append(reasons, kind)
+		details = append(details, fmt.Sprintf(format, args...))
+	}
+
+	if s.cfg.MinConfidence > 0 && a.Confidence != nil && *a.Confidence < s.cfg.MinConfidence {
+		flag(domain.AnomalyLowConfidence, "confidence %.2f is below %.2f", *a.Confidence, s.cfg.MinConfidence)
+	}
+
+	lookback := max(s.cfg.TravelWindow, s.cfg.BurstWindow)
+	if lookback > 0 {
+		from := a.RecordedAt.Add(-lookback)
+		earlier, err := s.anomalyRepo.GetRecognitionEvents(a.USN, from, a.RecordedAt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error fetching recognition events: %w", err)
+		}
+		for _, p := range pending {
+			if p.USN == a.USN && !p.RecordedAt.Before(from) && !p.RecordedAt.After(a.RecordedAt) {
+				earlier = append(earlier, domain.RecognitionEvent{USN: p.USN, Room: p.Room, RecordedAt: p.RecordedAt})
+			}
+		}
+		sort.SliceStable(earlier, func(i, j int) bool { return earlier[i].RecordedAt.Before(earlier[j].RecordedAt) })
+
+		// Latest first, so the nearest sighting in another room is reported.
+		for i := len(earlier) - 1; i >= 0; i-- {
+			e := earlier[i]
+			if s.cfg.TravelWindow > 0 && a.Room != "" && e.Room != "" && e.Room != a.Room &&
+				a.RecordedAt.Sub(e.RecordedAt) < s.cfg.TravelWindow {
+				flag(domain.AnomalyImpossibleTravel, "seen in room %s at %s", e.Room,
+					e.RecordedAt.In(s.cfg.Calendar.Location()).Format("15:04:05"))
+				break
+			}
+		}
+
+		if s.cfg.BurstSize > 0 && s.cfg.BurstWindow > 0 {
+			n := 1
+			for _, e := range earlier {
+				if a.RecordedAt.Sub(e.RecordedAt) < s.cfg.BurstWindow {
+					n++
+				}
+			}
+			if n >= s.cfg.BurstSize {
+				flag(domain.AnomalyDuplicateBurst, "%d captures within %s", n, s.cfg.BurstWindow)
+			}
+		}
+	}
+
+	sessions, err := s.anomalyRepo.GetStudentSessions(a.USN, s.cfg.Calendar.DateOf(a.RecordedAt))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching teaching sessions: %w", err)
+	}
+	if len(sessions) > 0 && !s.inSession(a.RecordedAt, sessions) {
+		flag(domain.AnomalyOutsideSession, "outside the %d sessions logged that day", len(sessions))
+	}
+	return reasons, details, nil
+}
+
+// inSession reports whether t falls in any of the sessions, widened by the
+// grace period.
+func (s *AnomalyService) inSession(t time.Time, sessions []domain.TeachingSession) bool {
+	for _, session := range sessions {
+		from, to, err := s.cfg.Calendar.Session(session.Date, session.StartTime, session.DurationMinutes)
+		if err != nil {
+			continue
+		}
+		if !t.Before(from.Add(-s.cfg.SessionGrace)) && !t.After(to.Add(s.cfg.SessionGrace)) {
+			return true
+		}
+	}
+	return false
+}
+
+// department is the department whose flags the faculty may review.
+func (s *AnomalyService) department(facultyID int64) (string, error) {
+	f, err := s.facultyRepo.GetFacultyByID(facultyID)
+	if err != nil {
+		return "", err
+	}
+	return f.Department, nil
+}
+
+// GetFlags lists the flags raised on students of the faculty's department.
+func (s *AnomalyService) GetFlags(facultyID int64, query domain.FlagQuery) ([]domain.AnomalyFlag, error) {
+	if err := s.validate.Var(query.Review, "omitempty,oneof=open accepted rejected"); err != nil {
+		return nil, validation.Var("review", err)
+	}
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	query.Filter = filter
+
+	query.Department, err = s.department(facultyID)
+	if err != nil {
+		return nil, err
+	}
+
+	flags, err := s.anomalyRepo.GetAnomalyFlags(query)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching flags: %w", err)
+	}
+	if flags == nil {
+		flags = []domain.AnomalyFlag{}
+	}
+	for i := range flags {
+		withFaceCropURL(&flags[i])
+	}
+	return flags, nil
+}
+
+// withFaceCropURL points reviewers at the flag's face crop, if it has one.
+func withFaceCropURL(flag *domain.AnomalyFlag) {
+	if flag.Event.FaceCropKey != "" {
+		flag.Event.FaceCropURL = fmt.Sprintf("/faculty/flags/%d/face-crop", flag.ID)
+	}
+}
+
+// reviewable fetches a flag the faculty may review: one raised on a student
+// of their department.
+func (s *AnomalyService) reviewable(facultyID, flagID int64) (domain.AnomalyFlag, error) {
+	flag, err := s.anomalyRepo.GetAnomalyFlag(flagID)
+	if err != nil {
+		return domain.AnomalyFlag{}, err
+	}
+	department, err := s.department(facultyID)
+	if err != nil {
+		return domain.AnomalyFlag{}, err
+	}
+	if flag.Department != department {
+		return domain.AnomalyFlag{}, domain.Forbidden("not authorized to review this flag")
+	}
+	withFaceCropURL(&flag)
+	return flag, nil
+}
+
+// GetFaceCrop returns the face crop sent with a flagged capture.
+func (s *AnomalyService) GetFaceCrop(facultyID, flagID int64) ([]byte, error) {
+	flag, err := s.reviewable(facultyID, flagID)
+	if err != nil {
+		return nil, err
+	}
+	if flag.Event.FaceCropKey == "" || s.cfg.Evidence == nil {
+		return nil, domain.NotFound("flag %d has no face crop", flagID)
+	}
+
+	data, err := s.cfg.Evidence.Get(flag.Event.FaceCropKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, domain.NotFound("face crop of flag %d is missing from the evidence store", flagID).Wrap(err)
+		}
+		return nil, fmt.Errorf("error reading face crop: %w", err)
+	}
+	return data, nil
+}
+
+// ReviewFlag closes an open flag. Accepting records the held capture as
+// attendance, exactly as it would have been without the flag; rejecting
+// drops it.
+func (s *AnomalyService) ReviewFlag(facultyID, flagID int64, req domain.FlagReviewPayload) (domain.AnomalyFlag, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return domain.AnomalyFlag{}, validation.Error(err)
+	}
+
+	flag, err := s.reviewable(facultyID, flagID)
+	if err != nil {
+		return domain.AnomalyFlag{}, err
+	}
+	if flag.Review != domain.FlagOpen {
+		return domain.AnomalyFlag{}, domain.Conflict("flag %d has already been reviewed", flagID)
+	}
+
+	if req.Decision == "reject" {
+		if err := s.anomalyRepo.ResolveAnomalyFlag(flagID, facultyID, domain.FlagRejected, nil); err != nil {
+			return domain.AnomalyFlag{}, err
+		}
+		return s.reviewable(facultyID, flagID)
+	}
+
+	// Marking is an upsert, so losing a race with another reviewer below
+	// leaves the same row behind.
+	capture := domain.AttendancePayload{
+		USN:         flag.Event.USN,
+		Status:      flag.Event.Status,
+		RecordedAt:  flag.Event.RecordedAt,
+		Room:        flag.Event.Room,
+		Confidence:  flag.Event.Confidence,
+		DeviceID:    flag.Event.DeviceID,
+		BoundingBox: flag.Event.BoundingBox,
+	}
+	attendanceID, err := s.attendanceRepo.MarkAttendance(&capture)
+	if err != nil {
+		return domain.AnomalyFlag{}, fmt.Errorf("error marking attendance: %w", err)
+	}
+	if err := s.anomalyRepo.ResolveAnomalyFlag(flagID, facultyID, domain.FlagAccepted, &attendanceID); err != nil {
+		return domain.AnomalyFlag{}, err
+	}
+
+	s.publisher.Publish(domain.AttendanceEvent{
+		Type:         domain.EventAttendanceRecorded,
+		AttendanceID: attendanceID,
+		USN:          capture.USN,
+		Status:       capture.Status,
+		RecordedAt:   capture.RecordedAt,
+		OccurredAt:   time.Now(),
+	})
+	return s.reviewable(facultyID, flagID)
+}
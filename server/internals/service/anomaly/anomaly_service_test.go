@@ -0,0 +1,232 @@
+package anomaly_service_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/blob"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	anomaly_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/anomaly"
+	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
+)
+
+var classDay = time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+
+func at(hour, minute, second int) time.Time {
+	return classDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second)
+}
+
+func TestAnomalousCapturesWaitForReview(t *testing.T) {
+	b := memorytest.New(t)
+	repo := b.Repo
+	screener := anomaly_service.NewAnomalyService(repo, repo, repo, repo, domain.Publishers{}, anomaly_service.Config{
+		TravelWindow:  10 * time.Minute,
+		BurstWindow:   time.Minute,
+		BurstSize:     3,
+		MinConfidence: 0.6,
+		SessionGrace:  15 * time.Minute,
+	})
+	svc := attendence_service.NewAttendanceService(repo, repo, repo, domain.Publishers{}, screener)
+
+	ravi, meera := b.Faculty("Ravi", "CSE"), b.Faculty("Meera", "ECE")
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi})
+	b.Students(
+		domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"},
+		domain.StudentRegisterPayload{USN: "1RV21CS002", Username: "Bob"},
+	)
+
+	confident, unsure := 0.95, 0.3
+	capture := func(usn, room string, confidence *float64, recordedAt time.Time) domain.CaptureResult {
+		t.Helper()
+		result, err := svc.MarkAttendance(&domain.AttendancePayload{USN: usn, Status: "Present", RecordedAt: recordedAt, Room: room, Confidence: confidence})
+		if err != nil {
+			t.Fatalf("MarkAttendance(%s at %s): %v", usn, recordedAt.Format("15:04:05"), err)
+		}
+		return result
+	}
+
+	if r := capture("1RV21CS001", "A101", &confident, at(9, 0, 0)); r.Held() || r.AttendanceID == 0 {
+		t.Fatalf("clean capture = %+v, want it recorded", r)
+	}
+	travel := capture("1RV21CS001", "B204", &confident, at(9, 3, 0))
+	if !travel.Held() || !slices.Equal(travel.Reasons, []string{domain.AnomalyImpossibleTravel}) {
+		t.Errorf("capture in another room 3 minutes later = %+v", travel)
+	}
+	if r := capture("1RV21CS002", "A101", &unsure, at(9, 0, 0)); !slices.Equal(r.Reasons, []string{domain.AnomalyLowConfidence}) {
+		t.Errorf("unsure capture = %+v", r)
+	}
+	capture("1RV21CS002", "A101", nil, at(9, 20, 0))
+	capture("1RV21CS002", "A101", nil, at(9, 20, 15))
+	if r := capture("1RV21CS002", "A101", nil, at(9, 20, 30)); !slices.Equal(r.Reasons, []string{domain.AnomalyDuplicateBurst}) {
+		t.Errorf("third capture within a minute = %+v", r)
+	}
+
+	// Once a session is logged, captures far outside it are held.
+	if _, err := repo.LogTeachingSession(ravi, domain.TeachingSession{
+		SubjectCode: "CS501", Date: classDay, StartTime: "09:00", DurationMinutes: 60, Topic: "Parsing",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if r := capture("1RV21CS002", "A101", nil, at(10, 10, 0)); r.Held() {
+		t.Errorf("capture within the grace period = %+v", r)
+	}
+	if r := capture("1RV21CS002", "A101", nil, at(14, 0, 0)); !slices.Equal(r.Reasons, []string{domain.AnomalyOutsideSession}) {
+		t.Errorf("afternoon capture = %+v", r)
+	}
+
+	open, err := screener.GetFlags(ravi, domain.FlagQuery{Review: domain.FlagOpen})
+	if err != nil {
+		t.Fatalf("GetFlags: %v", err)
+	}
+	if len(open) != 4 {
+		t.Fatalf("open flags = %d, want 4", len(open))
+	}
+	if others, err := screener.GetFlags(meera, domain.FlagQuery{}); err != nil || len(others) != 0 {
+		t.Errorf("flags of another department = %v, %v", others, err)
+	}
+
+	accept := domain.FlagReviewPayload{Decision: "accept"}
+	if _, err := screener.ReviewFlag(meera, travel.FlagID, accept); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("review from another department: %v, want forbidden", err)
+	}
+	flag, err := screener.ReviewFlag(ravi, travel.FlagID, accept)
+	if err != nil {
+		t.Fatalf("ReviewFlag: %v", err)
+	}
+	if flag.Review != domain.FlagAccepted || flag.AttendanceID == nil || flag.ReviewedBy == nil || *flag.ReviewedBy != ravi {
+		t.Errorf("accepted flag = %+v", flag)
+	}
+	if _, err := screener.ReviewFlag(ravi, travel.FlagID, domain.FlagReviewPayload{Decision: "reject"}); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second review: %v, want a conflict", err)
+	}
+
+	// Held captures never reached the attendance table; the accepted one did.
+	rows, err := repo.GetAttendanceBySubjectAndDate("CS501", classDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("assigned rows = %v, want none", rows)
+	}
+	assigned, _, err := repo.AssignSubjectToTimeRange(ravi, "CS501", classDay, at(8, 0, 0), at(18, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assigned != 2 {
+		t.Errorf("assigned %d captures, want Alice's accepted one and Bob's clean ones", assigned)
+	}
+}
+
+func TestFaceCropIsKeptAsEvidence(t *testing.T) {
+	b := memorytest.New(t)
+	repo := b.Repo
+	store, err := blob.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	screener := anomaly_service.NewAnomalyService(repo, repo, repo, repo, domain.Publishers{}, anomaly_service.Config{
+		MinConfidence:    0.6,
+		Evidence:         store,
+		MaxFaceCropBytes: 64,
+	})
+	svc := attendence_service.NewAttendanceService(repo, repo, repo, domain.Publishers{}, screener)
+
+	ravi := b.Faculty("Ravi", "CSE")
+	b.Students(domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"})
+
+	crop := append([]byte("\x89PNG\r\n\x1a\n"), "face"...)
+	unsure := 0.4
+	capture := domain.AttendancePayload{
+		USN: "1RV21CS001", Status: "Present", RecordedAt: at(9, 0, 0), Confidence: &unsure,
+		DeviceID: "cam-3", BoundingBox: &domain.BoundingBox{X: 10, Y: 20, Width: 64, Height: 80},
+		FaceCrop: base64.StdEncoding.EncodeToString(crop),
+	}
+	result, err := svc.MarkAttendance(&capture)
+	if err != nil {
+		t.Fatalf("MarkAttendance: %v", err)
+	}
+	if !result.Held() {
+		t.Fatalf("low-confidence capture = %+v, want it held", result)
+	}
+
+	flags, err := screener.GetFlags(ravi, domain.FlagQuery{})
+	if err != nil || len(flags) != 1 {
+		t.Fatalf("GetFlags = %v, %v", flags, err)
+	}
+	if e := flags[0].Event; e.DeviceID != "cam-3" || e.BoundingBox == nil || e.BoundingBox.Width != 64 || e.FaceCropURL == "" {
+		t.Errorf("flagged event = %+v", e)
+	}
+	got, err := screener.GetFaceCrop(ravi, result.FlagID)
+	if err != nil || !bytes.Equal(got, crop) {
+		t.Errorf("GetFaceCrop = %q, %v", got, err)
+	}
+
+	capture.FaceCrop = base64.StdEncoding.EncodeToString([]byte("not an image"))
+	if _, err := svc.MarkAttendance(&capture); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("crop that is not an image: %v, want a validation error", err)
+	}
+	capture.FaceCrop = base64.StdEncoding.EncodeToString(append(crop, make([]byte, 64)...))
+	if _, err := svc.MarkAttendance(&capture); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("oversized crop: %v, want a validation error", err)
+	}
+	// Far too long to encode a crop within the limit.
+	capture.FaceCrop = strings.Repeat("A", 1<<20)
+	var verr *domain.Error
+	if _, err := svc.MarkAttendance(&capture); !errors.As(err, &verr) || len(verr.Fields) != 1 || verr.Fields[0].Rule != "max" {
+		t.Errorf("1 MiB crop: %v, want the max rule", err)
+	}
+	// A crop of exactly the limit is fine.
+	capture.FaceCrop = base64.StdEncoding.EncodeToString(append(crop, make([]byte, 64-len(crop))...))
+	if _, err := svc.MarkAttendance(&capture); err != nil {
+		t.Errorf("crop at the limit: %v", err)
+	}
+}
+
+// A batch that fails leaves no recognition events behind, so retrying it
+// does not make its own captures look like a duplicate burst.
+func TestFailedBatchIsNotCountedOnRetry(t *testing.T) {
+	b := memorytest.New(t)
+	repo := b.Repo
+	screener := anomaly_service.NewAnomalyService(repo, repo, repo, repo, domain.Publishers{}, anomaly_service.Config{
+		BurstWindow: time.Minute,
+		BurstSize:   3,
+	})
+	svc := attendence_service.NewAttendanceService(repo, repo, repo, domain.Publishers{}, screener)
+	b.Students(domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"})
+
+	batch := []domain.AttendancePayload{
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: at(9, 0, 0)},
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: at(9, 0, 20)},
+		{USN: "1RV21CS999", Status: "Present", RecordedAt: at(9, 0, 0)},
+	}
+	if _, _, err := svc.BulkMarkAttendance(batch); err == nil {
+		t.Fatal("batch with an unknown usn accepted")
+	}
+	if events, err := repo.GetRecognitionEvents("1RV21CS001", at(8, 0, 0), at(10, 0, 0)); err != nil || len(events) != 0 {
+		t.Fatalf("failed batch left events %v, %v", events, err)
+	}
+
+	recorded, held, err := svc.BulkMarkAttendance(batch[:2])
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if recorded != 2 || held != 0 {
+		t.Errorf("retry recorded %d and held %d, want 2 and 0", recorded, held)
+	}
+
+	// Captures earlier in a batch still count toward a burst.
+	_, held, err = svc.BulkMarkAttendance([]domain.AttendancePayload{
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: at(11, 0, 0)},
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: at(11, 0, 10)},
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: at(11, 0, 20)},
+	})
+	if err != nil || held != 1 {
+		t.Errorf("burst within a batch held %d, %v; want 1", held, err)
+	}
+}
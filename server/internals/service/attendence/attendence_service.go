@@ -2,71 +2,202 @@ package attendence_service
 
 import (
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
 )
 
 type AttendanceService struct {
 	attendanceRepo domain.AttendanceRepository
+	termRepo       domain.TermRepo
+	substitutionRepo domain.SubstitutionRepo
+	publisher      domain.EventPublisher
+	screener       domain.CaptureScreener
 	validate   *validator.Validate
 }
 
-func NewAttendanceService(attendanceRepo domain.AttendanceRepository) *AttendanceService {
-	v := validator.New()
+// NewAttendanceService builds the service. A nil screener records every
+// capture as it arrives.
+func NewAttendanceService(attendanceRepo domain.AttendanceRepository, termRepo domain.TermRepo, substitutionRepo domain.SubstitutionRepo, publisher domain.EventPublisher, screener domain.CaptureScreener) *AttendanceService {
+	v := validation.New()
 	return &AttendanceService{
 		attendanceRepo: attendanceRepo,
+		termRepo:       termRepo,
+		substitutionRepo: substitutionRepo,
+		publisher:      publisher,
+		screener:       screener,
 		validate:       v,
 	}
 }
 
-func (s *AttendanceService) MarkAttendance(attendance *domain.AttendancePayload) (int64, error) {
-	if err := s.validate.Struct(attendance); err != nil {
-    fmt.Printf("%#v\n", attendance) 
+// resolveFilter replaces a named term with its dates and checks the range.
+func (s *AttendanceService) resolveFilter(filter domain.AttendanceFilter) (domain.AttendanceFilter, error) {
+	return domain.ResolveAttendanceFilter(s.termRepo, filter)
+}
+
+// check passes a capture through the screener's rules; pending are the
+// captures of the same batch checked before it. Nothing is stored yet.
+func (s *AttendanceService) check(attendance domain.AttendancePayload, pending []domain.AttendancePayload) (domain.Screening, error) {
+	if s.screener == nil {
+		return domain.Screening{Capture: attendance}, nil
+	}
+	screening, err := s.screener.Check(attendance, pending)
+	if err != nil {
+		return domain.Screening{}, fmt.Errorf("error screening capture: %w", err)
+	}
+	return screening, nil
+}
+
+// record keeps a checked capture as a recognition event; a held capture is
+// flagged for faculty review instead of being recorded as attendance.
+func (s *AttendanceService) record(screening domain.Screening) (domain.CaptureResult, error) {
+	if s.screener == nil {
+		return domain.CaptureResult{}, nil
+	}
+	flagID, err := s.screener.Record(screening)
+	if err != nil {
+		return domain.CaptureResult{}, fmt.Errorf("error recording capture: %w", err)
+	}
+	return domain.CaptureResult{FlagID: flagID, Reasons: screening.Reasons}, nil
+}
+
+// recordStored records a screening once the captures it came with are
+// committed. A failure is logged, not returned: the attendance counts either
+// way, and a client retrying on the error would only record it twice.
+func (s *AttendanceService) recordStored(screening domain.Screening) {
+	if _, err := s.record(screening); err != nil {
+		log.Printf("attendance: capture by %s stored without its recognition event: %v", screening.Capture.USN, err)
+	}
+}
+
+// captureSubject is the subject whose logged session covers the capture, so
+// it reaches that subject's live feed before anyone assigns it. A failed
+// lookup costs only the feed the capture, never the capture itself.
+func (s *AttendanceService) captureSubject(a domain.AttendancePayload) string {
+	code, err := s.attendanceRepo.GetCaptureSubject(a.USN, a.RecordedAt)
+	if err != nil {
+		log.Printf("attendance: resolve subject of capture by %s: %v", a.USN, err)
+		return ""
+	}
+	return code
 }
 
+func (s *AttendanceService) MarkAttendance(attendance *domain.AttendancePayload) (domain.CaptureResult, error) {
+	attendance.USN = domain.NormalizeUSN(attendance.USN)
+	if err := s.validate.Struct(attendance); err != nil {
+		return domain.CaptureResult{}, validation.Error(err)
+	}
+
+	screening, err := s.check(*attendance, nil)
+	if err != nil {
+		return domain.CaptureResult{}, err
+	}
+	if screening.Held() {
+		return s.record(screening)
+	}
+
 	id, err := s.attendanceRepo.MarkAttendance(attendance)
 	if err != nil {
-		return 0, fmt.Errorf("error marking attendance: %w", err)
+		return domain.CaptureResult{}, fmt.Errorf("error marking attendance: %w", err)
 	}
-	return id, nil
+	s.recordStored(screening)
+
+	s.publisher.Publish(domain.AttendanceEvent{
+		Type:         domain.EventAttendanceRecorded,
+		AttendanceID: id,
+		USN:          attendance.USN,
+		SubjectCode:  s.captureSubject(*attendance),
+		Status:       attendance.Status,
+		RecordedAt:   attendance.RecordedAt,
+		OccurredAt:   time.Now(),
+	})
+	return domain.CaptureResult{AttendanceID: id}, nil
 }
 
-func (s *AttendanceService) BulkMarkAttendance(attendances []domain.AttendancePayload) (int, error) {
+// BulkMarkAttendance records the captures that pass screening and returns
+// how many were recorded and how many were held for review. Recognition
+// events and flags are written only once the batch is stored, so a batch
+// that fails leaves nothing behind; after that no error is returned, since
+// the batch already counts and retrying it would record its events twice.
+func (s *AttendanceService) BulkMarkAttendance(attendances []domain.AttendancePayload) (int, int, error) {
     // Validate each attendance
-    for _, a := range attendances {
-        if err := s.validate.Struct(a); err != nil {
-            return 0, fmt.Errorf("validation failed for USN=%s: %w", a.USN, err)
+    for i := range attendances {
+        attendances[i].USN = domain.NormalizeUSN(attendances[i].USN)
+        if err := s.validate.Struct(attendances[i]); err != nil {
+            return 0, 0, validation.Item(i, err)
+        }
+    }
+
+    screenings := make([]domain.Screening, 0, len(attendances))
+    clean := make([]domain.AttendancePayload, 0, len(attendances))
+    for i, a := range attendances {
+        screening, err := s.check(a, attendances[:i])
+        if err != nil {
+            return 0, 0, err
+        }
+        screenings = append(screenings, screening)
+        if !screening.Held() {
+            clean = append(clean, a)
         }
     }
+    held := len(attendances) - len(clean)
 
     // Call repo
-    return s.attendanceRepo.BulkMarkAttendance(attendances)
+    var count int
+    if len(clean) > 0 {
+        var err error
+        if count, err = s.attendanceRepo.BulkMarkAttendance(clean); err != nil {
+            return 0, 0, err
+        }
+    }
+    for _, screening := range screenings {
+        s.recordStored(screening)
+    }
+
+    now := time.Now()
+    for _, a := range clean {
+        s.publisher.Publish(domain.AttendanceEvent{
+            Type:        domain.EventAttendanceRecorded,
+            USN:         a.USN,
+            SubjectCode: s.captureSubject(a),
+            Status:      a.Status,
+            RecordedAt:  a.RecordedAt,
+            OccurredAt:  now,
+        })
+    }
+    return count, held, nil
 }
 
 
-func (s *AttendanceService) GetAttendanceByStudentAndSubject(usn string, subjectCode string) ([]domain.AttendanceWithNames, error) {
+func (s *AttendanceService) GetAttendanceByStudentAndSubject(usn string, subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.AttendanceWithNames, int, error) {
+	usn = domain.NormalizeUSN(usn)
 	if err := s.validate.Var(usn, "required"); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+		return nil, 0, validation.Var("usn", err)
+	}
+	if err := s.validate.Var(subjectCode, "required,subject_code"); err != nil {
+		return nil, 0, validation.Var("subjectCode", err)
 	}
-	if err := s.validate.Var(subjectCode, "required"); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+	filter, err := s.resolveFilter(filter)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	attendances, err := s.attendanceRepo.GetAttendanceByStudentAndSubject(usn, subjectCode)
+	attendances, total, err := s.attendanceRepo.GetAttendanceByStudentAndSubject(usn, subjectCode, filter, page)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching attendance: %w", err)
+		return nil, 0, fmt.Errorf("error fetching attendance: %w", err)
 	}
-	return attendances, nil
+	return attendances, total, nil
 }
 func (s *AttendanceService) GetAttendanceBySubjectAndDate(subjectCode string, date time.Time) ([]domain.AttendanceWithNames, error) {
 
 	if subjectCode == "" {
-		return nil, fmt.Errorf("validation error: subject_id is required")
+		return nil, domain.Invalid("subjectCode", "required", "subjectCode is required")
 	}
 	if date.IsZero() {
-		return nil, fmt.Errorf("validation error: date is required")
+		return nil, domain.Invalid("date", "required", "date is required")
 	}
 
 	attendances, err := s.attendanceRepo.GetAttendanceBySubjectAndDate(subjectCode, date)
@@ -87,42 +218,55 @@ func (s *AttendanceService) AssignSubjectToTimeRange(
 
 
 	if err := s.validate.Var(facultyID, "required"); err != nil {
-		return 0, 0, fmt.Errorf("validation error: %w", err)
+		return 0, 0, validation.Var("faculty_id", err)
 	}
-	if err := s.validate.Var(subjectCode, "required"); err != nil {
-		return 0, 0, fmt.Errorf("validation error: %w", err)
+	if err := s.validate.Var(subjectCode, "required,subject_code"); err != nil {
+		return 0, 0, validation.Var("subjectCode", err)
 	}
 	if classDate.IsZero() || start.IsZero() || end.IsZero() {
-		return 0, 0, fmt.Errorf("start, end, and class date are required")
+		return 0, 0, domain.Invalid("class_date", "required", "start, end, and class date are required")
 	}
 
 	updatedCount, skipped, err := s.attendanceRepo.AssignSubjectToTimeRange(facultyID, subjectCode, classDate, start, end)
 	if err != nil {
 		return 0, 0, fmt.Errorf("error assigning subject to time range: %w", err)
 	}
+
+	if updatedCount > 0 {
+		s.publisher.Publish(domain.AttendanceEvent{
+			Type:         domain.EventAttendanceAssigned,
+			SubjectCode:  subjectCode,
+			UpdatedCount: updatedCount,
+			OccurredAt:   time.Now(),
+		})
+	}
 	return updatedCount, skipped, nil
 }
 
 
-func (s *AttendanceService) GetAttendanceSummaryBySubject(subjectCode string) ([]domain.StudentSummary, error) {
+func (s *AttendanceService) GetAttendanceSummaryBySubject(subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest) ([]domain.StudentSummary, int, error) {
 
-	if err := s.validate.Var(subjectCode, "required"); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+	if err := s.validate.Var(subjectCode, "required,subject_code"); err != nil {
+		return nil, 0, validation.Var("subjectCode", err)
+	}
+	filter, err := s.resolveFilter(filter)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	summaries, err := s.attendanceRepo.GetAttendanceSummaryBySubject(subjectCode)
+	summaries, total, err := s.attendanceRepo.GetAttendanceSummaryBySubject(subjectCode, filter, page)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching attendance summary: %w", err)
+		return nil, 0, fmt.Errorf("error fetching attendance summary: %w", err)
 	}
-	return summaries, nil
+	return summaries, total, nil
 }
 
 func (s *AttendanceService) GetClassAttendance(subjectCode string, date time.Time) ([]domain.ClassAttendance, error) {
-	if err := s.validate.Var(subjectCode, "required"); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+	if err := s.validate.Var(subjectCode, "required,subject_code"); err != nil {
+		return nil, validation.Var("subjectCode", err)
 	}
 	if date.IsZero() {
-		return nil, fmt.Errorf("validation error: date is required")
+		return nil, domain.Invalid("date", "required", "date is required")
 	}
 
 	attendances, err := s.attendanceRepo.GetClassAttendance(subjectCode, date)
@@ -132,31 +276,75 @@ func (s *AttendanceService) GetClassAttendance(subjectCode string, date time.Tim
 	return attendances, nil
 }
 
-func (s *AttendanceService) GetStudentAttendanceHistory(usn string, subjectCode string)([]domain.StudentHistory,error){
+func (s *AttendanceService) GetStudentAttendanceHistory(usn string, subjectCode string, filter domain.AttendanceFilter, page domain.PageRequest)([]domain.StudentHistory, int, error){
+	usn = domain.NormalizeUSN(usn)
 	if err := s.validate.Var(usn, "required"); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+		return nil, 0, validation.Var("usn", err)
 	}
-	if err := s.validate.Var(subjectCode, "required"); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+	if err := s.validate.Var(subjectCode, "required,subject_code"); err != nil {
+		return nil, 0, validation.Var("subjectCode", err)
+	}
+	filter, err := s.resolveFilter(filter)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	history, err := s.attendanceRepo.GetStudentAttendanceHistory(usn, subjectCode)
+	history, total, err := s.attendanceRepo.GetStudentAttendanceHistory(usn, subjectCode, filter, page)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching student attendance history: %w", err)
+		return nil, 0, fmt.Errorf("error fetching student attendance history: %w", err)
 	}
-	return history, nil
+	return history, total, nil
 }
 
-func (s *AttendanceService) GetAttendanceSummaryByStudent(usn string) ([]domain.SubjectSummary, error) {
+func (s *AttendanceService) GetAttendanceSummaryByStudent(usn string, filter domain.AttendanceFilter) ([]domain.SubjectSummary, error) {
+	usn = domain.NormalizeUSN(usn)
 	if err := s.validate.Var(usn, "required"); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+		return nil, validation.Var("usn", err)
+	}
+	filter, err := s.resolveFilter(filter)
+	if err != nil {
+		return nil, err
 	}
 
-	summaries, err := s.attendanceRepo.GetAttendanceSummaryByStudent(usn)
+	summaries, err := s.attendanceRepo.GetAttendanceSummaryByStudent(usn, filter)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching attendance summary: %w", err)
 	}
 	return summaries, nil
 }
 
+func (s *AttendanceService) CorrectAttendance(facultyID int64, attendanceID int64, req domain.AttendanceCorrectionPayload) (domain.AttendanceCorrection, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return domain.AttendanceCorrection{}, validation.Error(err)
+	}
+
+	correction, err := s.attendanceRepo.CorrectAttendance(facultyID, attendanceID, req.Status)
+	if err != nil {
+		return domain.AttendanceCorrection{}, fmt.Errorf("error correcting attendance: %w", err)
+	}
+
+	if correction.OldStatus != correction.Status {
+		s.publisher.Publish(domain.AttendanceEvent{
+			Type:         domain.EventAttendanceCorrected,
+			AttendanceID: correction.AttendanceID,
+			USN:          correction.USN,
+			SubjectCode:  correction.SubjectCode,
+			Status:       correction.Status,
+			OldStatus:    correction.OldStatus,
+			RecordedAt:   correction.RecordedAt,
+			OccurredAt:   time.Now(),
+		})
+	}
+	return correction, nil
+}
+
+// AuthorizeSubjectFeed checks that the faculty teaches the subject whose live
+// feed they asked for, or substitutes for one of its classes on day.
+func (s *AttendanceService) AuthorizeSubjectFeed(facultyID int64, subjectCode string, day time.Time) error {
+	if err := s.validate.Var(subjectCode, "required,subject_code"); err != nil {
+		return validation.Var("subjectCode", err)
+	}
 
+	filter := domain.AttendanceFilter{From: day, To: day}
+	return domain.AuthorizeSubject(s.attendanceRepo, s.substitutionRepo, facultyID, subjectCode, filter)
+}
@@ -0,0 +1,356 @@
+package attendence_service_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	attendence_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/attendence"
+)
+
+// recorder is an EventPublisher that keeps every event it receives.
+type recorder struct {
+	mu     sync.Mutex
+	events []domain.AttendanceEvent
+}
+
+func (r *recorder) Publish(event domain.AttendanceEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) ofType(eventType string) []domain.AttendanceEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []domain.AttendanceEvent
+	for _, e := range r.events {
+		if e.Type == eventType {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+type fixture struct {
+	svc    *attendence_service.AttendanceService
+	repo   *memory.MemoryRepo
+	events *recorder
+	// owner teaches CS501; other teaches nothing.
+	owner, other int64
+}
+
+var classDay = time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+
+// at is a capture time on classDay.
+func at(hour, minute int) time.Time {
+	return classDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	repo := memory.NewMemoryRepo(nil)
+	f := fixture{repo: repo, events: &recorder{}}
+	f.svc = attendence_service.NewAttendanceService(repo, repo, repo, f.events, nil)
+
+	var err error
+	if f.owner, err = repo.CreateFaculty(domain.FacultyRegisterPayload{Name: "Ravi", Email: "ravi@college.edu", Password: "secret123", Department: "CSE"}); err != nil {
+		t.Fatal(err)
+	}
+	if f.other, err = repo.CreateFaculty(domain.FacultyRegisterPayload{Name: "Meera", Email: "meera@college.edu", Password: "secret123", Department: "CSE"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.AddSubject(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: f.owner, Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []domain.StudentRegisterPayload{
+		{USN: "1RV21CS001", Username: "Alice", Password: "secret123", Department: "CSE", Sem: 5},
+		{USN: "1RV21CS002", Username: "Bob", Password: "secret123", Department: "CSE", Sem: 5},
+	} {
+		if _, err := repo.StudentRegister(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func (f fixture) mark(t *testing.T, usn, status string, recordedAt time.Time) int64 {
+	t.Helper()
+	result, err := f.svc.MarkAttendance(&domain.AttendancePayload{USN: usn, Status: status, RecordedAt: recordedAt})
+	if err != nil {
+		t.Fatalf("MarkAttendance(%s): %v", usn, err)
+	}
+	return result.AttendanceID
+}
+
+func (f fixture) assign(t *testing.T, facultyID int64, start, end time.Time) (int64, int64, error) {
+	t.Helper()
+	return f.svc.AssignSubjectToTimeRange(facultyID, "CS501", classDay, start, end)
+}
+
+func TestMarkAttendanceUpsertsUnassignedCapture(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.mark(t, "1RV21CS001", "Absent", at(9, 5))
+	second := f.mark(t, "1RV21CS001", "Present", at(9, 10))
+	if first != second {
+		t.Fatalf("second capture on the same day got id %d, want %d", second, first)
+	}
+
+	if got := len(f.events.ofType(domain.EventAttendanceRecorded)); got != 2 {
+		t.Fatalf("recorded events = %d, want 2", got)
+	}
+
+	if _, err := f.svc.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS999", Status: "Present", RecordedAt: at(9, 0)}); err == nil {
+		t.Fatal("capture for an unknown usn accepted")
+	}
+}
+
+func TestBulkMarkAttendanceIsAtomic(t *testing.T) {
+	f := newFixture(t)
+
+	_, _, err := f.svc.BulkMarkAttendance([]domain.AttendancePayload{
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: at(9, 0)},
+		{USN: "1RV21CS999", Status: "Present", RecordedAt: at(9, 0)},
+	})
+	if err == nil {
+		t.Fatal("batch with an unknown usn accepted")
+	}
+	if n := len(f.events.ofType(domain.EventAttendanceRecorded)); n != 0 {
+		t.Fatalf("failed batch published %d events", n)
+	}
+
+	// Nothing from the failed batch may be left to assign.
+	updated, _, err := f.assign(t, f.owner, at(8, 0), at(10, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != 0 {
+		t.Fatalf("failed batch left %d rows behind", updated)
+	}
+}
+
+// brokenScreener passes every capture but fails to record any of them.
+type brokenScreener struct{}
+
+func (brokenScreener) Check(a domain.AttendancePayload, _ []domain.AttendancePayload) (domain.Screening, error) {
+	return domain.Screening{Capture: a}, nil
+}
+
+func (brokenScreener) Record(domain.Screening) (int64, error) {
+	return 0, errors.New("recognition events unavailable")
+}
+
+// Once the attendance is committed a failure to record its recognition
+// events is not reported, so the client does not retry a stored capture.
+func TestRecordFailureAfterCommitIsNotAnError(t *testing.T) {
+	f := newFixture(t)
+	svc := attendence_service.NewAttendanceService(f.repo, f.repo, f.repo, f.events, brokenScreener{})
+
+	result, err := svc.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS001", Status: "Present", RecordedAt: at(9, 0)})
+	if err != nil || result.AttendanceID == 0 {
+		t.Fatalf("MarkAttendance = %+v, %v, want the stored capture", result, err)
+	}
+	count, held, err := svc.BulkMarkAttendance([]domain.AttendancePayload{
+		{USN: "1RV21CS002", Status: "Present", RecordedAt: at(9, 5)},
+	})
+	if err != nil || count != 1 || held != 0 {
+		t.Fatalf("BulkMarkAttendance = %d, %d, %v, want the stored batch", count, held, err)
+	}
+	if n := len(f.events.ofType(domain.EventAttendanceRecorded)); n != 2 {
+		t.Errorf("recorded events = %d, want 2", n)
+	}
+}
+
+// A capture inside a logged session is published under its subject, so the
+// live feed shows it before anyone assigns it; one outside every session
+// carries no subject.
+func TestCaptureEventsCarryTheSessionSubject(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.repo.LogTeachingSession(f.owner, domain.TeachingSession{
+		SubjectCode: "CS501", Date: classDay, StartTime: "09:00", DurationMinutes: 60, Topic: "Parsing",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	f.mark(t, "1RV21CS001", "Present", at(9, 10))
+	if _, _, err := f.svc.BulkMarkAttendance([]domain.AttendancePayload{
+		{USN: "1RV21CS002", Status: "Present", RecordedAt: at(9, 20)},
+		{USN: "1RV21CS001", Status: "Present", RecordedAt: at(11, 30)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	events := f.events.ofType(domain.EventAttendanceRecorded)
+	if len(events) != 3 {
+		t.Fatalf("events = %+v", events)
+	}
+	for i, want := range []string{"CS501", "CS501", ""} {
+		if events[i].SubjectCode != want {
+			t.Errorf("event %d = %+v, want subject %q", i, events[i], want)
+		}
+	}
+}
+
+func TestAssignSubjectToTimeRange(t *testing.T) {
+	f := newFixture(t)
+	f.mark(t, "1RV21CS001", "Present", at(9, 5))
+	f.mark(t, "1RV21CS002", "Present", at(11, 30))
+
+	if _, _, err := f.assign(t, f.other, at(9, 0), at(10, 0)); err == nil {
+		t.Fatal("faculty assigned a subject they do not teach")
+	}
+
+	updated, skipped, err := f.assign(t, f.owner, at(9, 0), at(10, 0))
+	if err != nil {
+		t.Fatalf("AssignSubjectToTimeRange: %v", err)
+	}
+	if updated != 1 || skipped != 0 {
+		t.Fatalf("updated, skipped = %d, %d; want 1, 0", updated, skipped)
+	}
+
+	assigned := f.events.ofType(domain.EventAttendanceAssigned)
+	if len(assigned) != 1 || assigned[0].UpdatedCount != 1 || assigned[0].SubjectCode != "CS501" {
+		t.Fatalf("assigned events = %+v", assigned)
+	}
+
+	rows, err := f.svc.GetClassAttendance("CS501", classDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].USN != "1RV21CS001" {
+		t.Fatalf("class attendance = %+v, want only 1RV21CS001", rows)
+	}
+}
+
+func TestAssignSkipsStudentsAlreadyAssigned(t *testing.T) {
+	f := newFixture(t)
+	f.mark(t, "1RV21CS001", "Present", at(9, 5))
+	if _, _, err := f.assign(t, f.owner, at(9, 0), at(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh capture the same day cannot be assigned to CS501 again.
+	f.mark(t, "1RV21CS001", "Present", at(9, 20))
+	updated, skipped, err := f.assign(t, f.owner, at(9, 0), at(10, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != 0 || skipped != 1 {
+		t.Fatalf("updated, skipped = %d, %d; want 0, 1", updated, skipped)
+	}
+}
+
+func TestCorrectAttendance(t *testing.T) {
+	f := newFixture(t)
+	id := f.mark(t, "1RV21CS001", "Absent", at(9, 5))
+
+	correct := domain.AttendanceCorrectionPayload{Status: "Present"}
+	if _, err := f.svc.CorrectAttendance(f.owner, id, correct); err == nil {
+		t.Fatal("corrected a row that has no subject yet")
+	}
+	if _, _, err := f.assign(t, f.owner, at(9, 0), at(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.svc.CorrectAttendance(f.other, id, correct); err == nil {
+		t.Fatal("another faculty corrected the row")
+	}
+
+	c, err := f.svc.CorrectAttendance(f.owner, id, correct)
+	if err != nil {
+		t.Fatalf("CorrectAttendance: %v", err)
+	}
+	if c.OldStatus != "Absent" || c.Status != "Present" || c.SubjectCode != "CS501" {
+		t.Fatalf("correction = %+v", c)
+	}
+
+	corrected := f.events.ofType(domain.EventAttendanceCorrected)
+	if len(corrected) != 1 || corrected[0].AttendanceID != id || corrected[0].OldStatus != "Absent" {
+		t.Fatalf("corrected events = %+v", corrected)
+	}
+
+	// Setting the same status again is not a change worth announcing.
+	if _, err := f.svc.CorrectAttendance(f.owner, id, correct); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.events.ofType(domain.EventAttendanceCorrected)); n != 1 {
+		t.Fatalf("no-op correction published an event; %d events", n)
+	}
+}
+
+func TestAttendanceSummaries(t *testing.T) {
+	f := newFixture(t)
+	for day := 0; day < 4; day++ {
+		status := "Present"
+		if day == 3 {
+			status = "Absent"
+		}
+		start := classDay.AddDate(0, 0, day)
+		if _, err := f.svc.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS001", Status: status, RecordedAt: start.Add(9 * time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.svc.MarkAttendance(&domain.AttendancePayload{USN: "1RV21CS002", Status: "Present", RecordedAt: start.Add(9 * time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := f.svc.AssignSubjectToTimeRange(f.owner, "CS501", start, at(8, 0), at(10, 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summary, total, err := f.svc.GetAttendanceSummaryBySubject("CS501", domain.AttendanceFilter{}, domain.PageRequest{Limit: 10, Sort: "percentage"})
+	if err != nil {
+		t.Fatalf("GetAttendanceSummaryBySubject: %v", err)
+	}
+	if total != 2 || len(summary) != 2 {
+		t.Fatalf("total = %d, rows = %d; want 2, 2", total, len(summary))
+	}
+	if summary[0].USN != "1RV21CS001" || summary[0].Attended != 3 || summary[0].TotalClasses != 4 || summary[0].Percentage != 75 {
+		t.Fatalf("lowest row = %+v, want Alice at 75%%", summary[0])
+	}
+
+	if _, _, err := f.svc.GetAttendanceSummaryBySubject("CS501", domain.AttendanceFilter{}, domain.PageRequest{Limit: 10, Sort: "nope"}); err == nil {
+		t.Fatal("unsupported sort key accepted")
+	}
+
+	// The range filter keeps only the first two class days.
+	filter := domain.AttendanceFilter{From: classDay, To: classDay.AddDate(0, 0, 1)}
+	byStudent, err := f.svc.GetAttendanceSummaryByStudent("1RV21CS001", filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byStudent) != 1 || byStudent[0].TotalClasses != 2 || byStudent[0].Percentage != 100 {
+		t.Fatalf("student summary = %+v", byStudent)
+	}
+
+	history, total, err := f.svc.GetStudentAttendanceHistory("1RV21CS001", "CS501", domain.AttendanceFilter{Status: "Absent"}, domain.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(history) != 1 || !history[0].Date.Equal(classDay.AddDate(0, 0, 3)) {
+		t.Fatalf("absences = %+v (total %d)", history, total)
+	}
+}
+
+func TestLegacyUSNMarkedPresent(t *testing.T) {
+	f := newFixture(t)
+	// Registered before the current USN format was enforced.
+	if _, err := f.repo.StudentRegister(domain.StudentRegisterPayload{USN: "1CS21001", Username: "Carol", Password: "secret123", Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	f.mark(t, "1cs21001", "Present", at(9, 5))
+	if _, _, err := f.assign(t, f.owner, at(8, 0), at(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := f.svc.GetAttendanceSummaryByStudent("1CS21001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatalf("GetAttendanceSummaryByStudent: %v", err)
+	}
+	if len(summary) != 1 || summary[0].Attended != 1 || summary[0].TotalClasses != 1 {
+		t.Fatalf("summary = %+v, want 1/1", summary)
+	}
+}
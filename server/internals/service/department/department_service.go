@@ -0,0 +1,60 @@
+package department_service
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+type DepartmentService struct {
+	departmentRepo domain.DepartmentRepo
+	validate       *validator.Validate
+}
+
+func NewDepartmentService(departmentRepo domain.DepartmentRepo) *DepartmentService {
+	v := validation.New()
+	return &DepartmentService{
+		departmentRepo: departmentRepo,
+		validate:       v,
+	}
+}
+
+func (s *DepartmentService) CreateDepartment(req domain.DepartmentPayload) error {
+	req.Code = domain.DepartmentCode(req.Code)
+	if err := s.validate.Struct(req); err != nil {
+		return validation.Error(err)
+	}
+
+	if err := s.departmentRepo.CreateDepartment(req); err != nil {
+		return fmt.Errorf("error creating department: %w", err)
+	}
+	return nil
+}
+
+func (s *DepartmentService) GetDepartments() ([]domain.Department, error) {
+	departments, err := s.departmentRepo.GetDepartments()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching departments: %w", err)
+	}
+	if departments == nil {
+		departments = []domain.Department{}
+	}
+	return departments, nil
+}
+
+func (s *DepartmentService) GetDepartment(code string) (domain.Department, error) {
+	return s.departmentRepo.GetDepartment(domain.DepartmentCode(code))
+}
+
+func (s *DepartmentService) UpdateDepartment(code string, req domain.DepartmentUpdatePayload) error {
+	if err := s.validate.Struct(req); err != nil {
+		return validation.Error(err)
+	}
+	return s.departmentRepo.UpdateDepartment(domain.DepartmentCode(code), req)
+}
+
+func (s *DepartmentService) DeleteDepartment(code string) error {
+	return s.departmentRepo.DeleteDepartment(domain.DepartmentCode(code))
+}
@@ -0,0 +1,64 @@
+package department_service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	department_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/department"
+)
+
+func TestCreateDepartmentNormalizesCode(t *testing.T) {
+	repo := memory.NewMemoryRepo(nil)
+	svc := department_service.NewDepartmentService(repo)
+
+	if err := svc.CreateDepartment(domain.DepartmentPayload{Code: " phy", Name: "Physics", Semesters: 6}); err != nil {
+		t.Fatalf("CreateDepartment: %v", err)
+	}
+	d, err := svc.GetDepartment("PHY")
+	if err != nil || d.Name != "Physics" || d.Semesters != 6 {
+		t.Fatalf("GetDepartment = %+v, %v", d, err)
+	}
+	if err := svc.CreateDepartment(domain.DepartmentPayload{Code: "PHY", Name: "Again", Semesters: 6}); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("duplicate: %v, want a conflict", err)
+	}
+
+	// A department caps the sem of its members.
+	_, err = repo.StudentRegister(domain.StudentRegisterPayload{USN: "1RV21PH001", Username: "Alice", Department: "PHY", Sem: 7})
+	var derr *domain.Error
+	if !errors.As(err, &derr) || len(derr.Fields) != 1 || derr.Fields[0].Field != "sem" {
+		t.Errorf("sem past the last semester: %v, want a sem validation error", err)
+	}
+}
+
+func TestUpdateAndDeleteDepartment(t *testing.T) {
+	repo := memory.NewMemoryRepo(nil)
+	svc := department_service.NewDepartmentService(repo)
+
+	hod, err := repo.CreateFaculty(domain.FacultyRegisterPayload{Name: "Ravi", Email: "ravi@college.edu", Password: "secret123", Department: "CSE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.UpdateDepartment("cse", domain.DepartmentUpdatePayload{Name: "Computer Science", HODFacultyID: &hod, Semesters: 8}); err != nil {
+		t.Fatalf("UpdateDepartment: %v", err)
+	}
+	d, err := svc.GetDepartment("CSE")
+	if err != nil || d.HODFacultyID == nil || *d.HODFacultyID != hod || d.HODName != "Ravi" {
+		t.Fatalf("GetDepartment = %+v, %v", d, err)
+	}
+
+	missing := hod + 100
+	if err := svc.UpdateDepartment("CSE", domain.DepartmentUpdatePayload{Name: "CSE", HODFacultyID: &missing, Semesters: 8}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("unknown HOD: %v, want not found", err)
+	}
+	if err := svc.DeleteDepartment("CSE"); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("delete department with faculty: %v, want a conflict", err)
+	}
+	if err := svc.DeleteDepartment("ME"); err != nil {
+		t.Errorf("delete unused department: %v", err)
+	}
+	if _, err := svc.GetDepartment("ME"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("deleted department: %v, want not found", err)
+	}
+}
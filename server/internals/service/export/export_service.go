@@ -0,0 +1,118 @@
+package export_service
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatPDF  = "pdf"
+)
+
+// registerWriter renders the register grid; Header is called once before the
+// rows and Close once after the last row.
+type registerWriter interface {
+	Header(reg domain.AttendanceRegister) error
+	Row(row domain.AttendanceRegisterRow) error
+	Close() error
+}
+
+type ExportService struct {
+	reportRepo       domain.ReportRepo
+	termRepo         domain.TermRepo
+	attendanceRepo   domain.AttendanceRepository
+	substitutionRepo domain.SubstitutionRepo
+}
+
+func NewExportService(reportRepo domain.ReportRepo, termRepo domain.TermRepo, attendanceRepo domain.AttendanceRepository, substitutionRepo domain.SubstitutionRepo) *ExportService {
+	return &ExportService{
+		reportRepo:       reportRepo,
+		termRepo:         termRepo,
+		attendanceRepo:   attendanceRepo,
+		substitutionRepo: substitutionRepo,
+	}
+}
+
+// ContentType returns the MIME type and file extension for a supported format.
+func ContentType(format string) (string, bool) {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8", true
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", true
+	case FormatPDF:
+		return "application/pdf", true
+	}
+	return "", false
+}
+
+// PrepareRegister validates the request and loads the register header. It is
+// split from WriteRegister so callers can fail with a proper status before
+// any bytes of the file have been sent.
+func (s *ExportService) PrepareRegister(subjectCode string, filter domain.AttendanceFilter) (domain.AttendanceRegister, error) {
+	if subjectCode == "" {
+		return domain.AttendanceRegister{}, domain.Invalid("subjectCode", "required", "subjectCode is required")
+	}
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, filter)
+	if err != nil {
+		return domain.AttendanceRegister{}, err
+	}
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return domain.AttendanceRegister{}, domain.Invalid("from", "required", "from and to, or a term, are required")
+	}
+
+	reg, err := s.reportRepo.GetAttendanceRegister(subjectCode, filter.From, filter.To)
+	if err != nil {
+		return reg, fmt.Errorf("error fetching register: %w", err)
+	}
+	return reg, nil
+}
+
+// AuthorizeRegister checks that the faculty teaches the register's subject
+// or substitutes for one of its classes in the register's period.
+func (s *ExportService) AuthorizeRegister(facultyID int64, reg domain.AttendanceRegister) error {
+	filter := domain.AttendanceFilter{From: reg.From, To: reg.To}
+	return domain.AuthorizeSubject(s.attendanceRepo, s.substitutionRepo, facultyID, reg.SubjectCode, filter)
+}
+
+// WriteRegister streams the register of reg to w in the given format.
+func (s *ExportService) WriteRegister(format string, w io.Writer, reg domain.AttendanceRegister) error {
+	var rw registerWriter
+	switch format {
+	case FormatCSV:
+		rw = newCSVRegisterWriter(w)
+	case FormatXLSX:
+		rw = newXLSXRegisterWriter(w)
+	case FormatPDF:
+		rw = newPDFRegisterWriter(w)
+	default:
+		return domain.Invalid("format", "oneof", "unsupported format %q", format)
+	}
+
+	if err := rw.Header(reg); err != nil {
+		return fmt.Errorf("write register header: %w", err)
+	}
+	if err := s.reportRepo.StreamAttendanceRegisterRows(reg, rw.Row); err != nil {
+		return fmt.Errorf("write register rows: %w", err)
+	}
+	if err := rw.Close(); err != nil {
+		return fmt.Errorf("finish register: %w", err)
+	}
+	return nil
+}
+
+func registerHeadings(reg domain.AttendanceRegister) []string {
+	headings := []string{"USN", "Student Name"}
+	for i, d := range reg.Dates {
+		heading := d.Format("02-01-2006")
+		if i < len(reg.StartTimes) {
+			heading += " " + reg.StartTimes[i]
+		}
+		headings = append(headings, heading)
+	}
+	return append(headings, "Attended", "Total", "Percentage")
+}
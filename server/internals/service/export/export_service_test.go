@@ -0,0 +1,84 @@
+package export_service_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	export_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/export"
+)
+
+var june = domain.AttendanceFilter{
+	From: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	To:   time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+}
+
+func TestAuthorizeRegister(t *testing.T) {
+	b := memorytest.New(t)
+	repo := b.Repo
+	svc := export_service.NewExportService(repo, repo, repo, repo)
+
+	ravi, asha, kiran := b.Faculty("Ravi", "CSE"), b.Faculty("Asha", "CSE"), b.Faculty("Kiran", "CSE")
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi})
+	if _, err := repo.CreateSubstitution(kiran, domain.Substitution{
+		SubjectCode: "CS501", Department: "CSE", Date: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), SubstituteID: asha,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	reg, err := svc.PrepareRegister("CS501", june)
+	if err != nil {
+		t.Fatalf("PrepareRegister: %v", err)
+	}
+	if err := svc.AuthorizeRegister(ravi, reg); err != nil {
+		t.Errorf("owner: %v", err)
+	}
+	if err := svc.AuthorizeRegister(asha, reg); err != nil {
+		t.Errorf("substitute in the period: %v", err)
+	}
+	if err := svc.AuthorizeRegister(kiran, reg); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("unrelated faculty = %v, want forbidden", err)
+	}
+
+	july, err := svc.PrepareRegister("CS501", domain.AttendanceFilter{From: june.From.AddDate(0, 1, 0), To: june.To.AddDate(0, 1, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AuthorizeRegister(asha, july); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("substitute outside the period = %v, want forbidden", err)
+	}
+}
+
+func TestPDFRegisterPages(t *testing.T) {
+	b := memorytest.New(t)
+	svc := export_service.NewExportService(b.Repo, b.Repo, b.Repo, b.Repo)
+
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: b.Faculty("Ravi", "CSE")})
+	var students []domain.StudentRegisterPayload
+	for i := 1; i <= 80; i++ {
+		students = append(students, domain.StudentRegisterPayload{USN: fmt.Sprintf("1RV21CS%03d", i), Username: "Student"})
+	}
+	b.Students(students...)
+
+	reg, err := svc.PrepareRegister("CS501", june)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 30 dates need two blocks of columns.
+	for i := 0; i < 30; i++ {
+		reg.Dates = append(reg.Dates, june.From.AddDate(0, 0, i))
+	}
+
+	var out bytes.Buffer
+	if err := svc.WriteRegister(export_service.FormatPDF, &out, reg); err != nil {
+		t.Fatalf("WriteRegister: %v", err)
+	}
+	// 80 students make three pages of rows, each drawn for both blocks.
+	if pages := bytes.Count(out.Bytes(), []byte("/Type /Page\n")); pages != 6 {
+		t.Errorf("pages = %d, want 6", pages)
+	}
+}
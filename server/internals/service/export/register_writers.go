@@ -0,0 +1,239 @@
+package export_service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// csv
+
+type csvRegisterWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVRegisterWriter(w io.Writer) *csvRegisterWriter {
+	return &csvRegisterWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvRegisterWriter) Header(reg domain.AttendanceRegister) error {
+	return c.w.Write(registerHeadings(reg))
+}
+
+func (c *csvRegisterWriter) Row(row domain.AttendanceRegisterRow) error {
+	record := append([]string{row.USN, row.StudentName}, row.Cells...)
+	record = append(record, strconv.Itoa(row.Attended), strconv.Itoa(row.Total),
+		strconv.FormatFloat(row.Percentage, 'f', 2, 64))
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+
+	// Flush periodically so large classes reach the client while rendering.
+	c.rows++
+	if c.rows%100 == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvRegisterWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// xlsx
+
+const xlsxSheet = "Register"
+
+type xlsxRegisterWriter struct {
+	out  io.Writer
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	next int
+}
+
+func newXLSXRegisterWriter(w io.Writer) *xlsxRegisterWriter {
+	return &xlsxRegisterWriter{out: w}
+}
+
+func (x *xlsxRegisterWriter) Header(reg domain.AttendanceRegister) error {
+	x.file = excelize.NewFile()
+	if err := x.file.SetSheetName("Sheet1", xlsxSheet); err != nil {
+		return err
+	}
+
+	sw, err := x.file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		return err
+	}
+	x.sw = sw
+
+	title := fmt.Sprintf("%s - %s (%s, Sem %d)", reg.SubjectCode, reg.SubjectName, reg.Department, reg.Sem)
+	period := fmt.Sprintf("Faculty: %s    Period: %s to %s", reg.FacultyName,
+		reg.From.Format("02-01-2006"), reg.To.Format("02-01-2006"))
+	if err := x.writeRow([]any{title}); err != nil {
+		return err
+	}
+	if err := x.writeRow([]any{period}); err != nil {
+		return err
+	}
+
+	headings := registerHeadings(reg)
+	cells := make([]any, len(headings))
+	for i, h := range headings {
+		cells[i] = h
+	}
+	return x.writeRow(cells)
+}
+
+func (x *xlsxRegisterWriter) Row(row domain.AttendanceRegisterRow) error {
+	cells := make([]any, 0, len(row.Cells)+5)
+	cells = append(cells, row.USN, row.StudentName)
+	for _, c := range row.Cells {
+		cells = append(cells, c)
+	}
+	cells = append(cells, row.Attended, row.Total, row.Percentage)
+	return x.writeRow(cells)
+}
+
+func (x *xlsxRegisterWriter) writeRow(cells []any) error {
+	x.next++
+	cell, err := excelize.CoordinatesToCellName(1, x.next)
+	if err != nil {
+		return err
+	}
+	return x.sw.SetRow(cell, cells)
+}
+
+func (x *xlsxRegisterWriter) Close() error {
+	defer x.file.Close()
+	if err := x.sw.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
+
+// pdf
+
+const (
+	// pdfDatesPerPage keeps date columns readable on landscape A4; longer
+	// ranges continue on further pages with the USN and name columns repeated.
+	pdfDatesPerPage = 26
+	// pdfRowsPerPage is how many fixed-height rows fit under the page header.
+	pdfRowsPerPage = 34
+)
+
+const (
+	pdfUSNWidth   = 24.0
+	pdfNameWidth  = 42.0
+	pdfDateWidth  = 7.5
+	pdfTotalWidth = 14.0
+	pdfRowHeight  = 5.0
+)
+
+// pdfRegisterWriter lays the register out one page of students at a time:
+// each batch of pdfRowsPerPage rows is drawn once per block of date columns
+// and then dropped, so the rows held never exceed a page. fpdf itself keeps
+// the finished pages until Close writes the document.
+type pdfRegisterWriter struct {
+	out  io.Writer
+	pdf  *fpdf.Fpdf
+	reg  domain.AttendanceRegister
+	rows []domain.AttendanceRegisterRow
+}
+
+func newPDFRegisterWriter(w io.Writer) *pdfRegisterWriter {
+	return &pdfRegisterWriter{out: w}
+}
+
+func (p *pdfRegisterWriter) Header(reg domain.AttendanceRegister) error {
+	p.reg = reg
+	p.pdf = fpdf.New("L", "mm", "A4", "")
+	p.pdf.SetMargins(8, 10, 8)
+	// Pages are broken by row count, never by fpdf.
+	p.pdf.SetAutoPageBreak(false, 0)
+	p.rows = make([]domain.AttendanceRegisterRow, 0, pdfRowsPerPage)
+	return nil
+}
+
+func (p *pdfRegisterWriter) Row(row domain.AttendanceRegisterRow) error {
+	p.rows = append(p.rows, row)
+	if len(p.rows) == pdfRowsPerPage {
+		return p.flushPage()
+	}
+	return nil
+}
+
+func (p *pdfRegisterWriter) Close() error {
+	// An empty class still gets a page with the headings.
+	if len(p.rows) > 0 || p.pdf.PageNo() == 0 {
+		if err := p.flushPage(); err != nil {
+			return err
+		}
+	}
+	return p.pdf.Output(p.out)
+}
+
+// flushPage draws the buffered rows on one page per block of date columns;
+// only the last block carries the totals.
+func (p *pdfRegisterWriter) flushPage() error {
+	dates := p.reg.Dates
+	chunks := max((len(dates)+pdfDatesPerPage-1)/pdfDatesPerPage, 1)
+
+	for chunk := 0; chunk < chunks; chunk++ {
+		start := chunk * pdfDatesPerPage
+		end := min(start+pdfDatesPerPage, len(dates))
+		last := chunk == chunks-1
+
+		p.pdf.AddPage()
+		p.pageHeader(dates[start:end], last)
+		for _, row := range p.rows {
+			p.pdf.CellFormat(pdfUSNWidth, pdfRowHeight, row.USN, "1", 0, "L", false, 0, "")
+			p.pdf.CellFormat(pdfNameWidth, pdfRowHeight, row.StudentName, "1", 0, "L", false, 0, "")
+			for _, cell := range row.Cells[start:end] {
+				p.pdf.CellFormat(pdfDateWidth, pdfRowHeight, cell, "1", 0, "C", false, 0, "")
+			}
+			if last {
+				p.pdf.CellFormat(pdfTotalWidth, pdfRowHeight, strconv.Itoa(row.Attended), "1", 0, "C", false, 0, "")
+				p.pdf.CellFormat(pdfTotalWidth, pdfRowHeight, strconv.Itoa(row.Total), "1", 0, "C", false, 0, "")
+				p.pdf.CellFormat(pdfTotalWidth, pdfRowHeight, strconv.FormatFloat(row.Percentage, 'f', 2, 64), "1", 0, "C", false, 0, "")
+			}
+			p.pdf.Ln(-1)
+		}
+	}
+
+	p.rows = p.rows[:0]
+	return p.pdf.Error()
+}
+
+func (p *pdfRegisterWriter) pageHeader(dates []time.Time, totals bool) {
+	pdf := p.pdf
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Attendance Register: %s - %s", p.reg.SubjectCode, p.reg.SubjectName), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.CellFormat(0, 5, fmt.Sprintf("%s, Sem %d    Faculty: %s    Period: %s to %s", p.reg.Department, p.reg.Sem,
+		p.reg.FacultyName, p.reg.From.Format("02-01-2006"), p.reg.To.Format("02-01-2006")), "", 1, "L", false, 0, "")
+	pdf.Ln(1)
+
+	pdf.SetFont("Helvetica", "B", 6)
+	pdf.CellFormat(pdfUSNWidth, pdfRowHeight, "USN", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(pdfNameWidth, pdfRowHeight, "Student Name", "1", 0, "C", false, 0, "")
+	for _, d := range dates {
+		pdf.CellFormat(pdfDateWidth, pdfRowHeight, d.Format("02/01"), "1", 0, "C", false, 0, "")
+	}
+	if totals {
+		pdf.CellFormat(pdfTotalWidth, pdfRowHeight, "Attended", "1", 0, "C", false, 0, "")
+		pdf.CellFormat(pdfTotalWidth, pdfRowHeight, "Total", "1", 0, "C", false, 0, "")
+		pdf.CellFormat(pdfTotalWidth, pdfRowHeight, "%", "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont("Helvetica", "", 6)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
 
 )
 
@@ -14,7 +15,7 @@ type FacultyService struct {
 }
 
 func NewFacultyService(facultyRepo domain.FacultyRepo) *FacultyService {
-	v := validator.New()
+	v := validation.New()
 	return &FacultyService{
 		facultyRepo: facultyRepo,
 		validate:    v,
@@ -22,8 +23,9 @@ func NewFacultyService(facultyRepo domain.FacultyRepo) *FacultyService {
 }
 
 func (s *FacultyService) RegisterFaculty(req domain.FacultyRegisterPayload) (int64,error) {
+	req.Department = domain.DepartmentCode(req.Department)
 	if err := s.validate.Struct(req); err != nil {
-		return 0, fmt.Errorf("validation error: %w", err)
+		return 0, validation.Error(err)
 	}
 
 	id, err := s.facultyRepo.CreateFaculty(req)
@@ -38,7 +40,7 @@ func (s *FacultyService) RegisterFaculty(req domain.FacultyRegisterPayload) (int
 
 func (s *FacultyService) AuthenticateFaculty(req domain.FacultyLoginPayload) (string, error) {
 	if err := s.validate.Struct(req); err != nil {
-		return "", fmt.Errorf("validation error: %w", err)
+		return "", validation.Error(err)
 	}
 
 	token, err := s.facultyRepo.AuthenticateFaculty(req)
@@ -58,27 +60,17 @@ func (s *FacultyService) GetFacultyByID(facultyID int64) (domain.Faculty, error)
 }
 
 
-func (s *FacultyService) GetAllFaculty() ([]domain.Faculty, error) {
-	faculties, err := s.facultyRepo.GetAllFaculty()
-	if err != nil {
-		return nil, err
+func (s *FacultyService) GetAllFaculty(filter domain.FacultyFilter, page domain.PageRequest) ([]domain.Faculty, int, error) {
+	if filter.Department != "" {
+		filter.Department = domain.DepartmentCode(filter.Department)
 	}
-	return faculties, nil
-}
-
-func (s *FacultyService) GetFacultyByDepartment(department string) ([]domain.Faculty, error) {
-	faculties, err := s.facultyRepo.GetAllFaculty()
+	faculties, total, err := s.facultyRepo.GetAllFaculty(filter, page)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-
-	var filtered []domain.Faculty
-	for _, f := range faculties {
-		if f.Department == department {
-			filtered = append(filtered, f)
-		}
-	}
-
-	return filtered, nil
+	return faculties, total, nil
 }
 
+func (s *FacultyService) GetFacultyByDepartment(department string, page domain.PageRequest) ([]domain.Faculty, int, error) {
+	return s.GetAllFaculty(domain.FacultyFilter{Department: department}, page)
+}
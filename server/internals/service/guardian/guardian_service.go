@@ -0,0 +1,95 @@
+package guardian_service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+type GuardianService struct {
+	guardianRepo domain.GuardianRepo
+	validate     *validator.Validate
+}
+
+func NewGuardianService(guardianRepo domain.GuardianRepo) *GuardianService {
+	v := validation.New()
+	return &GuardianService{
+		guardianRepo: guardianRepo,
+		validate:     v,
+	}
+}
+
+func (s *GuardianService) RegisterGuardian(req domain.GuardianPayload) (int64, error) {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	for i, usn := range req.USNs {
+		req.USNs[i] = domain.NormalizeUSN(usn)
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return 0, validation.Error(err)
+	}
+
+	id, err := s.guardianRepo.CreateGuardian(req)
+	if err != nil {
+		return 0, fmt.Errorf("error while creating guardian: %w", err)
+	}
+	return id, nil
+}
+
+func (s *GuardianService) LinkWard(guardianID int64, req domain.GuardianLinkPayload) error {
+	req.USN = domain.NormalizeUSN(req.USN)
+	if err := s.validate.Struct(req); err != nil {
+		return validation.Error(err)
+	}
+	return s.guardianRepo.LinkGuardianStudent(guardianID, req.USN)
+}
+
+func (s *GuardianService) AuthenticateGuardian(req domain.GuardianLoginPayload) (string, error) {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if err := s.validate.Struct(req); err != nil {
+		return "", validation.Error(err)
+	}
+
+	token, err := s.guardianRepo.AuthenticateGuardian(req)
+	if err != nil {
+		return "", fmt.Errorf("authentication failed: %w", err)
+	}
+	return token, nil
+}
+
+func (s *GuardianService) GetWards(guardianID int64) ([]domain.Ward, error) {
+	wards, err := s.guardianRepo.GetGuardianWards(guardianID)
+	if err != nil {
+		return nil, err
+	}
+	if wards == nil {
+		wards = []domain.Ward{}
+	}
+	return wards, nil
+}
+
+// AuthorizeWard checks that usn is one of the guardian's wards before any of
+// the ward's attendance is read.
+func (s *GuardianService) AuthorizeWard(guardianID int64, usn string) error {
+	ok, err := s.guardianRepo.IsGuardianOf(guardianID, usn)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.Forbidden("student %s is not linked to this guardian", usn)
+	}
+	return nil
+}
+
+func (s *GuardianService) GetGuardiansByStudent(usn string) ([]domain.Guardian, error) {
+	guardians, err := s.guardianRepo.GetGuardiansByStudent(domain.NormalizeUSN(usn))
+	if err != nil {
+		return nil, err
+	}
+	if guardians == nil {
+		guardians = []domain.Guardian{}
+	}
+	return guardians, nil
+}
@@ -0,0 +1,267 @@
+package import_service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+// ErrInvalidRoster underlies the bad request returned when the upload itself
+// is unusable: unknown entity, unsupported file type or an unreadable file.
+var ErrInvalidRoster = errors.New("invalid roster")
+
+type ImportService struct {
+	importRepo domain.ImportRepo
+}
+
+func NewImportService(importRepo domain.ImportRepo) *ImportService {
+	return &ImportService{
+		importRepo: importRepo,
+	}
+}
+
+// Import validates every row of the roster and, unless dryRun is set, commits
+// the valid rows in one transaction. Row problems are reported in the result
+// rather than as an error; an error means the file itself could not be used.
+func (s *ImportService) Import(entity, filename string, file io.Reader, dryRun bool) (domain.ImportResult, error) {
+	result := domain.ImportResult{Entity: entity, DryRun: dryRun, Errors: []domain.ImportRowError{}}
+
+	rows, err := readRoster(filename, file)
+	if err != nil {
+		return result, domain.BadRequest("invalid roster: %v", err).Wrap(ErrInvalidRoster)
+	}
+	result.TotalRows = len(rows)
+
+	lookups, err := s.importRepo.GetImportLookups()
+	if err != nil {
+		return result, fmt.Errorf("error loading existing records: %w", err)
+	}
+
+	switch entity {
+	case domain.ImportEntityStudents:
+		students, errs := validateStudents(rows, lookups)
+		result.ValidRows, result.Errors = len(students), errs
+		if !dryRun && len(students) > 0 {
+			result.Imported, err = s.importRepo.ImportStudents(students)
+		}
+	case domain.ImportEntityFaculty:
+		faculty, errs := validateFaculty(rows, lookups)
+		result.ValidRows, result.Errors = len(faculty), errs
+		if !dryRun && len(faculty) > 0 {
+			result.Imported, err = s.importRepo.ImportFaculty(faculty)
+		}
+	case domain.ImportEntitySubjects:
+		subjects, errs := validateSubjects(rows, lookups)
+		result.ValidRows, result.Errors = len(subjects), errs
+		if !dryRun && len(subjects) > 0 {
+			result.Imported, err = s.importRepo.ImportSubjects(subjects)
+		}
+	default:
+		return result, domain.BadRequest("invalid roster: unknown import entity %q", entity).Wrap(ErrInvalidRoster)
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("error importing %s: %w", entity, err)
+	}
+	return result, nil
+}
+
+// rowChecker collects the errors of a single row.
+type rowChecker struct {
+	line int
+	errs []domain.ImportRowError
+}
+
+func (c *rowChecker) fail(field, format string, args ...any) {
+	c.errs = append(c.errs, domain.ImportRowError{Row: c.line, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *rowChecker) required(field, value string) {
+	if value == "" {
+		c.fail(field, "%s is required", field)
+	}
+}
+
+func (c *rowChecker) sem(value string) int {
+	if value == "" {
+		c.fail("sem", "sem is required")
+		return 0
+	}
+	sem, err := strconv.Atoi(value)
+	if err != nil || sem < 1 || sem > 8 {
+		c.fail("sem", "sem must be a number between 1 and 8, got %q", value)
+		return 0
+	}
+	return sem
+}
+
+// department checks that the department exists and, for a sem other than
+// 0, that it has that many semesters.
+func (c *rowChecker) department(value string, sem int, lookups domain.ImportLookups) {
+	if value == "" {
+		c.fail("department", "department is required")
+		return
+	}
+	semesters, ok := lookups.Departments[value]
+	switch {
+	case !ok:
+		c.fail("department", "unknown department %q", value)
+	case sem > semesters:
+		c.fail("sem", "sem must be at most %d in department %s", semesters, value)
+	}
+}
+
+// password checks an optional password against the strength rule.
+func (c *rowChecker) password(value string) {
+	if value != "" && !validation.StrongPassword(value) {
+		c.fail("password", "password must be at least %d characters and contain a letter and a digit", validation.MinPasswordLength)
+	}
+}
+
+func validateStudents(rows []rosterRow, lookups domain.ImportLookups) ([]domain.StudentRegisterPayload, []domain.ImportRowError) {
+	var valid []domain.StudentRegisterPayload
+	errs := []domain.ImportRowError{}
+	seen := map[string]int{}
+
+	for _, row := range rows {
+		c := rowChecker{line: row.line}
+		student := domain.StudentRegisterPayload{
+			USN:        domain.NormalizeUSN(row.get("usn")),
+			Username:   row.get("username", "name", "student_name"),
+			Password:   row.get("password"),
+			Department: domain.DepartmentCode(row.get("department", "dept")),
+			Email:      strings.ToLower(row.get("email")),
+		}
+
+		c.required("usn", student.USN)
+		if student.USN != "" {
+			if !validation.ValidUSN(student.USN) {
+				c.fail("usn", "usn %q is not a valid USN", student.USN)
+			} else if first, dup := seen[student.USN]; dup {
+				c.fail("usn", "duplicate usn %s, first seen on row %d", student.USN, first)
+			} else {
+				seen[student.USN] = row.line
+				if lookups.USNs[student.USN] {
+					c.fail("usn", "usn %s is already registered", student.USN)
+				}
+			}
+		}
+		c.required("username", student.Username)
+		c.password(student.Password)
+		if student.Email != "" {
+			if _, err := mail.ParseAddress(student.Email); err != nil {
+				c.fail("email", "invalid email %q", student.Email)
+			}
+		}
+		student.Sem = c.sem(row.get("sem", "semester"))
+		c.department(student.Department, student.Sem, lookups)
+
+		if len(c.errs) > 0 {
+			errs = append(errs, c.errs...)
+			continue
+		}
+		valid = append(valid, student)
+	}
+	return valid, errs
+}
+
+func validateFaculty(rows []rosterRow, lookups domain.ImportLookups) ([]domain.FacultyRegisterPayload, []domain.ImportRowError) {
+	var valid []domain.FacultyRegisterPayload
+	errs := []domain.ImportRowError{}
+	seen := map[string]int{}
+
+	for _, row := range rows {
+		c := rowChecker{line: row.line}
+		faculty := domain.FacultyRegisterPayload{
+			Name:       row.get("name", "faculty_name"),
+			Email:      strings.ToLower(row.get("email")),
+			Password:   row.get("password"),
+			Department: domain.DepartmentCode(row.get("department", "dept")),
+		}
+
+		c.required("name", faculty.Name)
+		c.required("email", faculty.Email)
+		if faculty.Email != "" {
+			if _, err := mail.ParseAddress(faculty.Email); err != nil {
+				c.fail("email", "invalid email %q", faculty.Email)
+			} else if first, dup := seen[faculty.Email]; dup {
+				c.fail("email", "duplicate email %s, first seen on row %d", faculty.Email, first)
+			} else {
+				seen[faculty.Email] = row.line
+				if lookups.FacultyEmails[faculty.Email] {
+					c.fail("email", "email %s is already registered", faculty.Email)
+				}
+			}
+		}
+		c.required("password", faculty.Password)
+		c.password(faculty.Password)
+		c.department(faculty.Department, 0, lookups)
+
+		if len(c.errs) > 0 {
+			errs = append(errs, c.errs...)
+			continue
+		}
+		valid = append(valid, faculty)
+	}
+	return valid, errs
+}
+
+func validateSubjects(rows []rosterRow, lookups domain.ImportLookups) ([]domain.SubjectPayload, []domain.ImportRowError) {
+	var valid []domain.SubjectPayload
+	errs := []domain.ImportRowError{}
+	seen := map[string]int{}
+
+	for _, row := range rows {
+		c := rowChecker{line: row.line}
+		subject := domain.SubjectPayload{
+			Code:       strings.ToUpper(row.get("subject_code", "code")),
+			Name:       row.get("subject_name", "name"),
+			Department: domain.DepartmentCode(row.get("department", "dept")),
+		}
+
+		c.required("subject_code", subject.Code)
+		if subject.Code != "" {
+			if !validation.ValidSubjectCode(subject.Code) {
+				c.fail("subject_code", "subject code %q is not valid", subject.Code)
+			} else if first, dup := seen[subject.Code]; dup {
+				c.fail("subject_code", "duplicate subject code %s, first seen on row %d", subject.Code, first)
+			} else {
+				seen[subject.Code] = row.line
+				if lookups.SubjectCodes[subject.Code] {
+					c.fail("subject_code", "subject code %s already exists", subject.Code)
+				}
+			}
+		}
+		c.required("subject_name", subject.Name)
+
+		if v := row.get("faculty_id"); v == "" {
+			c.fail("faculty_id", "faculty_id is required")
+		} else if id, err := strconv.ParseInt(v, 10, 64); err != nil || !lookups.FacultyIDs[id] {
+			c.fail("faculty_id", "unknown faculty_id %q", v)
+		} else {
+			subject.FacultyID = id
+		}
+		subject.Sem = c.sem(row.get("sem", "semester"))
+		c.department(subject.Department, subject.Sem, lookups)
+		if v := row.get("planned_classes"); v != "" {
+			planned, err := strconv.Atoi(v)
+			if err != nil || planned < 0 {
+				c.fail("planned_classes", "planned_classes must be a non-negative number, got %q", v)
+			}
+			subject.PlannedClasses = planned
+		}
+
+		if len(c.errs) > 0 {
+			errs = append(errs, c.errs...)
+			continue
+		}
+		valid = append(valid, subject)
+	}
+	return valid, errs
+}
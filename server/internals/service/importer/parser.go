@@ -0,0 +1,95 @@
+package import_service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// rosterRow is one data row of an uploaded roster keyed by normalized header.
+type rosterRow struct {
+	line   int
+	fields map[string]string
+}
+
+func (r rosterRow) get(keys ...string) string {
+	for _, k := range keys {
+		if v, ok := r.fields[k]; ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// readRoster parses a CSV or XLSX upload (first sheet) into rows. The first
+// row must be a header; blank rows are skipped.
+func readRoster(filename string, r io.Reader) ([]rosterRow, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err = reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+	case ".xlsx":
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("open xlsx: %w", err)
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("xlsx has no sheets")
+		}
+		records, err = f.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("read xlsx rows: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file type %q, expected .csv or .xlsx", filepath.Ext(filename))
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	header := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = normalizeHeader(h)
+	}
+
+	var rows []rosterRow
+	for i, rec := range records[1:] {
+		row := rosterRow{line: i + 2, fields: map[string]string{}}
+		blank := true
+		for j, v := range rec {
+			if j >= len(header) || header[j] == "" {
+				continue
+			}
+			v = strings.TrimSpace(v)
+			if v != "" {
+				blank = false
+			}
+			row.fields[header[j]] = v
+		}
+		if !blank {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(h)
+}
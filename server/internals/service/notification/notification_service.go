@@ -0,0 +1,468 @@
+package notification_service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/notify"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Jobs that can be run on demand through RunJob.
+const (
+	JobLowAttendance   = "low-attendance"
+	JobWeeklyDigest    = "weekly-digest"
+	JobAbsenteeSummary = "absentee-summary"
+)
+
+// minClassesForAlert keeps a single early absence from triggering a
+// low-attendance alert.
+const minClassesForAlert = 5
+
+// lowAttendanceRepeat is how long a low-attendance alert for a subject is
+// suppressed after it was sent.
+const lowAttendanceRepeat = 7 * 24 * time.Hour
+
+type Config struct {
+	// Threshold is the eligibility percentage below which students are alerted.
+	Threshold float64
+	// Calendar decides what "today" and "this week" mean.
+	Calendar *calendar.Calendar
+	// DailyHour is the local hour after which the daily summary and the
+	// weekly digest go out.
+	DailyHour int
+	// DigestDay is the weekday the weekly digest is sent on.
+	DigestDay time.Weekday
+	// Interval is how often Run checks for due work.
+	Interval time.Duration
+}
+
+type NotificationService struct {
+	notificationRepo domain.NotificationRepo
+	attendanceRepo   domain.AttendanceRepository
+	channel          notify.Channel
+	cfg              Config
+	templates        map[string]*template.Template
+	validate         *validator.Validate
+}
+
+// NewNotificationService builds the service. A nil channel disables sending;
+// preferences and advisors can still be managed.
+func NewNotificationService(notificationRepo domain.NotificationRepo, attendanceRepo domain.AttendanceRepository, channel notify.Channel, cfg Config) (*NotificationService, error) {
+	if cfg.Calendar == nil {
+		cfg.Calendar = calendar.New(time.Local)
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Hour
+	}
+
+	// Templates are named after the kind, optionally suffixed with a
+	// recipient type when that audience needs different wording.
+	files, err := fs.Glob(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	templates := map[string]*template.Template{}
+	for _, file := range files {
+		t, err := template.ParseFS(templateFS, file)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", file, err)
+		}
+		templates[strings.TrimSuffix(path.Base(file), ".tmpl")] = t
+	}
+
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		attendanceRepo:   attendanceRepo,
+		channel:          channel,
+		cfg:              cfg,
+		templates:        templates,
+		validate:         validation.New(),
+	}, nil
+}
+
+// Run checks for due notifications every Interval until ctx is cancelled.
+func (s *NotificationService) Run(ctx context.Context) {
+	if s.channel == nil {
+		log.Println("notifications: no channel configured, scheduler disabled")
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		s.runDue(s.cfg.Calendar.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDue runs every job whose time has come. The notification log makes
+// repeated runs within the same day or week harmless.
+func (s *NotificationService) runDue(now time.Time) {
+	local := now.In(s.cfg.Calendar.Location())
+	jobs := []string{JobLowAttendance}
+	if local.Hour() >= s.cfg.DailyHour {
+		jobs = append(jobs, JobAbsenteeSummary)
+		if local.Weekday() == s.cfg.DigestDay {
+			jobs = append(jobs, JobWeeklyDigest)
+		}
+	}
+
+	for _, job := range jobs {
+		run, err := s.RunJob(job, now)
+		if err != nil {
+			log.Printf("notifications: %s: %v", job, err)
+			continue
+		}
+		if run.Sent > 0 || run.Failed > 0 {
+			log.Printf("notifications: %s sent=%d failed=%d skipped=%d", job, run.Sent, run.Failed, run.Skipped)
+		}
+	}
+}
+
+// RunJob runs one notification job immediately.
+func (s *NotificationService) RunJob(job string, now time.Time) (domain.NotificationRun, error) {
+	if s.channel == nil {
+		return domain.NotificationRun{}, fmt.Errorf("notifications are not configured")
+	}
+
+	switch job {
+	case JobLowAttendance:
+		return s.sendLowAttendanceAlerts(now)
+	case JobWeeklyDigest:
+		return s.sendWeeklyDigests(now)
+	case JobAbsenteeSummary:
+		return s.sendAbsenteeSummaries(now)
+	}
+	return domain.NotificationRun{}, domain.Invalid("job", "oneof", "unknown notification job %q", job)
+}
+
+func (s *NotificationService) sendLowAttendanceAlerts(now time.Time) (domain.NotificationRun, error) {
+	run := domain.NotificationRun{Job: JobLowAttendance}
+
+	students, err := s.notificationRepo.GetStudentContacts()
+	if err != nil {
+		return run, err
+	}
+	optedOut, err := s.notificationRepo.GetOptedOut(domain.RecipientStudent, domain.NotificationLowAttendance)
+	if err != nil {
+		return run, err
+	}
+	guardians, err := s.notificationRepo.GetGuardianContacts()
+	if err != nil {
+		return run, err
+	}
+	guardiansOptedOut, err := s.notificationRepo.GetOptedOut(domain.RecipientGuardian, domain.NotificationLowAttendance)
+	if err != nil {
+		return run, err
+	}
+
+	for _, st := range students {
+		notifyStudent := !optedOut[st.USN]
+		var notifyGuardians []domain.GuardianContact
+		for _, g := range guardians[st.USN] {
+			if !guardiansOptedOut[strconv.FormatInt(g.GuardianID, 10)] {
+				notifyGuardians = append(notifyGuardians, g)
+			}
+		}
+		if !notifyStudent && len(notifyGuardians) == 0 {
+			run.Skipped++
+			continue
+		}
+
+		summaries, err := s.attendanceRepo.GetAttendanceSummaryByStudent(st.USN, domain.AttendanceFilter{})
+		if err != nil {
+			return run, fmt.Errorf("summary for %s: %w", st.USN, err)
+		}
+
+		for _, sum := range summaries {
+			if sum.TotalClasses < minClassesForAlert || sum.Percentage >= s.cfg.Threshold {
+				continue
+			}
+
+			since := now.Add(-lowAttendanceRepeat)
+			if notifyStudent {
+				key := "subject:" + strconv.FormatInt(sum.SubjectID, 10)
+				sent, err := s.notificationRepo.WasNotified(domain.NotificationLowAttendance, domain.RecipientStudent, st.USN, key, since)
+				if err != nil {
+					return run, err
+				}
+				if sent {
+					run.Skipped++
+				} else {
+					data := struct {
+						Name string
+						domain.SubjectSummary
+						Threshold float64
+					}{st.Name, sum, s.cfg.Threshold}
+					s.deliver(&run, domain.NotificationLowAttendance, domain.RecipientStudent, st.USN, key, st.Name, st.Email, data)
+				}
+			}
+
+			// A guardian may have several wards, so the key names the ward too.
+			key := st.USN + ":subject:" + strconv.FormatInt(sum.SubjectID, 10)
+			for _, g := range notifyGuardians {
+				recipientID := strconv.FormatInt(g.GuardianID, 10)
+				sent, err := s.notificationRepo.WasNotified(domain.NotificationLowAttendance, domain.RecipientGuardian, recipientID, key, since)
+				if err != nil {
+					return run, err
+				}
+				if sent {
+					run.Skipped++
+					continue
+				}
+				data := struct {
+					Name        string
+					StudentName string
+					USN         string
+					domain.SubjectSummary
+					Threshold float64
+				}{g.Name, st.Name, st.USN, sum, s.cfg.Threshold}
+				s.deliver(&run, domain.NotificationLowAttendance, domain.RecipientGuardian, recipientID, key, g.Name, g.Email, data)
+			}
+		}
+	}
+	return run, nil
+}
+
+func (s *NotificationService) sendWeeklyDigests(now time.Time) (domain.NotificationRun, error) {
+	run := domain.NotificationRun{Job: JobWeeklyDigest}
+	local := now.In(s.cfg.Calendar.Location())
+	year, week := local.ISOWeek()
+	key := fmt.Sprintf("%d-W%02d", year, week)
+
+	students, err := s.notificationRepo.GetStudentContacts()
+	if err != nil {
+		return run, err
+	}
+	optedOut, err := s.notificationRepo.GetOptedOut(domain.RecipientStudent, domain.NotificationWeeklyDigest)
+	if err != nil {
+		return run, err
+	}
+
+	for _, st := range students {
+		if optedOut[st.USN] {
+			run.Skipped++
+			continue
+		}
+		sent, err := s.notificationRepo.WasNotified(domain.NotificationWeeklyDigest, domain.RecipientStudent, st.USN, key, time.Time{})
+		if err != nil {
+			return run, err
+		}
+		if sent {
+			run.Skipped++
+			continue
+		}
+
+		summaries, err := s.attendanceRepo.GetAttendanceSummaryByStudent(st.USN, domain.AttendanceFilter{})
+		if err != nil {
+			return run, fmt.Errorf("summary for %s: %w", st.USN, err)
+		}
+		if !anyClassesHeld(summaries) {
+			run.Skipped++
+			continue
+		}
+
+		data := struct {
+			Name      string
+			WeekOf    string
+			Subjects  []domain.SubjectSummary
+			Threshold float64
+		}{st.Name, local.Format("2006-01-02"), summaries, s.cfg.Threshold}
+		s.deliver(&run, domain.NotificationWeeklyDigest, domain.RecipientStudent, st.USN, key, st.Name, st.Email, data)
+	}
+	return run, nil
+}
+
+// anyClassesHeld reports whether a digest has something to say: every
+// enrolled subject is summarised, including ones not yet taught.
+func anyClassesHeld(summaries []domain.SubjectSummary) bool {
+	for _, sum := range summaries {
+		if sum.TotalClasses > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *NotificationService) sendAbsenteeSummaries(now time.Time) (domain.NotificationRun, error) {
+	run := domain.NotificationRun{Job: JobAbsenteeSummary}
+	day := s.cfg.Calendar.DateOf(now)
+
+	advisors, err := s.notificationRepo.GetClassAdvisors()
+	if err != nil {
+		return run, err
+	}
+	optedOut, err := s.notificationRepo.GetOptedOut(domain.RecipientFaculty, domain.NotificationAbsenteeSummary)
+	if err != nil {
+		return run, err
+	}
+
+	for _, adv := range advisors {
+		recipientID := strconv.FormatInt(adv.FacultyID, 10)
+		if optedOut[recipientID] {
+			run.Skipped++
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%s:%d", day.Format("2006-01-02"), adv.Department, adv.Sem)
+		sent, err := s.notificationRepo.WasNotified(domain.NotificationAbsenteeSummary, domain.RecipientFaculty, recipientID, key, time.Time{})
+		if err != nil {
+			return run, err
+		}
+		if sent {
+			run.Skipped++
+			continue
+		}
+
+		absentees, err := s.notificationRepo.GetAbsentees(adv.Department, adv.Sem, day)
+		if err != nil {
+			return run, err
+		}
+		if len(absentees) == 0 {
+			run.Skipped++
+			continue
+		}
+
+		data := struct {
+			Name       string
+			Department string
+			Sem        int
+			Date       string
+			Absentees  []domain.Absentee
+		}{adv.Name, adv.Department, adv.Sem, day.Format("2006-01-02"), absentees}
+		s.deliver(&run, domain.NotificationAbsenteeSummary, domain.RecipientFaculty, recipientID, key, adv.Name, adv.Email, data)
+	}
+	return run, nil
+}
+
+// deliver renders, sends and logs one notification, counting the outcome in run.
+func (s *NotificationService) deliver(run *domain.NotificationRun, kind, recipientType, recipientID, key, name, email string, data any) {
+	// Without an address the send can never succeed; logging it as failed
+	// would only have every tick retry it.
+	if email == "" {
+		run.Skipped++
+		return
+	}
+
+	entry := domain.NotificationLogEntry{
+		Kind:          kind,
+		RecipientType: recipientType,
+		RecipientID:   recipientID,
+		DedupeKey:     key,
+		Channel:       s.channel.Name(),
+		Status:        "sent",
+		SentAt:        time.Now(),
+	}
+
+	msg, err := s.render(kind, recipientType, data)
+	if err == nil {
+		msg.To, msg.ToName = email, name
+		err = s.channel.Send(msg)
+	}
+	if err != nil {
+		entry.Status, entry.Error = "failed", err.Error()
+		run.Failed++
+	} else {
+		run.Sent++
+	}
+
+	if err := s.notificationRepo.LogNotification(entry); err != nil {
+		log.Printf("notifications: %v", err)
+	}
+}
+
+func (s *NotificationService) render(kind, recipientType string, data any) (notify.Message, error) {
+	t, ok := s.templates[kind+"_"+recipientType]
+	if !ok {
+		t, ok = s.templates[kind]
+	}
+	if !ok {
+		return notify.Message{}, fmt.Errorf("no template for %s", kind)
+	}
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return notify.Message{}, fmt.Errorf("render %s subject: %w", kind, err)
+	}
+	if err := t.ExecuteTemplate(&body, "body", data); err != nil {
+		return notify.Message{}, fmt.Errorf("render %s body: %w", kind, err)
+	}
+	return notify.Message{Subject: subject.String(), Body: body.String()}, nil
+}
+
+// GetPreferences returns every kind the recipient can receive; kinds without
+// a stored preference are enabled.
+func (s *NotificationService) GetPreferences(recipientType, recipientID string) ([]domain.NotificationPreference, error) {
+	stored, err := s.notificationRepo.GetNotificationPreferences(recipientType, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := map[string]bool{}
+	for _, p := range stored {
+		enabled[p.Kind] = p.Enabled
+	}
+
+	kinds := domain.NotificationKindsFor(recipientType)
+	prefs := make([]domain.NotificationPreference, 0, len(kinds))
+	for _, kind := range kinds {
+		on, ok := enabled[kind]
+		prefs = append(prefs, domain.NotificationPreference{Kind: kind, Enabled: !ok || on})
+	}
+	return prefs, nil
+}
+
+func (s *NotificationService) SetPreference(recipientType, recipientID string, req domain.NotificationPreferencePayload) error {
+	if err := s.validate.Struct(req); err != nil {
+		return validation.Error(err)
+	}
+
+	allowed := false
+	for _, kind := range domain.NotificationKindsFor(recipientType) {
+		if kind == req.Kind {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return domain.Invalid("kind", "oneof", "unknown notification kind %q", req.Kind)
+	}
+
+	return s.notificationRepo.SetNotificationPreference(recipientType, recipientID, req.Kind, *req.Enabled)
+}
+
+func (s *NotificationService) SetClassAdvisor(req domain.ClassAdvisorPayload) error {
+	req.Department = domain.DepartmentCode(req.Department)
+	if err := s.validate.Struct(req); err != nil {
+		return validation.Error(err)
+	}
+	return s.notificationRepo.SetClassAdvisor(req)
+}
+
+func (s *NotificationService) GetClassAdvisors() ([]domain.ClassAdvisor, error) {
+	advisors, err := s.notificationRepo.GetClassAdvisors()
+	if err != nil {
+		return nil, err
+	}
+	if advisors == nil {
+		advisors = []domain.ClassAdvisor{}
+	}
+	return advisors, nil
+}
@@ -0,0 +1,140 @@
+package notification_service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/notify"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	notification_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/notification"
+)
+
+var firstDay = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+// outbox records every message instead of sending it.
+type outbox struct {
+	sent []notify.Message
+}
+
+func (o *outbox) Name() string { return "test" }
+
+func (o *outbox) Send(msg notify.Message) error {
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+// seed runs five CS501 classes that Alice and Bob both miss; only Alice has
+// an email address.
+func seed(t *testing.T) *memory.MemoryRepo {
+	t.Helper()
+	b := memorytest.New(t)
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: b.Faculty("Ravi", "CSE")})
+	b.Students(
+		domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice", Email: "alice@college.edu"},
+		domain.StudentRegisterPayload{USN: "1RV21CS002", Username: "Bob"},
+	)
+	for i := 0; i < 5; i++ {
+		b.Class("CS501", firstDay.AddDate(0, 0, i), map[string]string{"1RV21CS001": "Absent", "1RV21CS002": "Absent"})
+	}
+	return b.Repo
+}
+
+// A student without an email is skipped, never logged as failed, so later
+// ticks do not keep retrying them.
+func TestStudentsWithoutEmailAreSkipped(t *testing.T) {
+	repo := seed(t)
+	out := &outbox{}
+	svc, err := notification_service.NewNotificationService(repo, repo, out, notification_service.Config{Threshold: 75})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := firstDay.AddDate(0, 0, 7)
+	for _, job := range []string{notification_service.JobLowAttendance, notification_service.JobWeeklyDigest} {
+		for attempt := 0; attempt < 2; attempt++ {
+			run, err := svc.RunJob(job, now)
+			if err != nil {
+				t.Fatalf("%s: %v", job, err)
+			}
+			if run.Failed != 0 {
+				t.Errorf("%s run %d failed %d sends", job, attempt, run.Failed)
+			}
+			wantSent := 1
+			if attempt > 0 {
+				wantSent = 0
+			}
+			if run.Sent != wantSent {
+				t.Errorf("%s run %d sent %d, want %d", job, attempt, run.Sent, wantSent)
+			}
+		}
+	}
+
+	for _, msg := range out.sent {
+		if msg.To != "alice@college.edu" {
+			t.Errorf("sent to %q", msg.To)
+		}
+	}
+}
+
+// A ward without an email still gets their guardian alerted.
+func TestGuardianAlertedForWardWithoutEmail(t *testing.T) {
+	repo := seed(t)
+	out := &outbox{}
+	svc, err := notification_service.NewNotificationService(repo, repo, out, notification_service.Config{Threshold: 75})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateGuardian(domain.GuardianPayload{
+		Name: "Bob's mother", Relation: "mother", Phone: "9876543210", Email: "parent@example.com",
+		Password: "secret123", USNs: []string{"1RV21CS002"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := svc.RunJob(notification_service.JobLowAttendance, firstDay.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Sent != 2 || run.Failed != 0 {
+		t.Fatalf("run = %+v, want Alice and Bob's guardian", run)
+	}
+	if out.sent[1].To != "parent@example.com" {
+		t.Errorf("second alert went to %q", out.sent[1].To)
+	}
+}
+
+// A student the camera never saw has no attendance rows at all, yet missed
+// every logged session and must be alerted like one marked absent.
+func TestNeverCapturedStudentIsAlerted(t *testing.T) {
+	b := memorytest.New(t)
+	ravi := b.Faculty("Ravi", "CSE")
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi})
+	b.Students(
+		domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice", Email: "alice@college.edu"},
+		domain.StudentRegisterPayload{USN: "1RV21CS003", Username: "Carol", Email: "carol@college.edu"},
+	)
+	for i := 0; i < 5; i++ {
+		date := firstDay.AddDate(0, 0, i)
+		b.Class("CS501", date, map[string]string{"1RV21CS001": "Present"})
+		if _, err := b.Repo.LogTeachingSession(ravi, domain.TeachingSession{
+			SubjectCode: "CS501", Date: date, StartTime: "08:00", DurationMinutes: 60, Topic: "Parsing",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := &outbox{}
+	svc, err := notification_service.NewNotificationService(b.Repo, b.Repo, out, notification_service.Config{Threshold: 75})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := svc.RunJob(notification_service.JobLowAttendance, firstDay.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Sent != 1 || len(out.sent) != 1 || out.sent[0].To != "carol@college.edu" {
+		t.Fatalf("run = %+v, sent = %v, want one alert to Carol", run, out.sent)
+	}
+}
@@ -0,0 +1,137 @@
+package report_service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+type ReportService struct {
+	defaulterRepo    domain.DefaulterRepo
+	departmentRepo   domain.DepartmentRepo
+	termRepo         domain.TermRepo
+	validate         *validator.Validate
+	defaultThreshold float64
+}
+
+// NewReportService builds the report service; defaultThreshold is the
+// eligibility percentage used when a request does not name one.
+func NewReportService(defaulterRepo domain.DefaulterRepo, departmentRepo domain.DepartmentRepo, termRepo domain.TermRepo, defaultThreshold float64) *ReportService {
+	v := validation.New()
+	return &ReportService{
+		defaulterRepo:    defaulterRepo,
+		departmentRepo:   departmentRepo,
+		termRepo:         termRepo,
+		validate:         v,
+		defaultThreshold: defaultThreshold,
+	}
+}
+
+func (s *ReportService) GetDefaulterReport(query domain.DefaulterQuery) (domain.DefaulterReport, error) {
+	if query.Threshold == 0 {
+		query.Threshold = s.defaultThreshold
+	}
+	if query.Threshold <= 0 || query.Threshold > 100 {
+		return domain.DefaulterReport{}, domain.Invalid("threshold", "range", "threshold must be between 0 and 100")
+	}
+	if err := s.checkSem(query.Department, query.Sem); err != nil {
+		return domain.DefaulterReport{}, err
+	}
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, query.Filter)
+	if err != nil {
+		return domain.DefaulterReport{}, err
+	}
+	query.Filter = filter
+
+	defaulters, err := s.defaulterRepo.GetDefaulters(query)
+	if err != nil {
+		return domain.DefaulterReport{}, fmt.Errorf("error fetching defaulters: %w", err)
+	}
+
+	for i := range defaulters {
+		fillShortage(&defaulters[i], query.Threshold)
+	}
+
+	if defaulters == nil {
+		defaulters = []domain.Defaulter{}
+	}
+	return domain.DefaulterReport{Threshold: query.Threshold, Defaulters: defaulters}, nil
+}
+
+// checkSem checks that sem, when given, is one of the department's
+// semesters, or of the longest programme when no department is named.
+func (s *ReportService) checkSem(department string, sem int) error {
+	if sem == 0 {
+		return nil
+	}
+	limit := 0
+	if department != "" {
+		d, err := s.departmentRepo.GetDepartment(domain.DepartmentCode(department))
+		if err != nil {
+			return err
+		}
+		limit = d.Semesters
+	} else {
+		departments, err := s.departmentRepo.GetDepartments()
+		if err != nil {
+			return fmt.Errorf("error fetching departments: %w", err)
+		}
+		for _, d := range departments {
+			limit = max(limit, d.Semesters)
+		}
+	}
+	if sem < 1 || sem > limit {
+		return domain.Invalid("sem", "range", "sem must be between 1 and %d", limit)
+	}
+	return nil
+}
+
+// fillShortage works out how many consecutive classes the student must attend
+// to reach threshold, and whether the remaining planned classes allow it.
+func fillShortage(d *domain.Defaulter, threshold float64) {
+	if d.TotalClasses > 0 {
+		d.Percentage = math.Round(10000*float64(d.Attended)/float64(d.TotalClasses)) / 100
+	}
+
+	p := threshold / 100
+	if p >= 1 {
+		// Full attendance can never be recovered once a class was missed.
+		d.ClassesNeeded = -1
+	} else {
+		// Smallest x with (attended + x) / (total + x) >= p.
+		needed := (p*float64(d.TotalClasses) - float64(d.Attended)) / (1 - p)
+		d.ClassesNeeded = int(math.Max(0, math.Ceil(needed-1e-9)))
+	}
+
+	if d.PlannedClasses > 0 {
+		remaining := max(d.PlannedClasses-d.ClassesHeld, 0)
+		reachable := d.ClassesNeeded >= 0 && d.ClassesNeeded <= remaining
+		d.RemainingClasses = &remaining
+		d.CanReachThreshold = &reachable
+	}
+}
+
+func (s *ReportService) SetCondonation(adminID int64, req domain.CondonationPayload) error {
+	req.USN = domain.NormalizeUSN(req.USN)
+	if err := s.validate.Struct(req); err != nil {
+		return validation.Error(err)
+	}
+	if err := s.defaulterRepo.SetCondonation(adminID, req); err != nil {
+		return fmt.Errorf("error setting condonation: %w", err)
+	}
+	return nil
+}
+
+func (s *ReportService) RemoveCondonation(usn, subjectCode string) error {
+	usn = domain.NormalizeUSN(usn)
+	if usn == "" || subjectCode == "" {
+		return domain.Invalid("usn", "required", "usn and subjectCode are required")
+	}
+	if err := s.defaulterRepo.RemoveCondonation(usn, subjectCode); err != nil {
+		return fmt.Errorf("error removing condonation: %w", err)
+	}
+	return nil
+}
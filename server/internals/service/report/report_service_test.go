@@ -0,0 +1,167 @@
+package report_service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	report_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/report"
+)
+
+var firstDay = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+// seed runs five CS501 classes: Alice attends the first two, Bob all five.
+func seed(t *testing.T) *memory.MemoryRepo {
+	t.Helper()
+	b := memorytest.New(t)
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: b.Faculty("Ravi", "CSE"), PlannedClasses: 10})
+	b.Students(
+		domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"},
+		domain.StudentRegisterPayload{USN: "1RV21CS002", Username: "Bob"},
+	)
+	for i := 0; i < 5; i++ {
+		alice := "Present"
+		if i >= 2 {
+			alice = "Absent"
+		}
+		b.Class("CS501", firstDay.AddDate(0, 0, i), map[string]string{"1RV21CS001": alice, "1RV21CS002": "Present"})
+	}
+	return b.Repo
+}
+
+func TestDefaulterReport(t *testing.T) {
+	repo := seed(t)
+	svc := report_service.NewReportService(repo, repo, repo, 75)
+
+	report, err := svc.GetDefaulterReport(domain.DefaulterQuery{Department: "CSE"})
+	if err != nil {
+		t.Fatalf("GetDefaulterReport: %v", err)
+	}
+	if report.Threshold != 75 {
+		t.Fatalf("threshold = %v, want the default 75", report.Threshold)
+	}
+	if len(report.Defaulters) != 1 {
+		t.Fatalf("defaulters = %+v, want only Alice", report.Defaulters)
+	}
+
+	d := report.Defaulters[0]
+	if d.USN != "1RV21CS001" || d.Attended != 2 || d.TotalClasses != 5 || d.Percentage != 40 {
+		t.Fatalf("defaulter = %+v", d)
+	}
+	if d.ClassesHeld != 5 || d.ClassesNeeded != 7 {
+		t.Fatalf("held = %d, needed = %d; want 5, 7", d.ClassesHeld, d.ClassesNeeded)
+	}
+	if d.RemainingClasses == nil || *d.RemainingClasses != 5 || d.CanReachThreshold == nil || *d.CanReachThreshold {
+		t.Fatalf("remaining = %v, reachable = %v; want 5, false", d.RemainingClasses, d.CanReachThreshold)
+	}
+
+	// Alice attended every class in the first two days.
+	report, err = svc.GetDefaulterReport(domain.DefaulterQuery{Filter: domain.AttendanceFilter{To: firstDay.AddDate(0, 0, 1)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Defaulters) != 0 {
+		t.Fatalf("defaulters in the first two days = %+v", report.Defaulters)
+	}
+
+	if _, err := svc.GetDefaulterReport(domain.DefaulterQuery{Threshold: 120}); err == nil {
+		t.Fatal("threshold above 100 accepted")
+	}
+
+	// Semesters come from the departments, not a fixed range.
+	if err := repo.UpdateDepartment("CSE", domain.DepartmentUpdatePayload{Name: "CSE", Semesters: 6}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.GetDefaulterReport(domain.DefaulterQuery{Department: "cse", Sem: 6}); err != nil {
+		t.Errorf("last semester of CSE: %v", err)
+	}
+	for _, q := range []domain.DefaulterQuery{
+		{Department: "CSE", Sem: 7},
+		{Department: "CSE", Sem: -1},
+		{Sem: 9},
+	} {
+		if _, err := svc.GetDefaulterReport(q); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("sem %d of %q: %v, want a validation error", q.Sem, q.Department, err)
+		}
+	}
+	if _, err := svc.GetDefaulterReport(domain.DefaulterQuery{Sem: 8}); err != nil {
+		t.Errorf("sem 8 of the other departments: %v", err)
+	}
+	if _, err := svc.GetDefaulterReport(domain.DefaulterQuery{Department: "XYZ", Sem: 1}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("unknown department: %v, want not found", err)
+	}
+}
+
+// A student never marked in any class is the worst defaulter, not absent
+// from the report.
+func TestDefaultersIncludeUnmarkedStudents(t *testing.T) {
+	repo := seed(t)
+	svc := report_service.NewReportService(repo, repo, repo, 75)
+	if _, err := repo.ImportStudents([]domain.StudentRegisterPayload{
+		{USN: "1RV21CS003", Username: "Chitra", Department: "CSE", Sem: 5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := svc.GetDefaulterReport(domain.DefaulterQuery{SubjectCode: "CS501"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Defaulters) != 2 {
+		t.Fatalf("defaulters = %+v, want Alice and Chitra", report.Defaulters)
+	}
+	d := report.Defaulters[1]
+	if d.USN != "1RV21CS003" || d.Attended != 0 || d.TotalClasses != 5 || d.Percentage != 0 {
+		t.Fatalf("unmarked student = %+v, want 0 of 5", d)
+	}
+
+	// Faculty see only the subjects they teach.
+	report, err = svc.GetDefaulterReport(domain.DefaulterQuery{FacultyID: 99})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Defaulters) != 0 {
+		t.Fatalf("defaulters of another faculty = %+v", report.Defaulters)
+	}
+}
+
+func TestCondonation(t *testing.T) {
+	repo := seed(t)
+	svc := report_service.NewReportService(repo, repo, repo, 75)
+
+	if err := svc.SetCondonation(1, domain.CondonationPayload{USN: "1RV21CS001", SubjectCode: "CS999", Reason: "medical"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("condonation for an unknown subject: %v, want not found", err)
+	}
+	if err := svc.SetCondonation(1, domain.CondonationPayload{USN: "1RV21CS999", SubjectCode: "CS501", Reason: "medical"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("condonation for an unknown student: %v, want not found", err)
+	}
+	if _, err := repo.ImportStudents([]domain.StudentRegisterPayload{
+		{USN: "1RV21CS101", Username: "Deepa", Department: "CSE", Sem: 3},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetCondonation(1, domain.CondonationPayload{USN: "1RV21CS101", SubjectCode: "CS501", Reason: "medical"}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("condonation for a student not enrolled: %v, want a validation error", err)
+	}
+	if err := svc.SetCondonation(1, domain.CondonationPayload{USN: "1RV21CS001", SubjectCode: "CS501", Reason: "medical"}); err != nil {
+		t.Fatalf("SetCondonation: %v", err)
+	}
+
+	report, err := svc.GetDefaulterReport(domain.DefaulterQuery{SubjectCode: "CS501"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Defaulters) != 1 || !report.Defaulters[0].Condoned || report.Defaulters[0].CondonationReason != "medical" {
+		t.Fatalf("defaulters = %+v, want Alice condoned", report.Defaulters)
+	}
+
+	if err := svc.RemoveCondonation("1RV21CS001", "CS501"); err != nil {
+		t.Fatalf("RemoveCondonation: %v", err)
+	}
+	if err := svc.RemoveCondonation("1RV21CS001", "CS501"); err == nil {
+		t.Fatal("removed a condonation twice")
+	}
+}
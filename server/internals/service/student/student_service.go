@@ -1,10 +1,9 @@
 package student_service
 
 import (
-	"fmt"
-
 	"github.com/go-playground/validator/v10"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
 )
 
 type StudentService struct {
@@ -13,7 +12,7 @@ type StudentService struct {
 }
 
 func NewStudentService(studentRepo domain.StudentRepo) *StudentService {
-	v := validator.New()
+	v := validation.New()
 	return &StudentService{
 		studentRepo: studentRepo,
 		validate: v,
@@ -21,9 +20,10 @@ func NewStudentService(studentRepo domain.StudentRepo) *StudentService {
 }
 
 func (s *StudentService) RegisterStudent(req domain.StudentRegisterPayload) (int64, error) {
-
+	req.USN = domain.NormalizeUSN(req.USN)
+	req.Department = domain.DepartmentCode(req.Department)
 	if err := s.validate.Struct(req); err != nil {
-		return 0, fmt.Errorf("validation error: %w", err)
+		return 0, validation.Error(err)
 	}
 
 	// TODO : here i need call rabbit mq service and  drop a message "new entry with usn req.usn"
@@ -36,12 +36,12 @@ func (s *StudentService) RegisterStudent(req domain.StudentRegisterPayload) (int
 	return id, nil
 }
 func (s *StudentService) LoginStudent(usn, password string) (string, error) {
-	
+	usn = domain.NormalizeUSN(usn)
 	if err := s.validate.Var(usn, "required"); err != nil {
-		return "", fmt.Errorf("validation error: %w", err)
+		return "", validation.Var("usn", err)
 	}
 	if err := s.validate.Var(password, "required"); err != nil {
-		return "", fmt.Errorf("validation error: %w", err)
+		return "", validation.Var("password", err)
 	}
 
 	token, err := s.studentRepo.LoginStudent(usn, password)
@@ -54,9 +54,9 @@ func (s *StudentService) LoginStudent(usn, password string) (string, error) {
 }
 
 func (s *StudentService) UpdateStudentInfo(studentID int, payload domain.StudentUpdatePayload) error {
-
+	payload.Department = domain.DepartmentCode(payload.Department)
 	if err := s.validate.Struct(payload); err != nil {
-		return err
+		return validation.Error(err)
 	}
 	err := s.studentRepo.UpdateStudentInfo(studentID, payload)
 
@@ -0,0 +1,134 @@
+package student_service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	student_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/student"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
+)
+
+func newService(t *testing.T) (*student_service.StudentService, *memory.MemoryRepo) {
+	t.Helper()
+	utils.ConfigureJWT("test-secret", "test", time.Hour)
+	repo := memory.NewMemoryRepo(nil)
+	return student_service.NewStudentService(repo), repo
+}
+
+var alice = domain.StudentRegisterPayload{
+	USN:        "1RV21CS001",
+	Username:   "Alice",
+	Password:   "secret123",
+	Department: "CSE",
+	Sem:        5,
+}
+
+func TestRegisterStudentEnrollsInExistingSubjects(t *testing.T) {
+	svc, repo := newService(t)
+
+	facultyID, err := repo.CreateFaculty(domain.FacultyRegisterPayload{Name: "Ravi", Email: "ravi@college.edu", Password: "secret123", Department: "CSE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.AddSubject(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: facultyID, Department: "CSE", Sem: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.AddSubject(domain.SubjectPayload{Code: "CS301", Name: "Data Structures", FacultyID: facultyID, Department: "CSE", Sem: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := svc.RegisterStudent(alice)
+	if err != nil {
+		t.Fatalf("RegisterStudent: %v", err)
+	}
+
+	subjects, err := repo.GetSubjectsByStudentID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subjects) != 1 || subjects[0].Code != "CS501" {
+		t.Fatalf("subjects = %+v, want only CS501", subjects)
+	}
+}
+
+func TestRegisterStudentRejectsDuplicateUSN(t *testing.T) {
+	svc, _ := newService(t)
+
+	if _, err := svc.RegisterStudent(alice); err != nil {
+		t.Fatal(err)
+	}
+	again := alice
+	again.Username = "Someone Else"
+	if _, err := svc.RegisterStudent(again); err == nil {
+		t.Fatal("second registration with the same USN succeeded")
+	}
+}
+
+func TestRegisterStudentValidatesEmail(t *testing.T) {
+	svc, _ := newService(t)
+
+	req := alice
+	req.Email = "not-an-email"
+	if _, err := svc.RegisterStudent(req); err == nil {
+		t.Fatal("invalid email accepted")
+	}
+}
+
+func TestLoginStudent(t *testing.T) {
+	svc, _ := newService(t)
+	if _, err := svc.RegisterStudent(alice); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := svc.LoginStudent(alice.USN, alice.Password)
+	if err != nil {
+		t.Fatalf("LoginStudent: %v", err)
+	}
+	if token == "" {
+		t.Fatal("empty token")
+	}
+
+	if _, err := svc.LoginStudent(alice.USN, "wrong-password"); err == nil {
+		t.Fatal("wrong password accepted")
+	}
+	if _, err := svc.LoginStudent("1RV21CS998", alice.Password); err == nil {
+		t.Fatal("unknown usn accepted")
+	}
+	if _, err := svc.LoginStudent("", ""); err == nil {
+		t.Fatal("empty credentials accepted")
+	}
+}
+
+func TestRegisterStudentNormalizesUSN(t *testing.T) {
+	svc, _ := newService(t)
+
+	req := alice
+	req.USN = " 1rv21cs001 "
+	if _, err := svc.RegisterStudent(req); err != nil {
+		t.Fatalf("lower-case usn: %v", err)
+	}
+	if _, err := svc.LoginStudent("1RV21CS001", alice.Password); err != nil {
+		t.Fatalf("login after normalization: %v", err)
+	}
+
+	req.USN = "1CS21001"
+	if _, err := svc.RegisterStudent(req); err == nil {
+		t.Fatal("new student with a legacy-format usn accepted")
+	}
+}
+
+func TestLoginStudentWithLegacyUSN(t *testing.T) {
+	svc, repo := newService(t)
+
+	// Registered before the current USN format was enforced.
+	legacy := alice
+	legacy.USN = "1CS21001"
+	if _, err := repo.StudentRegister(legacy); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.LoginStudent("1cs21001", alice.Password); err != nil {
+		t.Fatalf("LoginStudent: %v", err)
+	}
+}
@@ -1,8 +1,10 @@
 package subject_service
 
 import (
+
 	"github.com/go-playground/validator/v10"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
 )
 
 
@@ -12,7 +14,7 @@ type SubjectService struct{
 }
 
 func NewSubjectService(subjectRepo domain.SubjectRepo) *SubjectService {
-	v := validator.New()
+	v := validation.New()
 	return &SubjectService{
 		subjectRepo: subjectRepo,
 		validator:   v,
@@ -20,6 +22,10 @@ func NewSubjectService(subjectRepo domain.SubjectRepo) *SubjectService {
 }
 
 func (s *SubjectService) AddSubject(req domain.SubjectPayload) (int64, error){
+	req.Department = domain.DepartmentCode(req.Department)
+	if err := s.validator.Struct(req); err != nil {
+		return 0, validation.Error(err)
+	}
 
 	id ,err := s.subjectRepo.AddSubject(req)
 
@@ -30,13 +36,13 @@ func (s *SubjectService) AddSubject(req domain.SubjectPayload) (int64, error){
 	return id,nil
 }
 
-func (s *SubjectService) GetSubjectsByDeptAndSem(department string, sem int) ([]domain.Subject, error){
+func (s *SubjectService) GetSubjectsByDeptAndSem(department string, sem int, page domain.PageRequest) ([]domain.Subject, int, error){
 
-	subjects, err := s.subjectRepo.GetSubjectsByDeptAndSem(department, sem)
+	subjects, total, err := s.subjectRepo.GetSubjectsByDeptAndSem(domain.DepartmentCode(department), sem, page)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return subjects, nil
+	return subjects, total, nil
 }
 
 func (s *SubjectService) GetSubjectsByFacultyID(facultyID int64) ([]domain.Subject, error){
@@ -49,6 +55,16 @@ func (s *SubjectService) GetSubjectsByFacultyID(facultyID int64) ([]domain.Subje
 
 	return subjects,nil
 }
+func (s *SubjectService) SetPlannedClasses(subjectCode string, planned int) error {
+	if subjectCode == "" {
+		return domain.Invalid("subject_code", "required", "subject_code is required")
+	}
+	if planned < 0 {
+		return domain.Invalid("planned_classes", "min", "planned_classes must not be negative")
+	}
+	return s.subjectRepo.SetPlannedClasses(subjectCode, planned)
+}
+
 //subjects of a particular student
 func (s *SubjectService) GetSubjectsByStudentID(studentID int64) ([]domain.SubjectPayload, error){
 
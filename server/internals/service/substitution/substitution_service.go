@@ -0,0 +1,132 @@
+package substitution_service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+type SubstitutionService struct {
+	substitutionRepo domain.SubstitutionRepo
+	departmentRepo   domain.DepartmentRepo
+	termRepo         domain.TermRepo
+	validate         *validator.Validate
+}
+
+func NewSubstitutionService(substitutionRepo domain.SubstitutionRepo, departmentRepo domain.DepartmentRepo, termRepo domain.TermRepo) *SubstitutionService {
+	return &SubstitutionService{
+		substitutionRepo: substitutionRepo,
+		departmentRepo:   departmentRepo,
+		termRepo:         termRepo,
+		validate:         validation.New(),
+	}
+}
+
+// AuthorizeHOD checks that the faculty is the head of the department.
+func (s *SubstitutionService) AuthorizeHOD(facultyID int64, code string) error {
+	return domain.AuthorizeHOD(s.departmentRepo, facultyID, code)
+}
+
+// AssignSubstitute lets req.SubstituteFacultyID take the department's
+// subject on req.ClassDate; hodID is recorded as having assigned it.
+func (s *SubstitutionService) AssignSubstitute(hodID int64, department string, req domain.SubstitutionPayload) (int64, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return 0, validation.Error(err)
+	}
+	date, _ := time.Parse(calendar.DateLayout, req.ClassDate)
+
+	id, err := s.substitutionRepo.CreateSubstitution(hodID, domain.Substitution{
+		Department:   domain.DepartmentCode(department),
+		SubjectCode:  req.SubjectCode,
+		Date:         date,
+		SubstituteID: req.SubstituteFacultyID,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error assigning substitute: %w", err)
+	}
+	return id, nil
+}
+
+func (s *SubstitutionService) RemoveSubstitute(department string, id int64) error {
+	return s.substitutionRepo.DeleteSubstitution(domain.DepartmentCode(department), id)
+}
+
+func (s *SubstitutionService) GetSubstitutions(query domain.SubstitutionQuery) ([]domain.Substitution, error) {
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	query.Filter = filter
+	query.Department = domain.DepartmentCode(query.Department)
+
+	list, err := s.substitutionRepo.GetSubstitutions(query)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching substitutions: %w", err)
+	}
+	if list == nil {
+		list = []domain.Substitution{}
+	}
+	return list, nil
+}
+
+// RecordSwap records two of the department's subjects exchanging their
+// slots on req.ClassDate.
+func (s *SubstitutionService) RecordSwap(hodID int64, department string, req domain.ClassSwapPayload) (int64, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return 0, validation.Error(err)
+	}
+	if req.OtherSubjectCode == req.SubjectCode {
+		return 0, domain.Invalid("otherSubjectCode", "nefield", "otherSubjectCode must differ from subjectCode")
+	}
+	// HH:MM strings order like the times they name.
+	if req.End <= req.Start {
+		return 0, domain.Invalid("end", "gtfield", "end must be after start")
+	}
+	if req.OtherEnd <= req.OtherStart {
+		return 0, domain.Invalid("other_end", "gtfield", "other_end must be after other_start")
+	}
+	date, _ := time.Parse(calendar.DateLayout, req.ClassDate)
+
+	id, err := s.substitutionRepo.CreateClassSwap(hodID, domain.ClassSwap{
+		Department:       domain.DepartmentCode(department),
+		Date:             date,
+		SubjectCode:      req.SubjectCode,
+		Start:            req.Start,
+		End:              req.End,
+		OtherSubjectCode: req.OtherSubjectCode,
+		OtherStart:       req.OtherStart,
+		OtherEnd:         req.OtherEnd,
+		Reason:           req.Reason,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error recording class swap: %w", err)
+	}
+	return id, nil
+}
+
+func (s *SubstitutionService) RemoveSwap(department string, id int64) error {
+	return s.substitutionRepo.DeleteClassSwap(domain.DepartmentCode(department), id)
+}
+
+func (s *SubstitutionService) GetClassSwaps(query domain.SubstitutionQuery) ([]domain.ClassSwap, error) {
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	query.Filter = filter
+	query.Department = domain.DepartmentCode(query.Department)
+
+	list, err := s.substitutionRepo.GetClassSwaps(query)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching class swaps: %w", err)
+	}
+	if list == nil {
+		list = []domain.ClassSwap{}
+	}
+	return list, nil
+}
@@ -0,0 +1,109 @@
+package substitution_service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	substitution_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/substitution"
+)
+
+var monday = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+func TestSubstituteCoversOneDate(t *testing.T) {
+	b := memorytest.New(t)
+	repo := b.Repo
+	svc := substitution_service.NewSubstitutionService(repo, repo, repo)
+
+	ravi, asha, hod := b.Faculty("Ravi", "CSE"), b.Faculty("Asha", "CSE"), b.Faculty("Kiran", "CSE")
+	b.HOD("CSE", hod)
+	b.Subjects(
+		domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi},
+		domain.SubjectPayload{Code: "CS502", Name: "Networks", FacultyID: asha},
+		domain.SubjectPayload{Code: "ME501", Name: "Thermodynamics", FacultyID: asha, Department: "ME"},
+	)
+	b.Students(domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"})
+
+	if err := svc.AuthorizeHOD(ravi, "cse"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("AuthorizeHOD(ravi) = %v, want forbidden", err)
+	}
+	if err := svc.AuthorizeHOD(hod, "cse"); err != nil {
+		t.Errorf("AuthorizeHOD(hod) = %v", err)
+	}
+
+	req := domain.SubstitutionPayload{SubjectCode: "CS501", ClassDate: "2025-06-02", SubstituteFacultyID: asha, Reason: "Ravi on leave"}
+	if _, err := svc.AssignSubstitute(hod, "cse", req); err != nil {
+		t.Fatalf("AssignSubstitute: %v", err)
+	}
+	if _, err := svc.AssignSubstitute(hod, "CSE", req); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second substitute on a date: %v, want a conflict", err)
+	}
+	if _, err := svc.AssignSubstitute(hod, "CSE", domain.SubstitutionPayload{SubjectCode: "ME501", ClassDate: "2025-06-02", SubstituteFacultyID: ravi}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("subject of another department: %v, want forbidden", err)
+	}
+
+	// Asha may assign and correct CS501 on June 2, but not on June 3.
+	for offset := range 2 {
+		b.Capture(monday.AddDate(0, 0, offset), map[string]string{"1RV21CS001": "Present"})
+	}
+	start := monday.Add(8 * time.Hour)
+	if n, _, err := repo.AssignSubjectToTimeRange(asha, "CS501", monday, start, start.Add(2*time.Hour)); err != nil || n != 1 {
+		t.Fatalf("substitute assigning = %d, %v", n, err)
+	}
+	next := monday.AddDate(0, 0, 1)
+	if _, _, err := repo.AssignSubjectToTimeRange(asha, "CS501", next, start, start.Add(2*time.Hour)); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("substitute assigning another date: %v, want forbidden", err)
+	}
+	rows, err := repo.GetAttendanceBySubjectAndDate("CS501", monday)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("GetAttendanceBySubjectAndDate = %v, %v", rows, err)
+	}
+	if _, err := repo.CorrectAttendance(asha, rows[0].ID, "Absent"); err != nil {
+		t.Errorf("substitute correcting: %v", err)
+	}
+
+	list, err := svc.GetSubstitutions(domain.SubstitutionQuery{FacultyID: asha})
+	if err != nil || len(list) != 1 || list[0].FacultyName != "Ravi" || list[0].SubstituteName != "Asha" {
+		t.Errorf("GetSubstitutions = %+v, %v", list, err)
+	}
+	if err := svc.RemoveSubstitute("ME", list[0].ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("remove through another department: %v, want not found", err)
+	}
+	if err := svc.RemoveSubstitute("CSE", list[0].ID); err != nil {
+		t.Errorf("RemoveSubstitute: %v", err)
+	}
+}
+
+func TestRecordSwap(t *testing.T) {
+	b := memorytest.New(t)
+	svc := substitution_service.NewSubstitutionService(b.Repo, b.Repo, b.Repo)
+
+	ravi := b.Faculty("Ravi", "CSE")
+	b.Subjects(
+		domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi},
+		domain.SubjectPayload{Code: "CS502", Name: "Networks", FacultyID: ravi},
+	)
+
+	req := domain.ClassSwapPayload{
+		ClassDate: "2025-06-02", SubjectCode: "CS501", Start: "09:00", End: "10:00",
+		OtherSubjectCode: "CS501", OtherStart: "11:00", OtherEnd: "12:00",
+	}
+	if _, err := svc.RecordSwap(ravi, "CSE", req); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("swap with itself: %v, want a validation error", err)
+	}
+	req.OtherSubjectCode, req.OtherEnd = "CS502", "10:30"
+	if _, err := svc.RecordSwap(ravi, "CSE", req); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("slot ending before it starts: %v, want a validation error", err)
+	}
+	req.OtherEnd = "12:00"
+	if _, err := svc.RecordSwap(ravi, "CSE", req); err != nil {
+		t.Fatalf("RecordSwap: %v", err)
+	}
+
+	swaps, err := svc.GetClassSwaps(domain.SubstitutionQuery{Department: "cse"})
+	if err != nil || len(swaps) != 1 || swaps[0].OtherSubjectName != "Networks" || swaps[0].OtherStart != "11:00" {
+		t.Errorf("GetClassSwaps = %+v, %v", swaps, err)
+	}
+}
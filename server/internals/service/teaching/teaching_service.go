@@ -0,0 +1,116 @@
+package teaching_service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+type TeachingService struct {
+	teachingRepo domain.TeachingSessionRepo
+	termRepo     domain.TermRepo
+	cal          *calendar.Calendar
+	validate     *validator.Validate
+}
+
+func NewTeachingService(teachingRepo domain.TeachingSessionRepo, termRepo domain.TermRepo, cal *calendar.Calendar) *TeachingService {
+	if cal == nil {
+		cal = calendar.New(nil)
+	}
+	return &TeachingService{
+		teachingRepo: teachingRepo,
+		termRepo:     termRepo,
+		cal:          cal,
+		validate:     validation.New(),
+	}
+}
+
+// LogSession records a session facultyID taught; sessions cannot be logged
+// ahead of their class date.
+func (s *TeachingService) LogSession(facultyID int64, req domain.TeachingSessionPayload) (int64, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return 0, validation.Error(err)
+	}
+	date, _ := s.cal.ParseDate(req.ClassDate)
+	if date.After(s.cal.Today()) {
+		return 0, domain.Invalid("class_date", "notfuture", "class_date must not be in the future")
+	}
+
+	id, err := s.teachingRepo.LogTeachingSession(facultyID, domain.TeachingSession{
+		SubjectCode:     req.SubjectCode,
+		Date:            date,
+		StartTime:       req.Start,
+		DurationMinutes: req.DurationMinutes,
+		Topic:           req.Topic,
+		Notes:           req.Notes,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error logging teaching session: %w", err)
+	}
+	return id, nil
+}
+
+func (s *TeachingService) UpdateSession(facultyID, sessionID int64, req domain.TeachingSessionUpdatePayload) error {
+	if err := s.validate.Struct(req); err != nil {
+		return validation.Error(err)
+	}
+	return s.teachingRepo.UpdateTeachingSession(facultyID, domain.TeachingSession{
+		ID:              sessionID,
+		StartTime:       req.Start,
+		DurationMinutes: req.DurationMinutes,
+		Topic:           req.Topic,
+		Notes:           req.Notes,
+	})
+}
+
+func (s *TeachingService) DeleteSession(facultyID, sessionID int64) error {
+	return s.teachingRepo.DeleteTeachingSession(facultyID, sessionID)
+}
+
+// GetTeachingLog returns the matching sessions with their totals per
+// subject, for accreditation audits.
+func (s *TeachingService) GetTeachingLog(query domain.TeachingLogQuery) (domain.TeachingLogReport, error) {
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, query.Filter)
+	if err != nil {
+		return domain.TeachingLogReport{}, err
+	}
+	query.Filter = filter
+
+	sessions, err := s.teachingRepo.GetTeachingLog(query)
+	if err != nil {
+		return domain.TeachingLogReport{}, fmt.Errorf("error fetching teaching log: %w", err)
+	}
+
+	report := domain.TeachingLogReport{Subjects: []domain.TeachingLogSubject{}, Sessions: sessions}
+	if !filter.From.IsZero() {
+		report.From = &filter.From
+	}
+	if !filter.To.IsZero() {
+		report.To = &filter.To
+	}
+	if report.Sessions == nil {
+		report.Sessions = []domain.TeachingSession{}
+	}
+
+	bySubject := map[string]*domain.TeachingLogSubject{}
+	for _, ts := range sessions {
+		sub := bySubject[ts.SubjectCode]
+		if sub == nil {
+			sub = &domain.TeachingLogSubject{SubjectCode: ts.SubjectCode, SubjectName: ts.SubjectName, FacultyName: ts.FacultyName}
+			bySubject[ts.SubjectCode] = sub
+		}
+		sub.Sessions++
+		sub.Minutes += ts.DurationMinutes
+		report.TotalSessions++
+		report.TotalMinutes += ts.DurationMinutes
+	}
+	for _, sub := range bySubject {
+		report.Subjects = append(report.Subjects, *sub)
+	}
+	sort.Slice(report.Subjects, func(i, j int) bool { return report.Subjects[i].SubjectCode < report.Subjects[j].SubjectCode })
+	return report, nil
+}
@@ -0,0 +1,100 @@
+package teaching_service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	teaching_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/teaching"
+)
+
+var monday = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+func TestTeachingLogIsTheDenominator(t *testing.T) {
+	b := memorytest.New(t)
+	repo := b.Repo
+	cal := calendar.New(nil).WithClock(func() time.Time { return monday.AddDate(0, 0, 8) })
+	svc := teaching_service.NewTeachingService(repo, repo, cal)
+
+	ravi := b.Faculty("Ravi", "CSE")
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi})
+	b.Students(
+		domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"},
+		domain.StudentRegisterPayload{USN: "1RV21CS002", Username: "Bob"},
+	)
+
+	// Alice is present on June 2 and 4, but only June 2 and 9 are logged.
+	// Bob is never marked.
+	for _, offset := range []int{0, 2} {
+		b.Class("CS501", monday.AddDate(0, 0, offset), map[string]string{"1RV21CS001": "Present"})
+	}
+	for _, date := range []string{"2025-06-02", "2025-06-09"} {
+		if _, err := svc.LogSession(ravi, domain.TeachingSessionPayload{
+			SubjectCode: "CS501", ClassDate: date, Start: "09:00", DurationMinutes: 60, Topic: "Parsing",
+		}); err != nil {
+			t.Fatalf("LogSession %s: %v", date, err)
+		}
+	}
+
+	summary, err := repo.GetAttendanceSummaryByStudent("1RV21CS001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary) != 1 || summary[0].TotalClasses != 2 || summary[0].Attended != 1 || summary[0].Percentage != 50 {
+		t.Errorf("summary = %+v, want 1 of 2 logged sessions", summary)
+	}
+
+	// Missed sessions count as absent, even for a student with no rows.
+	roster, _, err := repo.GetAttendanceSummaryBySubject("CS501", domain.AttendanceFilter{}, domain.PageRequest{Limit: 10, Sort: "usn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roster) != 2 || roster[1].USN != "1RV21CS002" || roster[1].TotalClasses != 2 || roster[1].Attended != 0 || roster[1].Percentage != 0 {
+		t.Errorf("subject summary = %+v, want Bob at 0 of 2", roster)
+	}
+
+	report, err := svc.GetTeachingLog(domain.TeachingLogQuery{FacultyID: ravi})
+	if err != nil {
+		t.Fatalf("GetTeachingLog: %v", err)
+	}
+	if report.TotalSessions != 2 || report.TotalMinutes != 120 || len(report.Subjects) != 1 || report.Subjects[0].Sessions != 2 {
+		t.Errorf("report = %+v", report)
+	}
+	if s := report.Sessions[0]; s.Present != 1 || s.Absent != 0 || s.FacultyName != "Ravi" {
+		t.Errorf("June 2 session = %+v", s)
+	}
+
+	payload := domain.TeachingSessionPayload{SubjectCode: "CS501", ClassDate: "2025-06-02", Start: "09:00", DurationMinutes: 60, Topic: "Again"}
+	if _, err := svc.LogSession(ravi, payload); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second session on a date and start time: %v, want a conflict", err)
+	}
+	if _, err := svc.LogSession(ravi+1, payload); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("session of another faculty's subject: %v, want forbidden", err)
+	}
+	payload.ClassDate = "2025-06-11"
+	if _, err := svc.LogSession(ravi, payload); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("future session: %v, want a validation error", err)
+	}
+
+	// A tutorial later on June 2 is a class of its own, attended on the
+	// strength of the same row, and cannot be moved onto the lecture.
+	payload.ClassDate, payload.Start = "2025-06-02", "11:00"
+	tutorial, err := svc.LogSession(ravi, payload)
+	if err != nil {
+		t.Fatalf("second session on a date at another time: %v", err)
+	}
+	summary, err = repo.GetAttendanceSummaryByStudent("1RV21CS001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary) != 1 || summary[0].TotalClasses != 3 || summary[0].Attended != 2 {
+		t.Errorf("summary = %+v, want 2 of 3 logged sessions", summary)
+	}
+	move := domain.TeachingSessionUpdatePayload{Start: "09:00", DurationMinutes: 60, Topic: "Tutorial"}
+	if err := svc.UpdateSession(ravi, tutorial, move); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("moving a session onto another: %v, want a conflict", err)
+	}
+}
@@ -0,0 +1,55 @@
+package term_service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+type TermService struct {
+	termRepo domain.TermRepo
+	validate *validator.Validate
+}
+
+func NewTermService(termRepo domain.TermRepo) *TermService {
+	v := validation.New()
+	return &TermService{
+		termRepo: termRepo,
+		validate: v,
+	}
+}
+
+func (s *TermService) CreateTerm(req domain.TermPayload) (int64, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return 0, validation.Error(err)
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return 0, domain.Invalid("start_date", "date", "start_date must be a YYYY-MM-DD date").Wrap(err)
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return 0, domain.Invalid("end_date", "date", "end_date must be a YYYY-MM-DD date").Wrap(err)
+	}
+	if end.Before(start) {
+		return 0, domain.Invalid("end_date", "gtefield", "end_date must not be before start_date")
+	}
+
+	id, err := s.termRepo.CreateTerm(req.Name, start, end)
+	if err != nil {
+		return 0, fmt.Errorf("error creating term: %w", err)
+	}
+	return id, nil
+}
+
+func (s *TermService) GetTerms() ([]domain.Term, error) {
+	terms, err := s.termRepo.GetTerms()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching terms: %w", err)
+	}
+	return terms, nil
+}
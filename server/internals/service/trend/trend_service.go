@@ -0,0 +1,189 @@
+package trend_service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/calendar"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+const monthLayout = "2006-01"
+
+type TrendService struct {
+	trendRepo domain.StudentTrendRepo
+	termRepo  domain.TermRepo
+	threshold float64
+}
+
+// NewTrendService builds the student trend service; threshold is the
+// eligibility percentage projections count allowed absences against.
+func NewTrendService(trendRepo domain.StudentTrendRepo, termRepo domain.TermRepo, threshold float64) *TrendService {
+	return &TrendService{
+		trendRepo: trendRepo,
+		termRepo:  termRepo,
+		threshold: threshold,
+	}
+}
+
+// GetAttendanceCalendar lays the student's classes out over month, given
+// as YYYY-MM.
+func (s *TrendService) GetAttendanceCalendar(usn, month string) (domain.AttendanceCalendar, error) {
+	first, err := time.Parse(monthLayout, month)
+	if err != nil {
+		return domain.AttendanceCalendar{}, domain.Invalid("month", "month", "month must be YYYY-MM")
+	}
+	last := first.AddDate(0, 1, -1)
+
+	rows, err := s.trendRepo.GetStudentDailyAttendance(usn, domain.AttendanceFilter{From: first, To: last})
+	if err != nil {
+		return domain.AttendanceCalendar{}, fmt.Errorf("error fetching attendance calendar: %w", err)
+	}
+
+	days := make([]domain.CalendarDay, last.Day())
+	for i := range days {
+		days[i] = domain.CalendarDay{Date: first.AddDate(0, 0, i), Entries: []domain.CalendarEntry{}}
+	}
+	for _, r := range rows {
+		d := &days[r.Date.Day()-1]
+		d.Entries = append(d.Entries, domain.CalendarEntry{SubjectCode: r.SubjectCode, SubjectName: r.SubjectName, Status: r.Status})
+		if r.Status == "Present" {
+			d.Present++
+		} else {
+			d.Absent++
+		}
+	}
+	return domain.AttendanceCalendar{Month: month, Days: days}, nil
+}
+
+func (s *TrendService) GetAttendanceTrend(usn string, filter domain.AttendanceFilter) ([]domain.SubjectTrend, error) {
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, filter)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.trendRepo.GetStudentDailyAttendance(usn, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching attendance trend: %w", err)
+	}
+	return weeklyTrends(rows), nil
+}
+
+// weeklyTrends groups date-ordered rows by subject and week, subjects in
+// order of code.
+func weeklyTrends(rows []domain.DailyAttendance) []domain.SubjectTrend {
+	bySubject := map[string]*domain.SubjectTrend{}
+	var codes []string
+	for _, r := range rows {
+		t := bySubject[r.SubjectCode]
+		if t == nil {
+			t = &domain.SubjectTrend{SubjectCode: r.SubjectCode, SubjectName: r.SubjectName}
+			bySubject[r.SubjectCode] = t
+			codes = append(codes, r.SubjectCode)
+		}
+		week := calendar.WeekStart(r.Date)
+		if n := len(t.Weeks); n == 0 || !t.Weeks[n-1].WeekStart.Equal(week) {
+			t.Weeks = append(t.Weeks, domain.TrendWeek{WeekStart: week})
+		}
+		w := &t.Weeks[len(t.Weeks)-1]
+		w.Total++
+		if r.Status == "Present" {
+			w.Present++
+		}
+	}
+
+	sort.Strings(codes)
+	trends := []domain.SubjectTrend{}
+	for _, code := range codes {
+		t := bySubject[code]
+		var present, total int
+		for i := range t.Weeks {
+			w := &t.Weeks[i]
+			w.Percentage = percentage(w.Present, w.Total)
+			present, total = present+w.Present, total+w.Total
+			w.Cumulative = percentage(present, total)
+
+			since := w.WeekStart.AddDate(0, 0, -7*(domain.RollingWeeks-1))
+			var rp, rt int
+			for j := i; j >= 0 && !t.Weeks[j].WeekStart.Before(since); j-- {
+				rp, rt = rp+t.Weeks[j].Present, rt+t.Weeks[j].Total
+			}
+			w.Rolling = percentage(rp, rt)
+		}
+		trends = append(trends, *t)
+	}
+	return trends
+}
+
+// GetAttendanceProjection estimates each enrolled subject's end-of-term
+// percentage from the classes planned but not yet held.
+func (s *TrendService) GetAttendanceProjection(usn string, filter domain.AttendanceFilter) (domain.AttendanceProjection, error) {
+	filter, err := domain.ResolveAttendanceFilter(s.termRepo, filter)
+	if err != nil {
+		return domain.AttendanceProjection{}, err
+	}
+	progress, err := s.trendRepo.GetStudentProgress(usn, filter)
+	if err != nil {
+		return domain.AttendanceProjection{}, fmt.Errorf("error fetching attendance progress: %w", err)
+	}
+	rows, err := s.trendRepo.GetStudentDailyAttendance(usn, filter)
+	if err != nil {
+		return domain.AttendanceProjection{}, fmt.Errorf("error fetching attendance trend: %w", err)
+	}
+
+	recent := map[string]float64{}
+	for _, t := range weeklyTrends(rows) {
+		recent[t.SubjectCode] = t.Weeks[len(t.Weeks)-1].Rolling
+	}
+	for i := range progress {
+		p := &progress[i]
+		rate, ok := recent[p.SubjectCode]
+		p.RecentPercentage = rate
+		if !ok {
+			// Nothing recorded yet: assume the student attends.
+			rate = 100
+		}
+		project(p, rate, s.threshold)
+	}
+
+	if progress == nil {
+		progress = []domain.SubjectProjection{}
+	}
+	return domain.AttendanceProjection{Threshold: s.threshold, Subjects: progress}, nil
+}
+
+// project fills p's percentages assuming the student attends rate percent
+// of the remaining classes.
+func project(p *domain.SubjectProjection, rate, threshold float64) {
+	p.Percentage = percentage(p.Attended, p.Total)
+	p.ProjectedPercentage, p.BestCase, p.WorstCase = p.Percentage, p.Percentage, p.Percentage
+	if p.PlannedClasses == 0 {
+		return
+	}
+
+	remaining := max(p.PlannedClasses-p.ClassesHeld, 0)
+	p.RemainingClasses = &remaining
+	end := p.Total + remaining
+	if end == 0 {
+		return
+	}
+	p.BestCase = percentage(p.Attended+remaining, end)
+	p.WorstCase = percentage(p.Attended, end)
+	p.ProjectedPercentage = math.Round(10000*(float64(p.Attended)+rate/100*float64(remaining))/float64(end)) / 100
+
+	// Largest k with (attended + remaining - k) / end >= threshold.
+	allowed := int(math.Floor(float64(p.Attended+remaining) - threshold/100*float64(end) + 1e-9))
+	if allowed < 0 {
+		allowed = -1
+	}
+	allowed = min(allowed, remaining)
+	p.AllowedAbsences = &allowed
+}
+
+func percentage(present, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(10000*float64(present)/float64(total)) / 100
+}
@@ -0,0 +1,131 @@
+package trend_service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory/memorytest"
+	trend_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/trend"
+)
+
+var monday = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+// seed holds four of CS501's ten planned classes, on June 2, 4, 9 and 30;
+// Alice misses the one on the 9th. CS502 has not started.
+func seed(t *testing.T) *trend_service.TrendService {
+	t.Helper()
+	b := memorytest.New(t)
+	ravi := b.Faculty("Ravi", "CSE")
+	b.Subjects(
+		domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi, PlannedClasses: 10},
+		domain.SubjectPayload{Code: "CS502", Name: "Networks", FacultyID: ravi},
+	)
+	b.Students(domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"})
+	for _, offset := range []int{0, 2, 7, 28} {
+		status := "Present"
+		if offset == 7 {
+			status = "Absent"
+		}
+		b.Class("CS501", monday.AddDate(0, 0, offset), map[string]string{"1RV21CS001": status})
+	}
+	return trend_service.NewTrendService(b.Repo, b.Repo, 75)
+}
+
+func TestAttendanceCalendar(t *testing.T) {
+	svc := seed(t)
+
+	cal, err := svc.GetAttendanceCalendar("1RV21CS001", "2025-06")
+	if err != nil {
+		t.Fatalf("GetAttendanceCalendar: %v", err)
+	}
+	if len(cal.Days) != 30 || !cal.Days[0].Date.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("days = %d starting %v, want all of June", len(cal.Days), cal.Days[0].Date)
+	}
+	if d := cal.Days[8]; d.Absent != 1 || len(d.Entries) != 1 || d.Entries[0].SubjectCode != "CS501" {
+		t.Errorf("June 9 = %+v", d)
+	}
+	if d := cal.Days[9]; d.Entries == nil || len(d.Entries) != 0 {
+		t.Errorf("June 10 = %+v, want no entries", d)
+	}
+
+	if _, err := svc.GetAttendanceCalendar("1RV21CS001", "June"); err == nil {
+		t.Error("month June was accepted")
+	}
+}
+
+// Once CS501 logs sessions, a capture on a day without one drops out and a
+// logged session the student was never marked in shows as an absence.
+func TestCalendarFollowsLoggedSessions(t *testing.T) {
+	b := memorytest.New(t)
+	ravi := b.Faculty("Ravi", "CSE")
+	b.Subjects(domain.SubjectPayload{Code: "CS501", Name: "Compilers", FacultyID: ravi})
+	b.Students(domain.StudentRegisterPayload{USN: "1RV21CS001", Username: "Alice"})
+	b.Class("CS501", monday, map[string]string{"1RV21CS001": "Present"})
+	b.Class("CS501", monday.AddDate(0, 0, 1), map[string]string{"1RV21CS001": "Present"})
+	for _, offset := range []int{0, 2} {
+		if _, err := b.Repo.LogTeachingSession(ravi, domain.TeachingSession{
+			SubjectCode: "CS501", Date: monday.AddDate(0, 0, offset), StartTime: "08:00", DurationMinutes: 60, Topic: "Parsing",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := trend_service.NewTrendService(b.Repo, b.Repo, 75)
+
+	cal, err := svc.GetAttendanceCalendar("1RV21CS001", "2025-06")
+	if err != nil {
+		t.Fatalf("GetAttendanceCalendar: %v", err)
+	}
+	if d := cal.Days[1]; d.Present != 1 || len(d.Entries) != 1 {
+		t.Errorf("June 2 = %+v, want the logged class attended", d)
+	}
+	if d := cal.Days[2]; len(d.Entries) != 0 {
+		t.Errorf("June 3 = %+v, want nothing without a logged session", d)
+	}
+	if d := cal.Days[3]; d.Absent != 1 || len(d.Entries) != 1 || d.Entries[0].Status != "Absent" {
+		t.Errorf("June 4 = %+v, want the unmarked session absent", d)
+	}
+}
+
+func TestAttendanceTrend(t *testing.T) {
+	svc := seed(t)
+
+	trend, err := svc.GetAttendanceTrend("1RV21CS001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatalf("GetAttendanceTrend: %v", err)
+	}
+	if len(trend) != 1 || len(trend[0].Weeks) != 3 {
+		t.Fatalf("trend = %+v, want three CS501 weeks", trend)
+	}
+	// The rolling window of June 30 reaches back to June 9 but not June 2.
+	if w := trend[0].Weeks[2]; w.Percentage != 100 || w.Rolling != 50 || w.Cumulative != 75 {
+		t.Errorf("week of June 30 = %+v", w)
+	}
+}
+
+func TestAttendanceProjection(t *testing.T) {
+	svc := seed(t)
+
+	p, err := svc.GetAttendanceProjection("1RV21CS001", domain.AttendanceFilter{})
+	if err != nil {
+		t.Fatalf("GetAttendanceProjection: %v", err)
+	}
+	if p.Threshold != 75 || len(p.Subjects) != 2 {
+		t.Fatalf("projection = %+v", p)
+	}
+
+	s := p.Subjects[0]
+	if s.SubjectCode != "CS501" || s.RemainingClasses == nil || *s.RemainingClasses != 6 {
+		t.Fatalf("CS501 = %+v", s)
+	}
+	if s.Percentage != 75 || s.RecentPercentage != 50 || s.ProjectedPercentage != 60 || s.BestCase != 90 || s.WorstCase != 30 {
+		t.Errorf("CS501 percentages = %+v", s)
+	}
+	if s.AllowedAbsences == nil || *s.AllowedAbsences != 1 {
+		t.Errorf("CS501 allowed absences = %v, want 1", s.AllowedAbsences)
+	}
+
+	if s := p.Subjects[1]; s.SubjectCode != "CS502" || s.Total != 0 || s.RemainingClasses != nil || s.AllowedAbsences != nil {
+		t.Errorf("CS502 = %+v, want no plan and no classes", s)
+	}
+}
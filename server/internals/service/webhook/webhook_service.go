@@ -0,0 +1,351 @@
+package webhook_service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	mathrand "math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+// Headers sent with every delivery. The signature header has the form
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">".
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type Config struct {
+	// MaxAttempts is how many failed attempts move a delivery to dead.
+	MaxAttempts int
+	// BaseBackoff is the wait after the first failure; it doubles per attempt
+	// up to MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// PollInterval is how often the worker looks for due deliveries when it
+	// is not woken by a new event.
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease keeps a claimed delivery from being picked up again while it is
+	// being sent; it must exceed the client timeout.
+	Lease time.Duration
+	// QueueSize bounds the published events waiting to be written as
+	// deliveries; zero uses the default.
+	QueueSize int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts:  8,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+		Lease:        2 * time.Minute,
+		QueueSize:    1024,
+	}
+}
+
+type WebhookService struct {
+	webhookRepo domain.WebhookRepo
+	client      *http.Client
+	cfg         Config
+	events      chan domain.AttendanceEvent
+	wake        chan struct{}
+	validate    *validator.Validate
+}
+
+// NewWebhookService builds the service. It implements domain.EventPublisher
+// so it can be handed to the attendance service alongside the live hub.
+func NewWebhookService(webhookRepo domain.WebhookRepo, client *http.Client, cfg Config) *WebhookService {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultConfig().QueueSize
+	}
+	v := validation.New()
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client:      client,
+		cfg:         cfg,
+		events:      make(chan domain.AttendanceEvent, cfg.QueueSize),
+		wake:        make(chan struct{}, 1),
+		validate:    v,
+	}
+}
+
+// CreateWebhook registers a subscription and returns its id and signing secret.
+func (s *WebhookService) CreateWebhook(req domain.WebhookPayload) (int64, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return 0, "", validation.Error(err)
+	}
+
+	// "*" already covers whatever else is listed next to it.
+	if slices.Contains(req.EventTypes, domain.WebhookAllEvents) {
+		req.EventTypes = []string{domain.WebhookAllEvents}
+	}
+	if req.Secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return 0, "", fmt.Errorf("generate secret: %w", err)
+		}
+		req.Secret = hex.EncodeToString(buf)
+	}
+
+	id, err := s.webhookRepo.CreateWebhook(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("error while creating webhook: %w", err)
+	}
+	return id, req.Secret, nil
+}
+
+func (s *WebhookService) GetWebhooks() ([]domain.Webhook, error) {
+	hooks, err := s.webhookRepo.GetWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	if hooks == nil {
+		hooks = []domain.Webhook{}
+	}
+	return hooks, nil
+}
+
+func (s *WebhookService) DeleteWebhook(webhookID int64) error {
+	return s.webhookRepo.DeleteWebhook(webhookID)
+}
+
+func (s *WebhookService) GetDeliveries(filter domain.DeliveryFilter, page domain.PageRequest) ([]domain.WebhookDelivery, int, error) {
+	if err := s.validate.Var(filter.Status, "omitempty,oneof=pending delivered dead"); err != nil {
+		return nil, 0, domain.Invalid("status", "oneof", "status must be pending, delivered or dead")
+	}
+
+	deliveries, total, err := s.webhookRepo.GetDeliveries(filter, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	if deliveries == nil {
+		deliveries = []domain.WebhookDelivery{}
+	}
+	return deliveries, total, nil
+}
+
+func (s *WebhookService) GetDelivery(deliveryID int64) (domain.WebhookDelivery, error) {
+	return s.webhookRepo.GetDelivery(deliveryID)
+}
+
+// ReplayDelivery queues a delivery again, typically one from the dead letters.
+func (s *WebhookService) ReplayDelivery(deliveryID int64) error {
+	if err := s.webhookRepo.ReplayDelivery(deliveryID); err != nil {
+		return err
+	}
+	s.nudge()
+	return nil
+}
+
+// Publish hands the event to Run, which writes a delivery for every
+// subscribed webhook, and returns at once. Only if the queue is full, with
+// the database far behind, is the event dropped.
+func (s *WebhookService) Publish(event domain.AttendanceEvent) {
+	select {
+	case s.events <- event:
+	default:
+		log.Printf("webhooks: queue full, dropping %s", event.Type)
+	}
+}
+
+// enqueueEvents writes published events as deliveries until ctx is
+// cancelled, then writes whatever is still queued.
+func (s *WebhookService) enqueueEvents(ctx context.Context) {
+	for {
+		select {
+		case event := <-s.events:
+			s.enqueue(event)
+		case <-ctx.Done():
+			for {
+				select {
+				case event := <-s.events:
+					s.enqueue(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *WebhookService) enqueue(event domain.AttendanceEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("webhooks: encode %s: %v", event.Type, err)
+		return
+	}
+
+	n, err := s.webhookRepo.EnqueueDeliveries(event.Type, payload)
+	if err != nil {
+		log.Printf("webhooks: %v", err)
+		return
+	}
+	if n > 0 {
+		s.nudge()
+	}
+}
+
+func (s *WebhookService) nudge() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run writes published events as deliveries and sends due deliveries until
+// ctx is cancelled. A send cut short by cancellation is not counted as a
+// failed attempt.
+func (s *WebhookService) Run(ctx context.Context) {
+	enqueued := make(chan struct{})
+	go func() {
+		defer close(enqueued)
+		s.enqueueEvents(ctx)
+	}()
+	defer func() { <-enqueued }()
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for s.processBatch(ctx) == s.cfg.BatchSize {
+			// A full batch means more may be due; keep draining.
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// processBatch claims and sends one batch and returns how many were claimed.
+func (s *WebhookService) processBatch(ctx context.Context) int {
+	if ctx.Err() != nil {
+		return 0
+	}
+
+	due, err := s.webhookRepo.ClaimDueDeliveries(s.cfg.BatchSize, s.cfg.Lease)
+	if err != nil {
+		log.Printf("webhooks: %v", err)
+		return 0
+	}
+
+	for _, d := range due {
+		if ctx.Err() != nil {
+			// The rest stay leased and are picked up again once it expires.
+			break
+		}
+		s.attempt(ctx, d)
+	}
+	return len(due)
+}
+
+func (s *WebhookService) attempt(ctx context.Context, d domain.PendingDelivery) {
+	code, err := s.send(ctx, d)
+	if err != nil && ctx.Err() != nil {
+		// Cut off by shutdown, not the receiver's fault; retry after the lease.
+		return
+	}
+	if err == nil {
+		if err := s.webhookRepo.MarkDeliverySucceeded(d.ID, code); err != nil {
+			log.Printf("webhooks: %v", err)
+		}
+		return
+	}
+
+	var next *time.Time
+	if d.Attempts+1 < s.cfg.MaxAttempts {
+		at := time.Now().Add(s.backoff(d.Attempts + 1))
+		next = &at
+	} else {
+		log.Printf("webhooks: delivery %d to %s is dead after %d attempts: %v", d.ID, d.URL, d.Attempts+1, err)
+	}
+	if err := s.webhookRepo.MarkDeliveryFailed(d.ID, code, err.Error(), next); err != nil {
+		log.Printf("webhooks: %v", err)
+	}
+}
+
+// backoff doubles BaseBackoff per failed attempt, capped at MaxBackoff, with
+// up to 10% jitter so receivers coming back up are not hit all at once.
+func (s *WebhookService) backoff(failures int) time.Duration {
+	wait := s.cfg.BaseBackoff
+	for i := 1; i < failures && wait < s.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > s.cfg.MaxBackoff {
+		wait = s.cfg.MaxBackoff
+	}
+	return wait + time.Duration(mathrand.Int64N(int64(wait)/10+1))
+}
+
+// envelope is the JSON body receivers get. ID stays the same across retries
+// and replays so receivers can deduplicate.
+type envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (s *WebhookService) send(ctx context.Context, d domain.PendingDelivery) (int, error) {
+	body, err := json.Marshal(envelope{ID: d.ID, Type: d.EventType, CreatedAt: d.CreatedAt, Data: d.Payload})
+	if err != nil {
+		return 0, fmt.Errorf("encode body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "smart-attendence-system-webhooks")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(d.Secret, time.Now(), body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("receiver responded %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// Sign returns the signature header value for body sent at the given time.
+// Receivers recompute the HMAC over "<t>.<body>" with their secret and
+// should reject stale timestamps.
+func Sign(secret string, at time.Time, body []byte) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,247 @@
+package webhook_service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/repository/memory"
+	webhook_service "github.com/suhas-developer07/Smart-Attendence-System/server/internals/service/webhook"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+// fastConfig retries within milliseconds so the worker can be run for real.
+func fastConfig() webhook_service.Config {
+	return webhook_service.Config{
+		MaxAttempts:  3,
+		BaseBackoff:  time.Millisecond,
+		MaxBackoff:   5 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+		BatchSize:    10,
+		Lease:        time.Second,
+	}
+}
+
+// start runs the worker until the test ends.
+func start(t *testing.T, svc *webhook_service.WebhookService) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// waitFor polls the delivery until it reaches status.
+func waitFor(t *testing.T, repo *memory.MemoryRepo, deliveryID int64, status string) domain.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		// Published events are written as deliveries by the worker, so the
+		// delivery may not exist yet.
+		d, err := repo.GetDelivery(deliveryID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			t.Fatal(err)
+		}
+		if err == nil && d.Status == status {
+			return d
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery %d is %s after %d attempts, want %s", deliveryID, d.Status, d.Attempts, status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// verify checks the signature header the way a receiver should.
+func verify(r *http.Request, body []byte) bool {
+	header := r.Header.Get(webhook_service.HeaderSignature)
+	ts, _, ok := strings.Cut(strings.TrimPrefix(header, "t="), ",")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return false
+	}
+	return header == webhook_service.Sign(secret, time.Unix(unix, 0), body)
+}
+
+func TestDeliverySignedAndDelivered(t *testing.T) {
+	type received struct {
+		event    string
+		verified bool
+		body     map[string]any
+	}
+	got := make(chan received, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var decoded map[string]any
+		_ = json.Unmarshal(body, &decoded)
+		got <- received{event: r.Header.Get(webhook_service.HeaderEvent), verified: verify(r, body), body: decoded}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	repo := memory.NewMemoryRepo(nil)
+	svc := webhook_service.NewWebhookService(repo, receiver.Client(), fastConfig())
+	if _, _, err := svc.CreateWebhook(domain.WebhookPayload{
+		URL:        receiver.URL,
+		EventTypes: []string{domain.EventAttendanceCorrected},
+		Secret:     secret,
+	}); err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+
+	// Not subscribed, so nothing is queued for it.
+	svc.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceRecorded, USN: "1RV21CS001"})
+	svc.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceCorrected, USN: "1RV21CS001", Status: "Present"})
+
+	start(t, svc)
+
+	select {
+	case r := <-got:
+		if !r.verified {
+			t.Fatal("signature did not verify")
+		}
+		if r.event != domain.EventAttendanceCorrected || r.body["type"] != domain.EventAttendanceCorrected {
+			t.Fatalf("received %q with body %v", r.event, r.body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("receiver was never called")
+	}
+
+	// Events are written in order, so the unsubscribed one is done too.
+	deliveries, total, err := svc.GetDeliveries(domain.DeliveryFilter{}, domain.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || deliveries[0].EventType != domain.EventAttendanceCorrected {
+		t.Fatalf("queued deliveries = %+v", deliveries)
+	}
+
+	d := waitFor(t, repo, deliveries[0].ID, domain.DeliveryDelivered)
+	if d.Attempts != 1 || d.LastStatusCode == nil || *d.LastStatusCode != http.StatusNoContent || d.DeliveredAt == nil {
+		t.Fatalf("delivery = %+v", d)
+	}
+}
+
+func TestFailingDeliveryRetriesThenDies(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	repo := memory.NewMemoryRepo(nil)
+	svc := webhook_service.NewWebhookService(repo, receiver.Client(), fastConfig())
+	if _, _, err := svc.CreateWebhook(domain.WebhookPayload{URL: receiver.URL, EventTypes: []string{domain.WebhookAllEvents}}); err != nil {
+		t.Fatal(err)
+	}
+	svc.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceRecorded, USN: "1RV21CS001"})
+	start(t, svc)
+
+	d := waitFor(t, repo, 1, domain.DeliveryDead)
+	if d.Attempts != 3 || calls.Load() != 3 {
+		t.Fatalf("attempts = %d, calls = %d; want 3, 3", d.Attempts, calls.Load())
+	}
+	if d.LastStatusCode == nil || *d.LastStatusCode != http.StatusServiceUnavailable || d.NextAttemptAt != nil {
+		t.Fatalf("dead delivery = %+v", d)
+	}
+
+	// A replay starts over with a fresh retry budget.
+	if err := svc.ReplayDelivery(d.ID); err != nil {
+		t.Fatalf("ReplayDelivery: %v", err)
+	}
+	waitFor(t, repo, d.ID, domain.DeliveryDead)
+	if calls.Load() != 6 {
+		t.Fatalf("calls after replay = %d, want 6", calls.Load())
+	}
+}
+
+// stalledRepo holds every enqueue until released, like a database that has
+// stopped answering.
+type stalledRepo struct {
+	*memory.MemoryRepo
+	release chan struct{}
+}
+
+func (r *stalledRepo) EnqueueDeliveries(eventType string, payload []byte) (int, error) {
+	<-r.release
+	return r.MemoryRepo.EnqueueDeliveries(eventType, payload)
+}
+
+// Publish runs on the attendance request path, so it must return even while
+// the deliveries cannot be written.
+func TestPublishDoesNotWaitForTheDatabase(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	repo := &stalledRepo{MemoryRepo: memory.NewMemoryRepo(nil), release: make(chan struct{})}
+	svc := webhook_service.NewWebhookService(repo, receiver.Client(), fastConfig())
+	if _, _, err := svc.CreateWebhook(domain.WebhookPayload{URL: receiver.URL, EventTypes: []string{domain.WebhookAllEvents}}); err != nil {
+		t.Fatal(err)
+	}
+	start(t, svc)
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < 100; i++ {
+			svc.Publish(domain.AttendanceEvent{Type: domain.EventAttendanceRecorded, USN: "1RV21CS001"})
+		}
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on the stalled database")
+	}
+
+	close(repo.release)
+	waitFor(t, repo.MemoryRepo, 100, domain.DeliveryDelivered)
+}
+
+// "*" listed with other event types still subscribes to every event, and
+// the listing never carries the signing secret.
+func TestWildcardNextToOtherEvents(t *testing.T) {
+	repo := memory.NewMemoryRepo(nil)
+	svc := webhook_service.NewWebhookService(repo, http.DefaultClient, fastConfig())
+	if _, _, err := svc.CreateWebhook(domain.WebhookPayload{
+		URL: "http://example.test/hook", EventTypes: []string{domain.EventAttendanceRecorded, domain.WebhookAllEvents}, Secret: secret,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	hooks, err := svc.GetWebhooks()
+	if err != nil || len(hooks) != 1 || len(hooks[0].EventTypes) != 1 || hooks[0].EventTypes[0] != domain.WebhookAllEvents {
+		t.Fatalf("GetWebhooks = %+v, %v", hooks, err)
+	}
+	body, err := json.Marshal(hooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), secret) {
+		t.Errorf("listing %s leaks the secret", body)
+	}
+	if n, err := repo.EnqueueDeliveries(domain.EventAttendanceCorrected, []byte(`{}`)); err != nil || n != 1 {
+		t.Errorf("EnqueueDeliveries = %d, %v, want the wildcard to match", n, err)
+	}
+}
@@ -0,0 +1,132 @@
+// Package supervisor runs the HTTP server and background workers and shuts
+// them down in order on SIGINT or SIGTERM.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type worker struct {
+	name   string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type closer struct {
+	name  string
+	close func() error
+}
+
+// Supervisor owns the process lifecycle. Shutdown happens in three stages:
+// the HTTP server stops accepting and drains in-flight requests, workers are
+// stopped newest first, then resources such as the broker and database are
+// closed newest first.
+type Supervisor struct {
+	// Timeout bounds each stage of shutdown.
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	workers []*worker
+	closers []closer
+}
+
+func New(timeout time.Duration) *Supervisor {
+	return &Supervisor{Timeout: timeout}
+}
+
+// Go starts run on its own goroutine. run must return once ctx is cancelled;
+// work already underway may finish first.
+func (s *Supervisor) Go(name string, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &worker{name: name, cancel: cancel, done: make(chan struct{})}
+
+	s.mu.Lock()
+	s.workers = append(s.workers, w)
+	s.mu.Unlock()
+
+	go func() {
+		defer close(w.done)
+		run(ctx)
+	}()
+}
+
+// OnClose registers a resource to release after all workers have stopped.
+func (s *Supervisor) OnClose(name string, close func() error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closers = append(s.closers, closer{name: name, close: close})
+}
+
+// Serve runs start until it fails or a termination signal arrives, then
+// calls shutdown (e.g. echo's Shutdown) and stops everything else. It
+// returns the server's error, if any, joined with anything that went wrong
+// while stopping.
+func (s *Supervisor) Serve(start func() error, shutdown func(ctx context.Context) error) error {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- start() }()
+
+	var errs []error
+	select {
+	case got := <-sig:
+		log.Printf("supervisor: received %s, shutting down", got)
+		ctx, cancel := context.WithTimeout(context.Background(), s.Timeout)
+		if err := shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("http shutdown: %w", err))
+		}
+		cancel()
+		if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs = append(errs, err)
+		}
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs = append(errs, fmt.Errorf("http server: %w", err))
+		}
+	}
+
+	errs = append(errs, s.Stop())
+	return errors.Join(errs...)
+}
+
+// Stop stops every worker and then closes every resource. It is safe to
+// call without Serve, e.g. from a one-off command.
+func (s *Supervisor) Stop() error {
+	s.mu.Lock()
+	workers, closers := s.workers, s.closers
+	s.workers, s.closers = nil, nil
+	s.mu.Unlock()
+
+	var errs []error
+	for i := len(workers) - 1; i >= 0; i-- {
+		w := workers[i]
+		w.cancel()
+		select {
+		case <-w.done:
+			log.Printf("supervisor: stopped %s", w.name)
+		case <-time.After(s.Timeout):
+			errs = append(errs, fmt.Errorf("%s did not stop within %s", w.name, s.Timeout))
+		}
+	}
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		c := closers[i]
+		if err := c.close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", c.name, err))
+			continue
+		}
+		log.Printf("supervisor: closed %s", c.name)
+	}
+	return errors.Join(errs...)
+}
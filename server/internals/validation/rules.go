@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+	"unicode"
+)
+
+// Default formats: VTU-style USNs such as 1RV21CS001 and subject codes such
+// as CS501, 21CS51 or BCS501.
+const (
+	DefaultUSNPattern         = `^[1-9][A-Z]{2}[0-9]{2}[A-Z]{2,3}[0-9]{3}$`
+	DefaultSubjectCodePattern = `^[0-9]{0,2}[A-Z]{2,5}[0-9]{2,4}[A-Z]?$`
+)
+
+// MinPasswordLength is the shortest password accepted at registration.
+const MinPasswordLength = 8
+
+// FutureSkew is how far past the server clock a capture may be stamped
+// before it is rejected, to allow for camera clocks that drift.
+const FutureSkew = 5 * time.Minute
+
+// Rules are the institution-specific formats behind the usn and
+// subject_code tags.
+type Rules struct {
+	USNPattern         string
+	SubjectCodePattern string
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		USNPattern:         DefaultUSNPattern,
+		SubjectCodePattern: DefaultSubjectCodePattern,
+	}
+}
+
+// departmentFormat is the shape of a department code; which codes exist is
+// up to the departments table.
+var departmentFormat = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+
+var (
+	usnFormat, subjectCodeFormat *regexp.Regexp
+	now                          = time.Now
+)
+
+func init() {
+	if err := Configure(DefaultRules()); err != nil {
+		panic(err)
+	}
+}
+
+// Configure sets the formats every validator checks. Like the JWT settings
+// it must run before requests are served.
+func Configure(r Rules) error {
+	usn, err := regexp.Compile(r.USNPattern)
+	if err != nil {
+		return fmt.Errorf("usn pattern: %w", err)
+	}
+	code, err := regexp.Compile(r.SubjectCodePattern)
+	if err != nil {
+		return fmt.Errorf("subject code pattern: %w", err)
+	}
+	usnFormat, subjectCodeFormat = usn, code
+	return nil
+}
+
+func ValidUSN(s string) bool { return usnFormat.MatchString(s) }
+
+func ValidSubjectCode(s string) bool { return subjectCodeFormat.MatchString(s) }
+
+func ValidDepartmentCode(s string) bool { return departmentFormat.MatchString(s) }
+
+// StrongPassword requires MinPasswordLength characters with at least one
+// letter and one digit.
+func StrongPassword(s string) bool {
+	var letter, digit bool
+	for _, r := range s {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+	return len([]rune(s)) >= MinPasswordLength && letter && digit
+}
+
+// NotFuture accepts the zero time, which required reports instead.
+func NotFuture(t time.Time) bool {
+	return t.IsZero() || !t.After(now().Add(FutureSkew))
+}
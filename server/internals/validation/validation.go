@@ -0,0 +1,141 @@
+// Package validation builds the payload validator the services share and
+// turns its failures into domain validation errors with per-field details.
+//
+// Besides validator's built-in tags it understands usn and subject_code
+// (the institution formats set with Configure), department (the shape of a
+// department code), password (see StrongPassword) and notfuture for capture
+// timestamps.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+)
+
+// New returns a validator that reports fields by their JSON names.
+func New() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return f.Name
+		}
+		return name
+	})
+
+	str := func(check func(string) bool) validator.Func {
+		return func(fl validator.FieldLevel) bool { return check(fl.Field().String()) }
+	}
+	must(v.RegisterValidation("usn", str(ValidUSN)))
+	must(v.RegisterValidation("subject_code", str(ValidSubjectCode)))
+	must(v.RegisterValidation("department", str(ValidDepartmentCode)))
+	must(v.RegisterValidation("password", str(StrongPassword)))
+	must(v.RegisterValidation("notfuture", func(fl validator.FieldLevel) bool {
+		t, ok := fl.Field().Interface().(time.Time)
+		return ok && NotFuture(t)
+	}))
+	return v
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Error converts the result of Struct into a domain validation error; nil
+// and errors other than validator.ValidationErrors pass through unchanged.
+func Error(err error) error {
+	return fieldError("", "", err)
+}
+
+// Var is Error for the result of validating a single value with Var, which
+// carries no field name of its own.
+func Var(field string, err error) error {
+	return fieldError("", field, err)
+}
+
+// Item is Error for element i of a list payload, so its fields read like
+// "[2].usn".
+func Item(i int, err error) error {
+	return fieldError(fmt.Sprintf("[%d].", i), "", err)
+}
+
+func fieldError(prefix, field string, err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+	fields := make([]domain.FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		name := field
+		if name == "" {
+			name = prefix + fieldName(fe)
+		}
+		fields = append(fields, domain.FieldError{Field: name, Rule: fe.Tag(), Message: message(name, fe)})
+	}
+	return domain.Validation(fields...)
+}
+
+// fieldName drops the struct name from the namespace, so nested and slice
+// fields read like "items[0].usn".
+func fieldName(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return rest
+	}
+	return ns
+}
+
+func message(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", field, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "email":
+		return field + " must be a valid email address"
+	case "url":
+		return field + " must be a valid URL"
+	case "usn":
+		return fmt.Sprintf("%s %q is not a valid USN", field, fe.Value())
+	case "subject_code":
+		return fmt.Sprintf("%s %q is not a valid subject code", field, fe.Value())
+	case "department":
+		return fmt.Sprintf("%s %q is not a valid department code", field, fe.Value())
+	case "password":
+		return fmt.Sprintf("%s must be at least %d characters and contain a letter and a digit", field, MinPasswordLength)
+	case "notfuture":
+		return field + " must not be in the future"
+	case "min", "gte":
+		if unit := lengthUnit(fe.Kind()); unit != "" {
+			return fmt.Sprintf("%s must have at least %s %s", field, fe.Param(), unit)
+		}
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "max", "lte":
+		if unit := lengthUnit(fe.Kind()); unit != "" {
+			return fmt.Sprintf("%s must have at most %s %s", field, fe.Param(), unit)
+		}
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
+	}
+	return fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+}
+
+func lengthUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	}
+	return ""
+}
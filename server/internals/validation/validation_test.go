@@ -0,0 +1,88 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/domain"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+)
+
+func TestStudentRegisterFieldErrors(t *testing.T) {
+	v := validation.New()
+	err := validation.Error(v.Struct(domain.StudentRegisterPayload{
+		USN:        "1cs21001",
+		Username:   "asha",
+		Password:   "secret",
+		Department: "Computer Science",
+		Sem:        9,
+	}))
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.CodeValidation {
+		t.Fatalf("err = %v, want a validation error", err)
+	}
+	got := map[string]string{}
+	for _, f := range derr.Fields {
+		got[f.Field] = f.Rule
+	}
+	want := map[string]string{"usn": "usn", "password": "password", "department": "department", "sem": "max"}
+	for field, rule := range want {
+		if got[field] != rule {
+			t.Errorf("field %s failed %q, want %q (all: %v)", field, got[field], rule, got)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+}
+
+func TestRules(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ok   bool
+	}{
+		{"1RV21CS001", true},
+		{"1MS22ISE042", true},
+		{"1CS21001", false},
+		{"1rv21cs001", false},
+	} {
+		if got := validation.ValidUSN(tt.name); got != tt.ok {
+			t.Errorf("ValidUSN(%q) = %v, want %v", tt.name, got, tt.ok)
+		}
+	}
+	for _, tt := range []struct {
+		code string
+		ok   bool
+	}{
+		{"CS501", true},
+		{"21CS51", true},
+		{"BCS501", true},
+		{"CS-501", false},
+	} {
+		if got := validation.ValidSubjectCode(tt.code); got != tt.ok {
+			t.Errorf("ValidSubjectCode(%q) = %v, want %v", tt.code, got, tt.ok)
+		}
+	}
+	if validation.StrongPassword("password") || !validation.StrongPassword("secret123") {
+		t.Error("StrongPassword must require a letter, a digit and 8 characters")
+	}
+	if validation.NotFuture(time.Now().Add(time.Hour)) || !validation.NotFuture(time.Now()) {
+		t.Error("NotFuture must reject captures an hour ahead and accept now")
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { validation.Configure(validation.DefaultRules()) })
+
+	if err := validation.Configure(validation.Rules{USNPattern: "(", SubjectCodePattern: ".*"}); err == nil {
+		t.Error("Configure accepted an invalid usn pattern")
+	}
+	if err := validation.Configure(validation.Rules{USNPattern: `^U[0-9]{4}$`, SubjectCodePattern: ".*"}); err != nil {
+		t.Fatal(err)
+	}
+	if !validation.ValidUSN("U1234") || validation.ValidUSN("1RV21CS001") {
+		t.Error("Configure did not replace the rules")
+	}
+}
@@ -7,40 +7,72 @@ import (
 	 "github.com/labstack/echo/v4/middleware"
 
 
-	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/suhas-developer07/Smart-Attendence-System/server/cmd"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/config"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/supervisor"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/internals/validation"
+	"github.com/suhas-developer07/Smart-Attendence-System/server/pkg/utils"
 )
 
 func main() {
 	fmt.Println("Server is running...")
 
-	err := godotenv.Load()
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Error Loading env file: %v", err)
+		log.Fatalf("Error loading config: %v", err)
 	}
 
-	DatabaseUrl := os.Getenv("DatabaseURL")
-
-	if DatabaseUrl == "" {
-		log.Fatalf("DatabaseUrl not found in env file ")
+	utils.ConfigureJWT(cfg.JWT.Secret, cfg.JWT.Issuer, cfg.JWT.TTL)
+	if err := validation.Configure(cfg.Institution.Rules()); err != nil {
+		log.Fatalf("Error configuring validation: %v", err)
 	}
 
-	Database, err := cmd.ConnectToDB(DatabaseUrl)
+	Database, err := cmd.ConnectToDB(cfg.Database)
 
 	if err != nil {
 		log.Fatalf("Failed to innitialize database.:%v", err)
 	}
 
-	defer Database.Close()
+	store := cmd.NewStore(Database, cfg)
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := cmd.RunMigrate(store, os.Args[2:])
+		Database.Close()
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "repair-dates" {
+		err := store.CheckSchema()
+		if err == nil {
+			err = cmd.RunRepairDates(store, os.Args[2:])
+		}
+		Database.Close()
+		if err != nil {
+			log.Fatalf("Date repair failed: %v", err)
+		}
+		return
+	}
+
+	// Closers run newest first, so the database outlives the broker.
+	sup := supervisor.New(cfg.Server.ShutdownTimeout)
+	sup.OnClose("database", Database.Close)
+
+	if cfg.Broker.URL != "" {
+		broker := cmd.NewRabbitmqConnection(cfg.Broker)
+		sup.OnClose("rabbitmq", broker.Close)
+	}
+
 
-	
 
     e := echo.New()
 
 
     e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-    AllowOrigins: []string{"*"}, 
+    AllowOrigins: cfg.Server.CORSOrigins,
     AllowMethods: []string{
         echo.GET,
         echo.POST,
@@ -57,9 +89,17 @@ func main() {
     },
     }))
 
-    cmd.SetupRoutes(e, Database)
+    cmd.SetupRoutes(e, store, cfg, sup)
 
-    e.Logger.Fatal(e.Start(":8080"))
+    start := func() error { return e.Start(cfg.Server.Addr()) }
+    if cfg.Server.TLS() {
+        start = func() error {
+            return e.StartTLS(cfg.Server.Addr(), cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+        }
+    }
 
-	e.Logger.Fatal(e.Start(":8080"))
+    if err := sup.Serve(start, e.Shutdown); err != nil {
+        log.Fatalf("Server stopped with errors: %v", err)
+    }
+    log.Println("Server stopped")
 }
@@ -1,12 +1,65 @@
 package utils
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtKey = []byte("KALI_LINUX")
+var (
+	jwtKey    []byte
+	jwtIssuer = "smart-attendence-system"
+	jwtTTL    time.Duration
+)
+
+var errJWTNotConfigured = errors.New("jwt signing key is not configured")
+
+// ConfigureJWT sets the signing key, issuer and lifetime used for every
+// token. It must run before tokens are issued or checked; a zero ttl issues
+// tokens that never expire.
+func ConfigureJWT(secret, issuer string, ttl time.Duration) {
+	jwtKey = []byte(secret)
+	jwtIssuer = issuer
+	jwtTTL = ttl
+}
+
+func registeredClaims() jwt.RegisteredClaims {
+	now := time.Now()
+	rc := jwt.RegisteredClaims{
+		IssuedAt: jwt.NewNumericDate(now),
+		Issuer:   jwtIssuer,
+	}
+	if jwtTTL > 0 {
+		rc.ExpiresAt = jwt.NewNumericDate(now.Add(jwtTTL))
+	}
+	return rc
+}
+
+func signToken(claims jwt.Claims) (string, error) {
+	if len(jwtKey) == 0 {
+		return "", errJWTNotConfigured
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+}
+
+// ParseToken verifies tokenStr against the configured key and issuer and
+// decodes it into claims. Expiry is checked when the token carries one.
+func ParseToken(tokenStr string, claims jwt.Claims) error {
+	if len(jwtKey) == 0 {
+		return errJWTNotConfigured
+	}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(jwtIssuer))
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return errors.New("invalid token")
+	}
+	return nil
+}
 type StudentClaims struct {
 	StudentID int64  `json:"student_id"`
 	USN       string `json:"usn"`
@@ -17,17 +70,9 @@ func GenerateTokenForStudent(student_id int64 ,usn string) (string, error) {
 	Claims := &StudentClaims{
 		StudentID: student_id,
 		USN:       usn,
-		RegisteredClaims: jwt.RegisteredClaims{
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "smart-attendence-system",
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims)
-	tokenString, err := token.SignedString(jwtKey)
-	if err != nil {
-		return "", err
+		RegisteredClaims: registeredClaims(),
 	}
-	return tokenString, nil
+	return signToken(Claims)
 }
 
 type FacultyClaims struct {
@@ -39,15 +84,39 @@ func GenerateTokenForFaculty(faculty_id int64 ,email string) (string, error) {
 	Claims := &FacultyClaims{
 		FacultyID: faculty_id,
 		Email:     email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "smart-attendence-system",
-		},
+		RegisteredClaims: registeredClaims(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims)
-	tokenString, err := token.SignedString(jwtKey)
-	if err != nil {
-		return "", err
+	return signToken(Claims)
+}
+
+type AdminClaims struct {
+	AdminID int64  `json:"admin_id"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	jwt.RegisteredClaims
+}
+func GenerateTokenForAdmin(admin_id int64, email string) (string, error) {
+	Claims := &AdminClaims{
+		AdminID: admin_id,
+		Email:   email,
+		Role:    "admin",
+		RegisteredClaims: registeredClaims(),
 	}
-	return tokenString, nil
-}
\ No newline at end of file
+	return signToken(Claims)
+}
+
+type GuardianClaims struct {
+	GuardianID int64  `json:"guardian_id"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	jwt.RegisteredClaims
+}
+func GenerateTokenForGuardian(guardian_id int64, email string) (string, error) {
+	Claims := &GuardianClaims{
+		GuardianID: guardian_id,
+		Email:      email,
+		Role:       "guardian",
+		RegisteredClaims: registeredClaims(),
+	}
+	return signToken(Claims)
+}